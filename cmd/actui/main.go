@@ -4,51 +4,48 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"container-tui/src/cli"
 	"container-tui/src/services"
 	"container-tui/src/ui"
 )
 
 var version = "0.1.12"
 
+// fakeBackendLatency paces simulated progress and log output in demo mode.
+const fakeBackendLatency = 150 * time.Millisecond
+
 func main() {
-	var dryRun bool
+	var options runtimeOptions
+	var headless *runtime
 
 	rootCmd := &cobra.Command{
 		Use:   "actui",
 		Short: "Apple Container TUI",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := services.CheckCLI(context.Background()); err != nil {
-				return err
-			}
-
-			var executor services.CommandExecutor
-			if dryRun {
-				executor = services.DryRunExecutor{}
-			} else {
-				executor = services.RealExecutor{}
-			}
-
-			configManager, err := services.NewConfigManager()
+			rt, err := newRuntime(options, fakeBackendLatency, cmd.ErrOrStderr())
 			if err != nil {
 				return err
 			}
-			config, _, err := configManager.Load()
+			executor := rt.executor
+			ui.ApplyTheme(rt.config.ThemeMode)
+			ui.ApplyEffectiveConfig(rt.effective)
+			ui.ApplyRedactor(rt.redactor)
+			confirmations, err := services.NewConfirmationPolicy(rt.config.ConfirmDestructiveActions, rt.config.Confirmations)
 			if err != nil {
-				return err
-			}
-			ui.ApplyTheme(config.ThemeMode)
-			logWriter, err := services.NewLogWriter(config.LogRetentionDays)
-			if err != nil {
-				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "warning: failed to initialize command log writer")
-			} else {
-				executor = services.NewLoggingExecutor(executor, logWriter, dryRun)
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "warning: "+err.Error())
 			}
+			ui.ApplyConfirmationPolicy(confirmations)
+			ui.ApplyProfiles(rt.profiles)
+			ui.ApplyConfigManager(rt.configManager)
+			ui.KeepProfileSwitchesForSession(sessionProfileReason(options, rt.config))
+			ui.ApplyCapabilities(rt.detectCapabilities(cmd.ErrOrStderr()), rt.capabilities)
 
-			if !dryRun {
+			if !options.dryRun {
 				statusBuilder := services.CheckDaemonStatusBuilder{}
 				statusCmd, buildErr := statusBuilder.Build()
 				if buildErr == nil {
@@ -63,21 +60,94 @@ func main() {
 			}
 
 			program := tea.NewProgram(ui.NewAppModel(executor, version), tea.WithAltScreen())
-			if _, err := program.Run(); err != nil {
+			watchCtx, stopWatching := context.WithCancel(context.Background())
+			defer stopWatching()
+			if changes, err := rt.configManager.Watch(watchCtx); err != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "warning: config changes need a restart: "+err.Error())
+			} else {
+				go func() {
+					for change := range changes {
+						program.Send(rt.reloadConfig(change))
+					}
+				}()
+			}
+			_, err = program.Run()
+			rt.close()
+			if err != nil {
 				return err
 			}
+			rt.reportDrift(cmd.ErrOrStderr())
 
 			return nil
 		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			headless.reportDrift(cmd.ErrOrStderr())
+			headless.close()
+		},
 	}
 
-	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "preview commands without executing")
+	flags := rootCmd.PersistentFlags()
+	flags.BoolVar(&options.dryRun, "dry-run", false, "preview commands without executing")
+	flags.BoolVar(&options.readOnly, "read-only", false, "block every command that changes runtime state")
+	flags.StringVar(&options.backend, "backend", "cli", "command backend: cli runs the container CLI, fake simulates it in memory")
+	flags.StringVar(&options.recordPath, "record", "", "append every command and result to a session fixture file")
+	flags.StringVar(&options.configPath, "config", "", "config file to read and write instead of the standard locations (also $ACTUI_CONFIG)")
+	flags.StringVar(&options.profile, "profile", "", "connection profile to run the container CLI with (also $ACTUI_PROFILE)")
+	flags.StringVar(&options.replayPath, "replay", "", "serve results from a recorded session fixture instead of running commands")
+
+	headlessRuntime := func() (*runtime, error) {
+		if headless == nil {
+			rt, err := newRuntime(options, 0, rootCmd.ErrOrStderr())
+			if err != nil {
+				return nil, err
+			}
+			rt.retries.SetNotify(func(state services.RetryState) {
+				_, _ = fmt.Fprintf(rootCmd.ErrOrStderr(), "%s: %s\n", state, state.Err)
+			})
+			headless = rt
+		}
+		return headless, nil
+	}
+	env := cli.Environment{
+		Executor: func() (services.CommandExecutor, error) {
+			rt, err := headlessRuntime()
+			if err != nil {
+				return nil, err
+			}
+			return rt.executor, nil
+		},
+		Capabilities: func() services.Capabilities {
+			rt, err := headlessRuntime()
+			if err != nil {
+				return services.Capabilities{}
+			}
+			return rt.probeCapabilities(rootCmd.ErrOrStderr())
+		},
+		DryRun: func() bool { return options.dryRun },
+		Confirmations: func() services.ConfirmationPolicy {
+			rt, err := headlessRuntime()
+			if err != nil {
+				return services.DefaultConfirmationPolicy()
+			}
+			policy, err := services.NewConfirmationPolicy(rt.config.ConfirmDestructiveActions, rt.config.Confirmations)
+			if err != nil {
+				_, _ = fmt.Fprintln(rootCmd.ErrOrStderr(), "warning: "+err.Error())
+			}
+			return policy
+		},
+		Config: func() (*services.ConfigManager, error) {
+			return newConfigManager(options.configPath)
+		},
+	}
+	rootCmd.AddCommand(cli.NewListCommands(env)...)
+	rootCmd.AddCommand(cli.NewContainerCommand(env), cli.NewImageCommand(env), cli.NewMachineCommand(env), cli.NewAuditCommand(), cli.NewConfigCommand(env))
+	rootCmd.SetFlagErrorFunc(cli.FlagError)
 
 	rootCmd.Version = version
 	rootCmd.SetVersionTemplate("actui version {{.Version}}\n")
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+	"container-tui/src/ui"
+)
+
+// runtimeOptions holds the persistent flags that shape the executor chain.
+type runtimeOptions struct {
+	dryRun     bool
+	readOnly   bool
+	backend    string
+	recordPath string
+	replayPath string
+	configPath string
+	profile    string
+}
+
+// runtime is the executor chain and configuration shared by the TUI and headless commands.
+type runtime struct {
+	executor      services.CommandExecutor
+	replay        *services.ReplayExecutor
+	config        models.UserConfig
+	effective     services.EffectiveConfig
+	redactor      *services.Redactor
+	configManager *services.ConfigManager
+	timeouts      *services.TimeoutExecutor
+	retries       *services.RetryingExecutor
+	logWriter     *services.LogWriter
+	profiles      *services.ProfileSet
+	capabilities  *services.CapabilityService
+}
+
+// capabilityProbeTimeout bounds probing the CLI at startup.
+const capabilityProbeTimeout = 15 * time.Second
+
+// newRuntime loads the user config and wraps the selected backend in the policy, timeout,
+// logging, retry and error classifying middleware. Warnings are written to stderr.
+func newRuntime(options runtimeOptions, latency time.Duration, stderr io.Writer) (*runtime, error) {
+	configManager, err := newConfigManager(options.configPath)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := configManager.Effective()
+	if err != nil {
+		return nil, err
+	}
+	config, used := effective.Config, effective.UserPath
+	if used == "" && len(configManager.ReadPaths) == 1 {
+		_, _ = fmt.Fprintf(stderr, "warning: config file %s not found; using defaults\n", configManager.ReadPaths[0])
+	}
+	redactor, err := services.NewRedactor(config.RedactPatterns)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "warning: "+err.Error())
+	}
+
+	profiles, err := services.NewProfileSet(config, options.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, replay, err := newBackend(options.backend, options.replayPath, latency, profiles)
+	if err != nil {
+		return nil, err
+	}
+	if replay != nil {
+		replay.SetRedactor(redactor)
+	}
+	if options.recordPath != "" {
+		recorder := services.NewRecordingExecutor(executor, options.recordPath)
+		recorder.SetRedactor(redactor)
+		executor = recorder
+	}
+	capabilities := newCapabilityService(options, executor, replay)
+	if options.dryRun {
+		executor = services.DryRunExecutor{}
+	}
+	policy := services.ExecutionPolicy{
+		ReadOnly: options.readOnly || config.ReadOnly,
+		Allow:    config.Policy.Allow,
+		Deny:     config.Policy.Deny,
+	}
+	if !policy.IsZero() {
+		executor = services.NewPolicyExecutor(executor, policy)
+	}
+	timeouts := services.NewTimeoutExecutor(executor, services.NewCommandTimeouts(config.CommandTimeouts))
+	executor = timeouts
+	logWriter, err := services.NewLogWriter(config.LogRetentionDays)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "warning: failed to initialize command log writer")
+		logWriter = nil
+	} else {
+		logWriter.SetRedactor(redactor)
+		logWriter.SetLimits(logLimits(config))
+		if config.Audit {
+			logWriter.EnableAudit(services.NewAuditMetadata(version))
+		}
+		logging := services.NewLoggingExecutor(executor, logWriter, options.dryRun)
+		logging.SetProfiles(profiles)
+		executor = logging
+	}
+	retries := services.NewRetryingExecutor(executor, services.NewRetryPolicy(config.Retry))
+	executor = services.NewClassifyingExecutor(retries)
+	return &runtime{
+		executor:      executor,
+		replay:        replay,
+		config:        config,
+		effective:     effective,
+		redactor:      redactor,
+		configManager: configManager,
+		timeouts:      timeouts,
+		retries:       retries,
+		logWriter:     logWriter,
+		profiles:      profiles,
+		capabilities:  capabilities,
+	}, nil
+}
+
+// sessionProfileReason says why a profile switched to in the TUI is not saved: the run
+// changes nothing, or its profile was picked for this run only. It is "" when the switch
+// is saved.
+func sessionProfileReason(options runtimeOptions, config models.UserConfig) string {
+	switch {
+	case options.dryRun:
+		return "dry run"
+	case options.readOnly || config.ReadOnly:
+		return "read-only"
+	case options.profile != "":
+		return "--profile is set"
+	case os.Getenv(services.EnvVarName("profile")) != "":
+		return services.EnvVarName("profile") + " is set"
+	}
+	return ""
+}
+
+func logLimits(config models.UserConfig) services.LogLimits {
+	return services.LogLimits{
+		MaxSegmentBytes: int64(config.LogSegmentSizeMB) << 20,
+		MaxTotalBytes:   int64(config.LogMaxSizeMB) << 20,
+		Compress:        config.LogCompress,
+	}
+}
+
+// reloadConfig applies a reloaded config to the command timeouts, the retry policy and the
+// command log, and returns the message that applies it to the TUI. Keys that shape the
+// executor chain are compared with the config actui started with and reported as needing
+// a restart; a profile saved by switching to it in the TUI is already applied.
+func (r *runtime) reloadConfig(change services.ConfigChange) ui.ConfigChangedMsg {
+	message := ui.ConfigChangedMsg{Change: change}
+	if !change.OK() {
+		return message
+	}
+	config := change.Effective.Config
+	r.timeouts.SetTimeouts(services.NewCommandTimeouts(config.CommandTimeouts))
+	r.retries.SetPolicy(services.NewRetryPolicy(config.Retry))
+	if r.logWriter != nil {
+		redactor, _ := services.NewRedactor(config.RedactPatterns)
+		r.logWriter.SetRedactor(redactor)
+		r.logWriter.SetRetention(config.LogRetentionDays)
+		r.logWriter.SetLimits(logLimits(config))
+	}
+	previous := r.config
+	if r.profiles != nil && config.Profile == r.profiles.Active().Name {
+		previous.Profile = config.Profile
+	}
+	message.Restart = services.RestartRequiredKeys(previous, config)
+	return message
+}
+
+// newConfigManager uses the --config file, then $ACTUI_CONFIG, then the standard locations,
+// and merges the project config found from the working directory over it.
+func newConfigManager(path string) (*services.ConfigManager, error) {
+	if path == "" {
+		path = os.Getenv(services.EnvPrefix + "CONFIG")
+	}
+	var manager *services.ConfigManager
+	if path != "" {
+		manager = services.NewConfigManagerAt(path)
+	} else {
+		var err error
+		if manager, err = services.NewConfigManager(); err != nil {
+			return nil, err
+		}
+	}
+	if dir, err := os.Getwd(); err == nil {
+		manager.UseProject(dir)
+	}
+	return manager, nil
+}
+
+// newCapabilityService probes the CLI below the logging, policy and dry-run middleware.
+// Recordings keep the probes, uncached, so a replay picks the output formats the recorded
+// session used; fixtures without them replay with unknown capabilities.
+func newCapabilityService(options runtimeOptions, executor services.CommandExecutor, replay *services.ReplayExecutor) *services.CapabilityService {
+	if replay != nil {
+		if !replay.Has(services.CapabilityVersionCommand()) {
+			return nil
+		}
+		return services.NewCapabilityService(executor, "")
+	}
+	cachePath := ""
+	if options.backend == "cli" && options.recordPath == "" {
+		cachePath, _ = services.DefaultCapabilityCachePath()
+	}
+	return services.NewCapabilityService(executor, cachePath)
+}
+
+// detectCapabilities probes the container CLI and prints its compatibility report. Replays
+// have no CLI to probe and return unknown capabilities.
+func (r *runtime) detectCapabilities(stderr io.Writer) services.Capabilities {
+	detected := r.probeCapabilities(stderr)
+	_, _ = fmt.Fprint(stderr, detected.CompatibilityReport())
+	return detected
+}
+
+// probeCapabilities probes the container CLI, warning on stderr when it cannot.
+func (r *runtime) probeCapabilities(stderr io.Writer) services.Capabilities {
+	if r.capabilities == nil {
+		return services.Capabilities{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), capabilityProbeTimeout)
+	defer cancel()
+	detected, err := r.capabilities.Detect(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "warning: could not detect container CLI capabilities: "+err.Error())
+	}
+	return detected
+}
+
+// close closes the remote connections of the executor chain.
+func (r *runtime) close() {
+	if r == nil {
+		return
+	}
+	_ = services.CloseExecutor(r.executor)
+}
+
+// reportDrift prints the replay drift report, if a replay was active.
+func (r *runtime) reportDrift(stderr io.Writer) {
+	if r == nil || r.replay == nil {
+		return
+	}
+	_, _ = fmt.Fprint(stderr, r.replay.DriftReport())
+}
+
+// newBackend selects the executor that answers commands. When replaying, the chosen backend
+// only answers commands missing from the recording, and only if it is the fake backend. The
+// CLI backend runs commands through the active profile, below the recorder so fixtures keep
+// the plain `container` commands.
+func newBackend(backend string, replayPath string, latency time.Duration, profiles *services.ProfileSet) (services.CommandExecutor, *services.ReplayExecutor, error) {
+	var executor services.CommandExecutor
+	switch backend {
+	case "cli":
+		if replayPath == "" {
+			profileExecutor := services.NewProfileExecutor(services.RealExecutor{}, profiles)
+			if err := profileExecutor.CheckCLI(context.Background()); err != nil {
+				_ = profileExecutor.Close()
+				return nil, nil, err
+			}
+			executor = profileExecutor
+		}
+	case "fake":
+		fake := services.NewFakeBackend()
+		fake.Latency = latency
+		executor = fake
+	default:
+		return nil, nil, fmt.Errorf("unknown backend %q (use cli or fake)", backend)
+	}
+	if replayPath == "" {
+		return executor, nil, nil
+	}
+
+	entries, err := services.ReadFixture(replayPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load replay fixture: %w", err)
+	}
+	replay := services.NewReplayExecutor(entries, executor)
+	return replay, replay, nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/services"
+)
+
+// NewAuditCommand returns the `audit` command group.
+func NewAuditCommand() *cobra.Command {
+	group := &cobra.Command{Use: "audit", Short: "Inspect the command audit trail"}
+	group.AddCommand(newAuditVerifyCommand())
+	return group
+}
+
+func newAuditVerifyCommand() *cobra.Command {
+	var logPath string
+	var output string
+	cmd := &cobra.Command{
+		Use:           "verify",
+		Short:         "Check the command log for gaps, edits or truncation",
+		Args:          exactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			if logPath == "" {
+				if logPath, err = services.DefaultLogPath(); err != nil {
+					return err
+				}
+			}
+			report, err := services.VerifyAuditLog(logPath)
+			if err != nil {
+				return err
+			}
+			if format == OutputTable {
+				printAuditReport(cmd, report)
+			} else if err := writeOutput(cmd.OutOrStdout(), format, report, tableRows{}); err != nil {
+				return err
+			}
+			if !report.OK() {
+				return ErrAuditFailed
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&logPath, "log", "", "command log to verify (default: the actui command log)")
+	addOutputFlag(cmd, &output)
+	return cmd
+}
+
+func printAuditReport(cmd *cobra.Command, report services.AuditReport) {
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Segments: %d (%d sealed, %d expired by retention)\n", report.Segments, report.Sealed, report.Expired)
+	_, _ = fmt.Fprintf(out, "Entries:  %d (%d audited", report.Entries, report.Audited)
+	if report.Audited > 0 {
+		_, _ = fmt.Fprintf(out, ", seq %d-%d", report.FirstSequence, report.LastSequence)
+	}
+	_, _ = fmt.Fprintln(out, ")")
+	if report.OK() {
+		_, _ = fmt.Fprintln(out, "OK: no gaps, edits or truncation found")
+		return
+	}
+	_, _ = fmt.Fprintf(out, "FAILED: %d problem(s) found\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		_, _ = fmt.Fprintln(out, "  "+issue.String())
+	}
+}
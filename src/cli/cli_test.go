@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+func fakeEnvironment(backend *services.FakeBackend, dryRun bool) Environment {
+	return Environment{
+		Executor: func() (services.CommandExecutor, error) { return backend, nil },
+		DryRun:   func() bool { return dryRun },
+	}
+}
+
+func runHeadless(t *testing.T, env Environment, args ...string) (string, error) {
+	t.Helper()
+	root := &cobra.Command{Use: "actui", SilenceUsage: true, SilenceErrors: true}
+	root.AddCommand(NewListCommands(env)...)
+	root.AddCommand(NewAuditCommand())
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestListCommandsRenderEachFormat(t *testing.T) {
+	env := fakeEnvironment(services.NewFakeBackend(), false)
+
+	table, err := runHeadless(t, env, "containers")
+	if err != nil {
+		t.Fatalf("containers: %v", err)
+	}
+	if !strings.HasPrefix(table, "ID") || !strings.Contains(table, "web") {
+		t.Fatalf("unexpected table output:\n%s", table)
+	}
+
+	out, err := runHeadless(t, env, "machines", "--output", "json")
+	if err != nil {
+		t.Fatalf("machines: %v", err)
+	}
+	var machines []machineView
+	if err := json.Unmarshal([]byte(out), &machines); err != nil || len(machines) != 2 || machines[0].ID != "default" {
+		t.Fatalf("unexpected machines json %v (%v):\n%s", machines, err, out)
+	}
+
+	out, err = runHeadless(t, env, "images", "-o", "yaml")
+	if err != nil {
+		t.Fatalf("images: %v", err)
+	}
+	var images []imageView
+	if err := yaml.Unmarshal([]byte(out), &images); err != nil || len(images) == 0 || images[0].Reference == "" {
+		t.Fatalf("unexpected images yaml %v (%v):\n%s", images, err, out)
+	}
+
+	out, err = runHeadless(t, env, "status", "-o", "json")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	var status statusView
+	if err := json.Unmarshal([]byte(out), &status); err != nil || !status.Running {
+		t.Fatalf("unexpected status %+v (%v)", status, err)
+	}
+}
+
+func TestListCommandsUseProbedJSONOutput(t *testing.T) {
+	backend := services.NewFakeBackend()
+	env := fakeEnvironment(backend, false)
+	env.Capabilities = func() services.Capabilities {
+		detected, err := services.NewCapabilityService(backend, "").Detect(context.Background())
+		if err != nil {
+			t.Fatalf("detect: %v", err)
+		}
+		return detected
+	}
+
+	out, err := runHeadless(t, env, "containers", "-o", "json")
+	if err != nil {
+		t.Fatalf("containers: %v", err)
+	}
+	var containers []containerView
+	if err := json.Unmarshal([]byte(out), &containers); err != nil || len(containers) != 3 {
+		t.Fatalf("unexpected containers json %v (%v):\n%s", containers, err, out)
+	}
+	if containers[0].Platform != "linux/arm64" || len(containers[0].Networks) != 1 || containers[0].Labels["com.example.demo"] != "true" {
+		t.Fatalf("expected JSON-only details, got %+v", containers[0])
+	}
+
+	out, err = runHeadless(t, env, "images", "-o", "json")
+	if err != nil {
+		t.Fatalf("images: %v", err)
+	}
+	var images []imageView
+	if err := json.Unmarshal([]byte(out), &images); err != nil || len(images) == 0 || images[0].Size == 0 || images[0].Created == nil {
+		t.Fatalf("expected image sizes, got %v (%v)", images, err)
+	}
+
+	env.DryRun = func() bool { return true }
+	if out, _ := runHeadless(t, env, "containers"); strings.TrimSpace(out) != "container list --all" {
+		t.Fatalf("expected dry runs to plan table output, got %q", out)
+	}
+}
+
+func TestListCommandsRejectUnknownFormat(t *testing.T) {
+	_, err := runHeadless(t, fakeEnvironment(services.NewFakeBackend(), false), "registries", "-o", "xml")
+	if err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
+
+func TestListCommandsDryRunPrintsPlan(t *testing.T) {
+	env := Environment{
+		Executor: func() (services.CommandExecutor, error) {
+			t.Fatal("dry run must not build an executor")
+			return nil, nil
+		},
+		DryRun: func() bool { return true },
+	}
+	out, err := runHeadless(t, env, "images")
+	if err != nil {
+		t.Fatalf("images: %v", err)
+	}
+	if strings.TrimSpace(out) != "container image list" {
+		t.Fatalf("unexpected plan %q", out)
+	}
+}
+
+func TestListCommandsFormatRuntimeErrors(t *testing.T) {
+	backend := services.NewFakeBackend()
+	stop, err := services.StopDaemonBuilder{}.Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if _, err := backend.Execute(stop); err != nil {
+		t.Fatalf("stop daemon: %v", err)
+	}
+	_, err = runHeadless(t, fakeEnvironment(backend, false), "containers")
+	if err == nil || !strings.Contains(err.Error(), "daemon is not running") {
+		t.Fatalf("expected daemon error, got %v", err)
+	}
+}
+
+func runMutation(t *testing.T, env Environment, stdin string, args ...string) (string, error) {
+	t.Helper()
+	root := &cobra.Command{Use: "actui", SilenceUsage: true, SilenceErrors: true}
+	root.AddCommand(NewListCommands(env)...)
+	root.AddCommand(NewContainerCommand(env), NewImageCommand(env), NewMachineCommand(env), NewConfigCommand(env))
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(args)
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestMutationCommandsConfirmBeforeRunning(t *testing.T) {
+	backend := services.NewFakeBackend()
+	env := fakeEnvironment(backend, false)
+
+	_, err := runMutation(t, env, "", "container", "stop", "web")
+	if ExitCode(err) != ExitAborted {
+		t.Fatalf("expected aborted without an answer, got %v", err)
+	}
+	_, err = runMutation(t, env, "wrong\n", "container", "delete", "scratchpad")
+	if ExitCode(err) != ExitAborted {
+		t.Fatalf("expected mismatched type-to-confirm to abort, got %v", err)
+	}
+	if _, err := runMutation(t, env, "y\n", "container", "stop", "web"); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if _, err := runMutation(t, env, "", "container", "delete", "web", "--yes"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	out, err := runHeadless(t, env, "containers")
+	if err != nil || strings.Contains(out, "web ") {
+		t.Fatalf("expected web to be deleted, got %v:\n%s", err, out)
+	}
+}
+
+func TestMutationCommandsDryRunPrintsPlan(t *testing.T) {
+	env := Environment{
+		Executor: func() (services.CommandExecutor, error) {
+			t.Fatal("dry run must not build an executor")
+			return nil, nil
+		},
+		DryRun: func() bool { return true },
+	}
+	out, err := runMutation(t, env, "", "container", "export", "web", "--destination", t.TempDir())
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "container export") || !strings.HasPrefix(lines[2], "container image rm") {
+		t.Fatalf("unexpected export plan:\n%s", out)
+	}
+
+	out, err = runMutation(t, env, "", "machine", "set", "builder", "--cpus", "4", "--memory", "8G", "--home-mount", "ro")
+	if err != nil || strings.TrimSpace(out) != "container machine set -n builder cpus=4 memory=8G home-mount=ro" {
+		t.Fatalf("unexpected machine set plan %q (%v)", out, err)
+	}
+}
+
+func TestMutationCommandsExitCodes(t *testing.T) {
+	backend := services.NewFakeBackend()
+	env := fakeEnvironment(backend, false)
+
+	_, err := runMutation(t, env, "", "container", "start", "missing", "--yes")
+	if code := ExitCode(err); code != ExitNotFound {
+		t.Fatalf("expected not-found exit code, got %d (%v)", code, err)
+	}
+	_, err = runMutation(t, env, "", "machine", "set", "builder", "--cpus", "zero", "--yes")
+	if code := ExitCode(err); code != ExitUsage {
+		t.Fatalf("expected usage exit code, got %d (%v)", code, err)
+	}
+	_, err = runMutation(t, env, "", "image", "pull")
+	if code := ExitCode(err); code != ExitUsage {
+		t.Fatalf("expected usage exit code for missing argument, got %d (%v)", code, err)
+	}
+
+	_, err = runMutation(t, env, "", "image", "pull", "registry.example.com/team/app:1", "--yes")
+	if code := ExitCode(err); code != ExitAuth || !strings.Contains(err.Error(), "container registry login registry.example.com") {
+		t.Fatalf("expected auth exit code and login hint, got %d (%v)", code, err)
+	}
+	builder := &CommandError{Command: models.Command{Executable: "container", Args: []string{"build", "."}}, Result: models.Result{Stderr: "Error: buildkit is unavailable"}, Err: errors.New("exit status 1")}
+	if code := ExitCode(builder); code != ExitBuilder || !strings.Contains(builder.Error(), "hint: start builder with `container builder start`") {
+		t.Fatalf("expected builder exit code and hint, got %d (%v)", code, builder)
+	}
+	network := &CommandError{Result: models.Result{Stderr: "Error: i/o timeout"}, Err: errors.New("exit status 1")}
+	if code := ExitCode(network); code != ExitNetwork {
+		t.Fatalf("expected network exit code, got %d", code)
+	}
+
+	policy := services.NewPolicyExecutor(backend, services.ExecutionPolicy{ReadOnly: true})
+	blocked := Environment{Executor: func() (services.CommandExecutor, error) { return policy, nil }}
+	_, err = runMutation(t, blocked, "", "image", "prune", "--yes")
+	if code := ExitCode(err); code != ExitPolicy {
+		t.Fatalf("expected policy exit code, got %d (%v)", code, err)
+	}
+}
+
+func TestMachineSetKeepsCurrentValues(t *testing.T) {
+	backend := services.NewFakeBackend()
+	env := fakeEnvironment(backend, false)
+	if _, err := runMutation(t, env, "", "machine", "set", "builder", "--cpus", "6", "--yes"); err != nil {
+		t.Fatalf("machine set: %v", err)
+	}
+	out, err := runHeadless(t, env, "machines", "-o", "json")
+	if err != nil {
+		t.Fatalf("machines: %v", err)
+	}
+	var machines []machineView
+	if err := json.Unmarshal([]byte(out), &machines); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, machine := range machines {
+		if machine.ID == "builder" && (machine.CPUs != 6 || machine.Memory != "4G" || machine.HomeMount != "ro") {
+			t.Fatalf("unexpected builder machine %+v", machine)
+		}
+	}
+}
+
+func TestAuditVerifyReportsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "command.log")
+	writer := services.NewLogWriterAt(path, 0)
+	writer.EnableAudit(services.AuditMetadata{Host: "mac-01", User: "ci", Version: "test"})
+	for _, name := range []string{"web", "db"} {
+		command := models.Command{Executable: "container", Args: []string{"stop", name}}
+		if err := writer.Write(services.BuildLogEntry(command, models.Result{Status: models.ResultSuccess}, false)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	env := fakeEnvironment(services.NewFakeBackend(), false)
+
+	out, err := runHeadless(t, env, "audit", "verify", "--log", path)
+	if err != nil || !strings.Contains(out, "OK: no gaps") {
+		t.Fatalf("expected a clean trail, got %v:\n%s", err, out)
+	}
+
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "stop db", "stop dB", 1)), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out, err = runHeadless(t, env, "audit", "verify", "--log", path, "-o", "json")
+	if ExitCode(err) != ExitAuditFailed || !strings.Contains(out, "entry 2 was modified") {
+		t.Fatalf("expected exit %d and a modified entry, got %d:\n%s", ExitAuditFailed, ExitCode(err), out)
+	}
+}
+
+func TestConfigCommandsGetSetUnsetValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	env := Environment{Config: func() (*services.ConfigManager, error) { return services.NewConfigManagerAt(path), nil }}
+
+	out, err := runMutation(t, env, "", "config", "validate")
+	if err != nil || !strings.Contains(out, "defaults apply") {
+		t.Fatalf("expected defaults without a file, got %v:\n%s", err, out)
+	}
+	if _, err := runMutation(t, env, "", "config", "set", "theme_mode", "dark"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if _, err := runMutation(t, env, "", "config", "set", "policy.deny", "machine delete", "image prune"); err != nil {
+		t.Fatalf("set list: %v", err)
+	}
+	_, err = runMutation(t, env, "", "config", "set", "theme_mode", "neon")
+	if ExitCode(err) != ExitUsage {
+		t.Fatalf("expected an invalid value to be a usage error, got %v", err)
+	}
+	out, err = runMutation(t, env, "", "config", "get", "theme_mode")
+	if err != nil || out != "dark\n" {
+		t.Fatalf("unexpected get %q (%v)", out, err)
+	}
+	out, err = runMutation(t, env, "", "config", "get", "policy.deny", "-o", "json")
+	if err != nil || strings.TrimSpace(out) != `[
+  "machine delete",
+  "image prune"
+]` {
+		t.Fatalf("unexpected get json %q (%v)", out, err)
+	}
+	if _, err := runMutation(t, env, "", "config", "unset", "theme_mode"); err != nil {
+		t.Fatalf("unset: %v", err)
+	}
+	out, _ = runMutation(t, env, "", "config", "get", "theme_mode")
+	if out != "auto\n" {
+		t.Fatalf("expected the default after unset, got %q", out)
+	}
+
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, append(data, []byte("colour = \"blue\"\n")...), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out, err = runMutation(t, env, "", "config", "validate")
+	if ExitCode(err) != ExitInvalidConfig || !strings.Contains(out, path+":") || !strings.Contains(out, "colour: unknown key") {
+		t.Fatalf("expected exit %d and an unknown key, got %d:\n%s", ExitInvalidConfig, ExitCode(err), out)
+	}
+}
+
+func TestConfigEditRevalidatesBeforeSaving(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	if err := os.WriteFile(path, []byte("theme_mode = \"light\"\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// The first run writes an invalid theme, the second a valid one.
+	editor := filepath.Join(dir, "editor.sh")
+	script := `#!/bin/sh
+if [ -f "$0.ran" ]; then echo 'theme_mode = "dark"' > "$1"; else touch "$0.ran"; echo 'theme_mode = "neon"' > "$1"; fi
+`
+	if err := os.WriteFile(editor, []byte(script), 0o700); err != nil {
+		t.Fatalf("write editor: %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+	env := Environment{Config: func() (*services.ConfigManager, error) { return services.NewConfigManagerAt(path), nil }}
+
+	out, err := runMutation(t, env, "n\n", "config", "edit")
+	data, _ := os.ReadFile(path)
+	if ExitCode(err) != ExitAborted || !strings.Contains(out, "unknown theme") || string(data) != "theme_mode = \"light\"\n" {
+		t.Fatalf("expected an aborted edit to leave the file alone, got %v:\n%s", err, out)
+	}
+
+	_ = os.Remove(editor + ".ran")
+	out, err = runMutation(t, env, "y\n", "config", "edit")
+	data, _ = os.ReadFile(path)
+	if err != nil || !strings.Contains(out, "saved "+path) || string(data) != "theme_mode = \"dark\"\n" {
+		t.Fatalf("expected the second edit to be saved, got %v:\n%s\n%s", err, out, data)
+	}
+}
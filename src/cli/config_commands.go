@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// NewConfigCommand returns the `config` command group.
+func NewConfigCommand(env Environment) *cobra.Command {
+	group := &cobra.Command{Use: "config", Short: "Show, change and validate the config file"}
+	group.AddCommand(
+		newConfigPathCommand(env),
+		newConfigGetCommand(env),
+		newConfigSetCommand(env),
+		newConfigUnsetCommand(env),
+		newConfigValidateCommand(env),
+		newConfigEditCommand(env),
+	)
+	return group
+}
+
+func newConfigPathCommand(env Environment) *cobra.Command {
+	return &cobra.Command{
+		Use:           "path",
+		Short:         "Print the config file in use",
+		Args:          exactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), manager.Path())
+			return err
+		},
+	}
+}
+
+func newConfigGetCommand(env Environment) *cobra.Command {
+	var output string
+	var sources bool
+	cmd := &cobra.Command{
+		Use:           "get [key]",
+		Short:         "Print the effective value of a key, or the whole config",
+		Args:          maximumArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			key := ""
+			if len(args) == 1 {
+				key = args[0]
+			}
+			if sources {
+				return printConfigSources(cmd.OutOrStdout(), format, manager, key)
+			}
+			config, _, err := manager.Load()
+			if err != nil {
+				return err
+			}
+			value, err := services.ConfigValue(config, key)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			if format != OutputTable {
+				return writeOutput(cmd.OutOrStdout(), format, value, tableRows{})
+			}
+			return printConfigValue(cmd.OutOrStdout(), value)
+		},
+	}
+	cmd.Flags().BoolVar(&sources, "sources", false, "list each value with where it came from: default, user, project or env")
+	addOutputFlag(cmd, &output)
+	return cmd
+}
+
+// printConfigSources lists the effective settings under key, or all of them, with their source.
+func printConfigSources(out io.Writer, format OutputFormat, manager *services.ConfigManager, key string) error {
+	effective, err := manager.Effective()
+	if err != nil {
+		return err
+	}
+	settings := make([]services.ConfigSetting, 0, len(effective.Settings))
+	rows := tableRows{header: []string{"KEY", "SOURCE", "VALUE"}}
+	for _, setting := range effective.Settings {
+		if key == "" || setting.Key == key || strings.HasPrefix(setting.Key, key+".") {
+			settings = append(settings, setting)
+			rows.rows = append(rows.rows, []string{setting.Key, setting.Source, setting.Value})
+		}
+	}
+	if len(settings) == 0 {
+		return &UsageError{Err: fmt.Errorf("%s is not set", key)}
+	}
+	return writeOutput(out, format, settings, rows)
+}
+
+// printConfigValue prints scalars as is, lists one item per line and tables as TOML.
+func printConfigValue(out io.Writer, value any) error {
+	switch typed := value.(type) {
+	case map[string]any:
+		data, err := toml.Marshal(typed)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case []string:
+		for _, item := range typed {
+			if _, err := fmt.Fprintln(out, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		_, err := fmt.Fprintln(out, typed)
+		return err
+	}
+}
+
+func newConfigSetCommand(env Environment) *cobra.Command {
+	return &cobra.Command{
+		Use:           "set <key> <value>...",
+		Short:         "Store a value in the config file",
+		Long:          "Store a value in the config file. Lists take one argument per item, or one comma separated argument.",
+		Args:          minimumArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			if err := manager.Set(args[0], args[1:]); err != nil {
+				return &UsageError{Err: err}
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "set %s in %s\n", args[0], manager.WritePath)
+			warnEnvOverride(cmd, args[0])
+			return nil
+		},
+	}
+}
+
+func newConfigUnsetCommand(env Environment) *cobra.Command {
+	return &cobra.Command{
+		Use:           "unset <key>",
+		Short:         "Remove a key from the config file so its default applies",
+		Args:          exactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			removed, err := manager.Unset(args[0])
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			if !removed {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s is not set in %s\n", args[0], manager.WritePath)
+				return nil
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "unset %s in %s\n", args[0], manager.WritePath)
+			warnEnvOverride(cmd, args[0])
+			return nil
+		},
+	}
+}
+
+// warnEnvOverride notes when an ACTUI_* variable hides the value just written.
+func warnEnvOverride(cmd *cobra.Command, key string) {
+	name := services.EnvVarName(key)
+	if _, ok := os.LookupEnv(name); ok {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "note: %s is set and overrides this value\n", name)
+	}
+}
+
+func newConfigValidateCommand(env Environment) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:           "validate [file]",
+		Short:         "Check a config file for unknown keys, wrong types and invalid values",
+		Args:          maximumArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			path := manager.Path()
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			problems, err := services.ValidateConfigFile(path)
+			if errors.Is(err, os.ErrNotExist) && len(args) == 0 {
+				problems, err = []services.ConfigProblem{}, nil
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "no config file at %s; defaults apply\n", path)
+			}
+			if err != nil {
+				return err
+			}
+			if project := manager.ProjectPath; len(args) == 0 && project != "" {
+				found, err := services.ValidateConfigFile(project)
+				if err != nil {
+					return err
+				}
+				problems = append(problems, found...)
+				path += ", " + project
+			}
+			if err := services.CheckEnvOverrides(); err != nil {
+				problems = append(problems, services.ConfigProblem{Message: err.Error()})
+			}
+
+			if format != OutputTable {
+				if err := writeOutput(cmd.OutOrStdout(), format, problems, tableRows{}); err != nil {
+					return err
+				}
+			} else if len(problems) == 0 {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s: OK\n", path)
+			} else {
+				printConfigProblems(cmd.OutOrStdout(), problems)
+			}
+			if len(problems) > 0 {
+				return fmt.Errorf("%w: %d problem(s) found", ErrInvalidConfig, len(problems))
+			}
+			return nil
+		},
+	}
+	addOutputFlag(cmd, &output)
+	return cmd
+}
+
+func printConfigProblems(out io.Writer, problems []services.ConfigProblem) {
+	for _, problem := range problems {
+		_, _ = fmt.Fprintln(out, problem.String())
+	}
+}
+
+func newConfigEditCommand(env Environment) *cobra.Command {
+	return &cobra.Command{
+		Use:           "edit",
+		Short:         "Open the config file in $EDITOR and save it once it validates",
+		Args:          exactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := env.configManager()
+			if err != nil {
+				return err
+			}
+			original, err := os.ReadFile(manager.WritePath)
+			if errors.Is(err, os.ErrNotExist) {
+				defaults, _ := services.ConfigValue(models.DefaultUserConfig(), "")
+				original, err = toml.Marshal(defaults)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Edit a copy so a half-finished edit never becomes the live config.
+			temp, err := os.CreateTemp("", "actui-config-*.toml")
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = os.Remove(temp.Name())
+			}()
+			_, err = temp.Write(original)
+			if closeErr := temp.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for {
+				if err := runEditor(cmd, temp.Name()); err != nil {
+					return err
+				}
+				edited, err := os.ReadFile(temp.Name())
+				if err != nil {
+					return err
+				}
+				if bytes.Equal(edited, original) {
+					_, _ = fmt.Fprintln(out, "no changes")
+					return nil
+				}
+				problems, err := manager.SaveRaw(edited)
+				if err != nil {
+					return err
+				}
+				if len(problems) == 0 {
+					_, _ = fmt.Fprintf(out, "saved %s\n", manager.WritePath)
+					return nil
+				}
+				printConfigProblems(out, problems)
+				_, _ = fmt.Fprint(out, "Edit again? [Y/n] ")
+				answer, err := readLine(cmd.InOrStdin())
+				if (err != nil && answer == "") || strings.EqualFold(answer, "n") || strings.EqualFold(answer, "no") {
+					_, _ = fmt.Fprintln(out)
+					return fmt.Errorf("%w: %s was not changed", ErrAborted, manager.WritePath)
+				}
+			}
+		},
+	}
+}
+
+// runEditor opens path in $VISUAL, $EDITOR or vi. The editor command may carry arguments,
+// such as "code --wait".
+func runEditor(cmd *cobra.Command, path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	process := exec.Command("/bin/sh", "-c", editor+` "$1"`, "actui", path)
+	// Only hand the terminal to the editor; a piped stdin stays with the prompts.
+	if stdin, ok := cmd.InOrStdin().(*os.File); ok {
+		process.Stdin = stdin
+	}
+	process.Stdout = cmd.OutOrStdout()
+	process.Stderr = cmd.ErrOrStderr()
+	if err := process.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// confirm asks for the confirmation the policy requires before a guarded command runs.
+// expected is the text a type-to-confirm prompt wants typed back.
+func confirm(in io.Reader, out io.Writer, policy services.ConfirmationPolicy, action services.DestructiveAction, expected string, command models.Command) error {
+	mode := policy.ModeFor(action)
+	if mode == services.ConfirmNone {
+		return nil
+	}
+
+	label := services.DestructiveActionMetadata()[action].Label
+	_, _ = fmt.Fprintf(out, "%s: %s\n", label, services.DefaultRedactor().RedactCommand(command).String())
+	if mode == services.ConfirmTypeToConfirm {
+		_, _ = fmt.Fprintf(out, "Type %q to confirm: ", expected)
+	} else {
+		_, _ = fmt.Fprint(out, "Proceed? [y/N] ")
+	}
+
+	answer, err := readLine(in)
+	if err != nil && answer == "" {
+		_, _ = fmt.Fprintln(out)
+		return fmt.Errorf("%w: no confirmation received; pass --yes to run without prompting", ErrAborted)
+	}
+	if mode == services.ConfirmTypeToConfirm {
+		if answer != expected {
+			return fmt.Errorf("%w: confirmation did not match %q", ErrAborted, expected)
+		}
+		return nil
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("%w: confirmation declined", ErrAborted)
+	}
+}
+
+// readLine reads one line without buffering past it, so consecutive prompts can share stdin.
+func readLine(in io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return strings.TrimSpace(string(line)), nil
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			return strings.TrimSpace(string(line)), err
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// NewContainerCommand returns the `container` command group.
+func NewContainerCommand(env Environment) *cobra.Command {
+	group := &cobra.Command{Use: "container", Short: "Start, stop, delete or export a container"}
+	group.AddCommand(
+		newMutationCommand(env, "start <container>", "Start a container", func(args []string) services.CommandBuilder {
+			return services.StartContainerBuilder{ContainerID: args[0]}
+		}),
+		newMutationCommand(env, "stop <container>", "Stop a running container", func(args []string) services.CommandBuilder {
+			return services.StopContainerBuilder{ContainerID: args[0]}
+		}),
+		newMutationCommand(env, "delete <container>", "Delete a stopped container", func(args []string) services.CommandBuilder {
+			return services.DeleteContainerBuilder{ContainerID: args[0]}
+		}),
+		newContainerExportCommand(env),
+	)
+	return group
+}
+
+// newMutationCommand builds a single-command subcommand whose target is the last argument.
+func newMutationCommand(env Environment, use, short string, build func(args []string) services.CommandBuilder) *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:           use,
+		Short:         short,
+		Args:          exactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return env.apply(cmd, yes, build(args), args[len(args)-1])
+		},
+	}
+	addYesFlag(cmd, &yes)
+	return cmd
+}
+
+func newContainerExportCommand(env Environment) *cobra.Command {
+	var yes bool
+	var destination string
+	var keepImage bool
+	cmd := &cobra.Command{
+		Use:           "export <container>",
+		Short:         "Export a stopped container to an OCI archive",
+		Args:          exactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if env.dryRun() {
+				// The container state cannot be read in dry-run mode, so plan as if it were stopped.
+				container := models.Container{ID: args[0], Name: args[0], Status: models.ContainerStatusStopped}
+				plan, err := services.NewExportWorkflowService(nil).Plan(container, destination)
+				if err != nil {
+					return &UsageError{Err: err}
+				}
+				commands := plan.Commands
+				if !keepImage {
+					commands = append(commands, plan.CleanupCommand)
+				}
+				return printPlan(cmd.OutOrStdout(), commands...)
+			}
+
+			executor, err := env.executor()
+			if err != nil {
+				return err
+			}
+			container, err := findContainer(executor, env.capabilities(), args[0])
+			if err != nil {
+				return err
+			}
+			workflow := services.NewExportWorkflowService(executor)
+			plan, err := workflow.Plan(container, destination)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			result, err := workflow.Execute(plan)
+			if err != nil {
+				return &CommandError{Command: plan.Commands[0], Result: result.Result, Err: err}
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), result.Result.Stdout)
+			if keepImage {
+				return nil
+			}
+
+			if !yes {
+				err := confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), env.confirmations(), plan.CleanupAction(), plan.CleanupConfirmation(), plan.CleanupCommand)
+				if errors.Is(err, ErrAborted) {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "kept temporary image %s\n", plan.GeneratedImageRef)
+					return nil
+				}
+			}
+			return streamCommand(cmd, executor, plan.CleanupCommand)
+		},
+	}
+	addYesFlag(cmd, &yes)
+	cmd.Flags().StringVarP(&destination, "destination", "d", ".", "directory to write the archive to")
+	cmd.Flags().BoolVar(&keepImage, "keep-image", false, "keep the temporary image created for the export")
+	return cmd
+}
+
+// findContainer looks up a container by ID or name.
+func findContainer(executor services.CommandExecutor, capabilities services.Capabilities, target string) (models.Container, error) {
+	builder := services.ListContainersBuilder{Capabilities: capabilities}
+	command, err := builder.Build()
+	if err != nil {
+		return models.Container{}, err
+	}
+	result, err := executor.Execute(command)
+	if err != nil {
+		return models.Container{}, &CommandError{Command: command, Result: result, Err: err}
+	}
+	containers, err := builder.Parse(result.Stdout)
+	if err != nil {
+		return models.Container{}, err
+	}
+	for _, container := range containers {
+		if container.ID == target || container.Name == target {
+			return container, nil
+		}
+	}
+	return models.Container{}, &CommandError{Command: command, Result: result, Err: fmt.Errorf("container %s not found", target)}
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// Environment supplies headless commands with the executor chain configured by the root command.
+type Environment struct {
+	// Executor builds the configured executor chain; it is called once flags are parsed.
+	Executor func() (services.CommandExecutor, error)
+	// DryRun reports whether --dry-run was given.
+	DryRun func() bool
+	// Confirmations returns the configured confirmation policy; it is called after Executor.
+	Confirmations func() services.ConfirmationPolicy
+	// Config returns the config manager selected by --config; it does not need Executor.
+	Config func() (*services.ConfigManager, error)
+	// Capabilities probes the container CLI so list commands can pick their output format.
+	Capabilities func() services.Capabilities
+}
+
+func (e Environment) dryRun() bool {
+	return e.DryRun != nil && e.DryRun()
+}
+
+func (e Environment) executor() (services.CommandExecutor, error) {
+	if e.Executor == nil {
+		return nil, errors.New("no executor configured")
+	}
+	return e.Executor()
+}
+
+// capabilities returns the probed CLI capabilities. Dry runs build no executor and report
+// unknown capabilities, so their plans show table output.
+func (e Environment) capabilities() services.Capabilities {
+	if e.Capabilities == nil || e.dryRun() {
+		return services.Capabilities{}
+	}
+	return e.Capabilities()
+}
+
+func (e Environment) confirmations() services.ConfirmationPolicy {
+	if e.Confirmations == nil {
+		return services.DefaultConfirmationPolicy()
+	}
+	return e.Confirmations()
+}
+
+func (e Environment) configManager() (*services.ConfigManager, error) {
+	if e.Config == nil {
+		return services.NewConfigManager()
+	}
+	return e.Config()
+}
+
+// run executes a built command and turns failures into formatted errors.
+func (e Environment) run(builder services.CommandBuilder) (models.Result, error) {
+	command, err := builder.Build()
+	if err != nil {
+		return models.Result{}, err
+	}
+	executor, err := e.executor()
+	if err != nil {
+		return models.Result{}, err
+	}
+	result, err := executor.Execute(command)
+	if err != nil {
+		return result, &CommandError{Command: command, Result: result, Err: err}
+	}
+	return result, nil
+}
+
+// apply confirms and runs a mutating command, streaming its output to cmd. In dry-run mode
+// it prints the command instead. expected is the text type-to-confirm prompts ask for.
+func (e Environment) apply(cmd *cobra.Command, yes bool, builder services.CommandBuilder, expected string) error {
+	command, err := builder.Build()
+	if err != nil {
+		return &UsageError{Err: err}
+	}
+	if e.dryRun() {
+		return printPlan(cmd.OutOrStdout(), command)
+	}
+	executor, err := e.executor()
+	if err != nil {
+		return err
+	}
+	if guarded, ok := builder.(services.GuardedBuilder); ok && !yes {
+		if err := confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), e.confirmations(), guarded.GuardedAction(), expected, command); err != nil {
+			return err
+		}
+	}
+	return streamCommand(cmd, executor, command)
+}
+
+// streamCommand runs command, copying stdout lines to the command's output and stderr lines
+// to its error stream.
+func streamCommand(cmd *cobra.Command, executor services.CommandExecutor, command models.Command) error {
+	result, err := services.StreamContext(cmd.Context(), executor, command, func(line services.StreamLine) {
+		target := cmd.OutOrStdout()
+		if line.Source == services.StreamStderr {
+			target = cmd.ErrOrStderr()
+		}
+		_, _ = fmt.Fprintln(target, line.Text)
+	})
+	if err != nil {
+		return &CommandError{Command: command, Result: result, Err: err}
+	}
+	return nil
+}
+
+// printPlan writes the commands a dry run would execute, with secrets masked.
+func printPlan(w io.Writer, commands ...models.Command) error {
+	redactor := services.DefaultRedactor()
+	for _, command := range commands {
+		if _, err := fmt.Fprintln(w, redactor.RedactCommand(command).String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CommandError reports a failed runtime command with its captured result.
+type CommandError struct {
+	Command models.Command
+	Result  models.Result
+	Err     error
+}
+
+// Error returns the formatted failure and, when it has a remedy, the command that fixes it.
+func (e *CommandError) Error() string {
+	runtimeErr := e.RuntimeError()
+	message := runtimeErr.Error()
+	if runtimeErr.Remedy == services.RemedyNone {
+		return message
+	}
+	if fix, _, err := runtimeErr.RemedyCommand(); err == nil {
+		message += "\nhint: " + strings.ToLower(runtimeErr.Remedy.Label()) + " with `" + fix.String() + "`"
+	}
+	return message
+}
+
+// RuntimeError returns the failure as classified by the executor, classifying it here when
+// the executor did not.
+func (e *CommandError) RuntimeError() *services.RuntimeError {
+	err := e.Err
+	if err == nil {
+		err = errors.New("command failed")
+	}
+	return services.NewRuntimeError(e.Command, e.Result, err)
+}
+
+// Category returns the kind of the failure.
+func (e *CommandError) Category() services.ErrorCategory {
+	return e.RuntimeError().Kind
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// addYesFlag registers --yes on cmd.
+func addYesFlag(cmd *cobra.Command, target *bool) {
+	cmd.Flags().BoolVarP(target, "yes", "y", false, "skip the confirmation prompt")
+}
+
+// exactArgs requires n positional arguments and reports a usage error otherwise.
+func exactArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(n)(cmd, args); err != nil {
+			return &UsageError{Err: err}
+		}
+		return nil
+	}
+}
+
+// minimumArgs wraps cobra.MinimumNArgs so missing arguments exit with ExitUsage.
+func minimumArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MinimumNArgs(n)(cmd, args); err != nil {
+			return &UsageError{Err: err}
+		}
+		return nil
+	}
+}
+
+// maximumArgs wraps cobra.MaximumNArgs so extra arguments exit with ExitUsage.
+func maximumArgs(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.MaximumNArgs(n)(cmd, args); err != nil {
+			return &UsageError{Err: err}
+		}
+		return nil
+	}
+}
+
+// addOutputFlag registers --output on cmd.
+func addOutputFlag(cmd *cobra.Command, target *string) {
+	cmd.Flags().StringVarP(target, "output", "o", string(OutputTable), "output format: table, json or yaml")
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/services"
+)
+
+// Exit codes returned by headless commands.
+const (
+	ExitOK             = 0
+	ExitFailure        = 1
+	ExitUsage          = 2
+	ExitNotFound       = 3
+	ExitConflict       = 4
+	ExitPermission     = 5
+	ExitDaemon         = 6
+	ExitAuth           = 7
+	ExitPolicy         = 8
+	ExitTimeout        = 9
+	ExitAborted        = 10
+	ExitInvalidRequest = 11
+	ExitAuditFailed    = 12
+	ExitInvalidConfig  = 13
+	ExitBuilder        = 14
+	ExitNetwork        = 15
+)
+
+// categoryExitCodes maps error kinds to exit codes; unlisted categories exit with ExitFailure.
+var categoryExitCodes = map[services.ErrorCategory]int{
+	services.ErrorCanceled:         ExitAborted,
+	services.ErrorTimeout:          ExitTimeout,
+	services.ErrorPolicy:           ExitPolicy,
+	services.ErrorNotFound:         ExitNotFound,
+	services.ErrorAlreadyRunning:   ExitConflict,
+	services.ErrorAlreadyStopped:   ExitConflict,
+	services.ErrorPermission:       ExitPermission,
+	services.ErrorDaemon:           ExitDaemon,
+	services.ErrorAuth:             ExitAuth,
+	services.ErrorBuilder:          ExitBuilder,
+	services.ErrorNetwork:          ExitNetwork,
+	services.ErrorInvalidReference: ExitInvalidRequest,
+	services.ErrorBuildPath:        ExitInvalidRequest,
+	services.ErrorBuildContext:     ExitInvalidRequest,
+}
+
+// ErrAborted is returned when a confirmation prompt is declined.
+var ErrAborted = errors.New("aborted")
+
+// ErrAuditFailed is returned when audit verification finds gaps, edits or truncation.
+var ErrAuditFailed = errors.New("audit verification failed")
+
+// ErrInvalidConfig is returned when config validation finds problems.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// UsageError reports invalid arguments, flags or builder input.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// FlagError wraps cobra flag parsing errors so they exit with ExitUsage.
+func FlagError(_ *cobra.Command, err error) error {
+	return &UsageError{Err: err}
+}
+
+// ExitCode returns the process exit code for an error returned by a command.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+	if errors.Is(err, ErrAborted) {
+		return ExitAborted
+	}
+	if errors.Is(err, ErrAuditFailed) {
+		return ExitAuditFailed
+	}
+	if errors.Is(err, ErrInvalidConfig) {
+		return ExitInvalidConfig
+	}
+	var commandErr *CommandError
+	if errors.As(err, &commandErr) {
+		if code, ok := categoryExitCodes[commandErr.Category()]; ok {
+			return code
+		}
+		return ExitFailure
+	}
+	// Errors that never reached a command only carry the structured categories.
+	switch category := services.ClassifyError(err, ""); category {
+	case services.ErrorCanceled, services.ErrorTimeout, services.ErrorPolicy:
+		return categoryExitCodes[category]
+	}
+	return ExitFailure
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"container-tui/src/services"
+)
+
+// NewImageCommand returns the `image` command group.
+func NewImageCommand(env Environment) *cobra.Command {
+	group := &cobra.Command{Use: "image", Short: "Pull, delete or prune images"}
+	group.AddCommand(
+		newMutationCommand(env, "pull <reference>", "Pull an image from a registry", func(args []string) services.CommandBuilder {
+			return services.PullImageBuilder{Reference: args[0]}
+		}),
+		newMutationCommand(env, "delete <reference>", "Delete a local image", func(args []string) services.CommandBuilder {
+			return services.ImageDeleteBuilder{ImageReference: args[0]}
+		}),
+		newImagePruneCommand(env),
+	)
+	return group
+}
+
+func newImagePruneCommand(env Environment) *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:           "prune",
+		Short:         "Remove images not used by any container",
+		Args:          exactArgs(0),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return env.apply(cmd, yes, services.ImagePruneBuilder{}, "prune")
+		},
+	}
+	addYesFlag(cmd, &yes)
+	return cmd
+}
@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+type containerView struct {
+	ID       string            `json:"id" yaml:"id"`
+	Name     string            `json:"name" yaml:"name"`
+	Image    string            `json:"image" yaml:"image"`
+	Status   string            `json:"status" yaml:"status"`
+	Created  string            `json:"created,omitempty" yaml:"created,omitempty"`
+	Platform string            `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Ports    []string          `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Networks []networkView     `json:"networks,omitempty" yaml:"networks,omitempty"`
+	Mounts   []mountView       `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type networkView struct {
+	Name     string `json:"name" yaml:"name"`
+	Address  string `json:"address,omitempty" yaml:"address,omitempty"`
+	Hostname string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+}
+
+type mountView struct {
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+	ReadOnly    bool   `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+}
+
+type imageView struct {
+	Reference string            `json:"reference" yaml:"reference"`
+	Name      string            `json:"name" yaml:"name"`
+	Tag       string            `json:"tag" yaml:"tag"`
+	Digest    string            `json:"digest,omitempty" yaml:"digest,omitempty"`
+	Platform  string            `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Size      int64             `json:"size,omitempty" yaml:"size,omitempty"`
+	Created   *time.Time        `json:"created,omitempty" yaml:"created,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+type machineView struct {
+	ID        string `json:"id" yaml:"id"`
+	Image     string `json:"image" yaml:"image"`
+	State     string `json:"state" yaml:"state"`
+	Default   bool   `json:"default" yaml:"default"`
+	CPUs      int    `json:"cpus" yaml:"cpus"`
+	Memory    string `json:"memory" yaml:"memory"`
+	HomeMount string `json:"homeMount" yaml:"homeMount"`
+}
+
+type registryView struct {
+	Hostname string    `json:"hostname" yaml:"hostname"`
+	Username string    `json:"username" yaml:"username"`
+	Created  time.Time `json:"created" yaml:"created"`
+	Modified time.Time `json:"modified" yaml:"modified"`
+}
+
+type statusView struct {
+	State       string `json:"state" yaml:"state"`
+	Running     bool   `json:"running" yaml:"running"`
+	Version     string `json:"version,omitempty" yaml:"version,omitempty"`
+	InstallRoot string `json:"installRoot,omitempty" yaml:"installRoot,omitempty"`
+	AppRoot     string `json:"appRoot,omitempty" yaml:"appRoot,omitempty"`
+}
+
+// NewListCommands returns the read-only headless subcommands.
+func NewListCommands(env Environment) []*cobra.Command {
+	return []*cobra.Command{
+		newListCommand(env, "containers", "List containers", func(capabilities services.Capabilities) (services.CommandBuilder, listOutput) {
+			builder := services.ListContainersBuilder{Capabilities: capabilities}
+			return builder, containersOutput(builder)
+		}),
+		newListCommand(env, "images", "List local images", func(capabilities services.Capabilities) (services.CommandBuilder, listOutput) {
+			builder := services.ImageListBuilder{Capabilities: capabilities}
+			return builder, imagesOutput(builder)
+		}),
+		newListCommand(env, "machines", "List container machines", fixedList(services.MachineListBuilder{}, machinesOutput)),
+		newListCommand(env, "registries", "List registry logins", fixedList(services.RegistryListBuilder{}, registriesOutput)),
+		newListCommand(env, "status", "Show container daemon status", fixedList(services.CheckDaemonStatusBuilder{}, statusOutput)),
+	}
+}
+
+// listOutput parses command output into a printable value and its table rows.
+type listOutput func(stdout string) (any, tableRows, error)
+
+// listSource returns a list command's builder and parser for the CLI's capabilities.
+type listSource func(capabilities services.Capabilities) (services.CommandBuilder, listOutput)
+
+// fixedList is the source of a list whose command does not depend on the CLI version.
+func fixedList(builder services.CommandBuilder, parse listOutput) listSource {
+	return func(services.Capabilities) (services.CommandBuilder, listOutput) {
+		return builder, parse
+	}
+}
+
+func newListCommand(env Environment, use, short string, source listSource) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  exactArgs(0),
+		// Errors are printed once by main; usage is noise for runtime failures.
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := ParseOutputFormat(output)
+			if err != nil {
+				return &UsageError{Err: err}
+			}
+			builder, parse := source(env.capabilities())
+			if env.dryRun() {
+				command, err := builder.Build()
+				if err != nil {
+					return err
+				}
+				return printPlan(cmd.OutOrStdout(), command)
+			}
+			result, err := env.run(builder)
+			if err != nil {
+				return err
+			}
+			value, rows, err := parse(result.Stdout)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd.OutOrStdout(), format, value, rows)
+		},
+	}
+	addOutputFlag(cmd, &output)
+	return cmd
+}
+
+func containersOutput(builder services.ListContainersBuilder) listOutput {
+	return func(stdout string) (any, tableRows, error) {
+		containers, err := builder.Parse(stdout)
+		if err != nil {
+			return nil, tableRows{}, err
+		}
+		views := make([]containerView, 0, len(containers))
+		rows := tableRows{header: []string{"ID", "NAME", "IMAGE", "STATUS"}}
+		for _, container := range containers {
+			views = append(views, newContainerView(container))
+			rows.rows = append(rows.rows, []string{container.ID, container.Name, container.Image, string(container.Status)})
+		}
+		return views, rows, nil
+	}
+}
+
+func newContainerView(container models.Container) containerView {
+	view := containerView{
+		ID:       container.ID,
+		Name:     container.Name,
+		Image:    container.Image,
+		Status:   string(container.Status),
+		Created:  container.Created,
+		Platform: container.Platform,
+		Labels:   container.Labels,
+	}
+	for _, port := range container.Ports {
+		view.Ports = append(view.Ports, fmt.Sprintf("%d:%d/%s", port.HostPort, port.ContainerPort, port.Protocol))
+	}
+	for _, network := range container.Networks {
+		view.Networks = append(view.Networks, networkView{Name: network.Name, Address: network.Address, Hostname: network.Hostname})
+	}
+	for _, mount := range container.Mounts {
+		view.Mounts = append(view.Mounts, mountView{Source: mount.Source, Destination: mount.Destination, ReadOnly: mount.ReadOnly})
+	}
+	return view
+}
+
+func imagesOutput(builder services.ImageListBuilder) listOutput {
+	return func(stdout string) (any, tableRows, error) {
+		images, err := builder.Parse(stdout)
+		if err != nil {
+			return nil, tableRows{}, err
+		}
+		views := make([]imageView, 0, len(images))
+		rows := tableRows{header: []string{"NAME", "TAG", "DIGEST"}}
+		for _, image := range images {
+			view := imageView{Reference: image.Reference(), Name: image.Name, Tag: image.Tag, Digest: image.Digest, Platform: image.Platform, Size: image.Size, Labels: image.Labels}
+			if !image.Created.IsZero() {
+				created := image.Created
+				view.Created = &created
+			}
+			views = append(views, view)
+			rows.rows = append(rows.rows, []string{image.Name, image.Tag, shortDigest(image.Digest)})
+		}
+		return views, rows, nil
+	}
+}
+
+func machinesOutput(stdout string) (any, tableRows, error) {
+	machines, err := services.ParseMachineList(stdout)
+	if err != nil {
+		return nil, tableRows{}, err
+	}
+	views := make([]machineView, 0, len(machines))
+	rows := tableRows{header: []string{"ID", "STATE", "DEFAULT", "CPUS", "MEMORY", "HOME", "IMAGE"}}
+	for _, machine := range machines {
+		views = append(views, machineView{ID: machine.ID, Image: machine.Image, State: string(machine.State), Default: machine.IsDefault, CPUs: machine.CPUs, Memory: machine.Memory, HomeMount: machine.HomeMount})
+		isDefault := ""
+		if machine.IsDefault {
+			isDefault = "*"
+		}
+		rows.rows = append(rows.rows, []string{machine.ID, string(machine.State), isDefault, strconv.Itoa(machine.CPUs), machine.Memory, machine.HomeMount, machine.Image})
+	}
+	return views, rows, nil
+}
+
+func registriesOutput(stdout string) (any, tableRows, error) {
+	registries, err := services.ParseRegistryList(stdout)
+	if err != nil {
+		return nil, tableRows{}, err
+	}
+	views := make([]registryView, 0, len(registries))
+	rows := tableRows{header: []string{"HOSTNAME", "USERNAME", "MODIFIED"}}
+	for _, registry := range registries {
+		views = append(views, registryView{Hostname: registry.Hostname, Username: registry.Username, Created: registry.CreatedDate, Modified: registry.ModifiedDate})
+		rows.rows = append(rows.rows, []string{registry.Hostname, registry.Username, registry.ModifiedDate.Format(time.DateTime)})
+	}
+	return views, rows, nil
+}
+
+func statusOutput(stdout string) (any, tableRows, error) {
+	status := services.ParseDaemonStatus(stdout)
+	view := statusView{
+		State:       string(status.State),
+		Running:     status.Running,
+		Version:     status.Version,
+		InstallRoot: status.InstallRoot,
+		AppRoot:     status.AppRoot,
+	}
+	rows := tableRows{header: []string{"STATE", "VERSION"}, rows: [][]string{{view.State, view.Version}}}
+	return view, rows, nil
+}
+
+// shortDigest trims a sha256 digest for table display.
+func shortDigest(digest string) string {
+	const prefix = "sha256:"
+	if len(digest) > len(prefix)+12 && digest[:len(prefix)] == prefix {
+		return digest[len(prefix) : len(prefix)+12]
+	}
+	return digest
+}
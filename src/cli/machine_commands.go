@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// NewMachineCommand returns the `machine` command group.
+func NewMachineCommand(env Environment) *cobra.Command {
+	group := &cobra.Command{Use: "machine", Short: "Start, stop, configure or delete container machines"}
+	group.AddCommand(
+		newMutationCommand(env, "start <machine>", "Start a container machine", func(args []string) services.CommandBuilder {
+			return services.MachineStartBuilder{MachineID: args[0]}
+		}),
+		newMutationCommand(env, "stop <machine>", "Stop a running container machine", func(args []string) services.CommandBuilder {
+			return services.MachineStopBuilder{MachineID: args[0]}
+		}),
+		newMachineSetCommand(env),
+		newMutationCommand(env, "delete <machine>", "Delete a container machine", func(args []string) services.CommandBuilder {
+			return services.MachineDeleteBuilder{MachineID: args[0]}
+		}),
+	)
+	return group
+}
+
+func newMachineSetCommand(env Environment) *cobra.Command {
+	var yes bool
+	builder := services.MachineSetBuilder{}
+	cmd := &cobra.Command{
+		Use:           "set <machine>",
+		Short:         "Change machine resources; omitted values keep their current setting",
+		Args:          exactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			builder.MachineID = args[0]
+			if !env.dryRun() && (builder.CPUs == "" || builder.Memory == "" || builder.HomeMount == "") {
+				executor, err := env.executor()
+				if err != nil {
+					return err
+				}
+				machine, err := findMachine(executor, args[0])
+				if err != nil {
+					return err
+				}
+				builder = fillMachineSet(builder, machine)
+			}
+			return env.apply(cmd, yes, builder, args[0])
+		},
+	}
+	addYesFlag(cmd, &yes)
+	cmd.Flags().StringVar(&builder.CPUs, "cpus", "", "number of CPUs")
+	cmd.Flags().StringVar(&builder.Memory, "memory", "", "memory size, for example 8G")
+	cmd.Flags().StringVar(&builder.HomeMount, "home-mount", "", "home directory mount: rw, ro or none")
+	return cmd
+}
+
+// fillMachineSet copies the machine's current resources into unset builder fields.
+func fillMachineSet(builder services.MachineSetBuilder, machine models.ContainerMachine) services.MachineSetBuilder {
+	if builder.CPUs == "" && machine.CPUs > 0 {
+		builder.CPUs = strconv.Itoa(machine.CPUs)
+	}
+	if builder.Memory == "" {
+		builder.Memory = machine.Memory
+	}
+	if builder.HomeMount == "" {
+		builder.HomeMount = machine.HomeMount
+	}
+	return builder
+}
+
+// findMachine looks up a machine by ID.
+func findMachine(executor services.CommandExecutor, target string) (models.ContainerMachine, error) {
+	command, err := services.MachineListBuilder{}.Build()
+	if err != nil {
+		return models.ContainerMachine{}, err
+	}
+	result, err := executor.Execute(command)
+	if err != nil {
+		return models.ContainerMachine{}, &CommandError{Command: command, Result: result, Err: err}
+	}
+	machines, err := services.ParseMachineList(result.Stdout)
+	if err != nil {
+		return models.ContainerMachine{}, err
+	}
+	for _, machine := range machines {
+		if machine.ID == target {
+			return machine, nil
+		}
+	}
+	return models.ContainerMachine{}, &CommandError{Command: command, Result: result, Err: fmt.Errorf("machine %s not found", target)}
+}
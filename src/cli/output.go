@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// OutputFormat selects how headless commands print results.
+type OutputFormat string
+
+const (
+	// OutputTable prints aligned columns for people.
+	OutputTable OutputFormat = "table"
+	// OutputJSON prints indented JSON for scripts.
+	OutputJSON OutputFormat = "json"
+	// OutputYAML prints YAML for scripts.
+	OutputYAML OutputFormat = "yaml"
+)
+
+// ParseOutputFormat validates the --output flag.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case OutputTable, "":
+		return OutputTable, nil
+	case OutputJSON:
+		return OutputJSON, nil
+	case OutputYAML, "yml":
+		return OutputYAML, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (use table, json or yaml)", value)
+	}
+}
+
+// tableRows renders a value as a header and rows for table output.
+type tableRows struct {
+	header []string
+	rows   [][]string
+}
+
+// writeOutput prints value in format; table output uses rows.
+func writeOutput(w io.Writer, format OutputFormat, value any, rows tableRows) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case OutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.Join(rows.header, "\t"))
+		for _, row := range rows.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 )
 
-// Command represents an executable and its arguments.
+// Command represents an executable and its arguments. Env lists KEY=VALUE variables added
+// to the environment it runs in.
 type Command struct {
 	Executable string
 	Args       []string
+	Env        []string
 }
 
 // String formats the command for previews.
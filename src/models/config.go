@@ -1,21 +1,122 @@
 package models
 
+import "time"
+
 // UserConfig stores persisted user preferences.
 type UserConfig struct {
-	DefaultBuildFile          string `mapstructure:"default_build_file" toml:"default_build_file"`
-	ConfirmDestructiveActions bool   `mapstructure:"confirm_destructive_actions" toml:"confirm_destructive_actions"`
-	ThemeMode                 string `mapstructure:"theme_mode" toml:"theme_mode"`
-	RefreshOnFocus            bool   `mapstructure:"refresh_on_focus" toml:"refresh_on_focus"`
-	LogRetentionDays          int    `mapstructure:"log_retention_days" toml:"log_retention_days"`
+	DefaultBuildFile          string                       `mapstructure:"default_build_file" toml:"default_build_file"`
+	DefaultTagPattern         string                       `mapstructure:"default_tag_pattern" toml:"default_tag_pattern"`
+	BuildContext              string                       `mapstructure:"build_context" toml:"build_context"`
+	PullLatest                bool                         `mapstructure:"pull_latest" toml:"pull_latest"`
+	ConfirmDestructiveActions bool                         `mapstructure:"confirm_destructive_actions" toml:"confirm_destructive_actions"`
+	ThemeMode                 string                       `mapstructure:"theme_mode" toml:"theme_mode"`
+	RefreshOnFocus            bool                         `mapstructure:"refresh_on_focus" toml:"refresh_on_focus"`
+	LogRetentionDays          int                          `mapstructure:"log_retention_days" toml:"log_retention_days"`
+	LogSegmentSizeMB          int                          `mapstructure:"log_segment_size_mb" toml:"log_segment_size_mb"`
+	LogMaxSizeMB              int                          `mapstructure:"log_max_size_mb" toml:"log_max_size_mb"`
+	LogCompress               bool                         `mapstructure:"log_compress" toml:"log_compress"`
+	Audit                     bool                         `mapstructure:"audit" toml:"audit"`
+	CommandTimeouts           map[string]time.Duration     `mapstructure:"command_timeouts" toml:"command_timeouts"`
+	Retry                     RetryConfig                  `mapstructure:"retry" toml:"retry"`
+	Confirmations             map[string]string            `mapstructure:"confirmations" toml:"confirmations"`
+	ReadOnly                  bool                         `mapstructure:"read_only" toml:"read_only"`
+	Policy                    PolicyConfig                 `mapstructure:"policy" toml:"policy"`
+	RedactPatterns            []string                     `mapstructure:"redact_patterns" toml:"redact_patterns"`
+	BuildPresets              map[string]BuildPreset       `mapstructure:"build_presets" toml:"build_presets"`
+	RunPresets                map[string]RunPreset         `mapstructure:"run_presets" toml:"run_presets"`
+	Profile                   string                       `mapstructure:"profile" toml:"profile"`
+	Profiles                  map[string]ConnectionProfile `mapstructure:"profiles" toml:"profiles"`
+}
+
+// ConnectionProfile is a named way of reaching a container runtime: the CLI binary, global arguments
+// placed before every subcommand, extra KEY=VALUE environment variables and, optionally, a
+// remote host that runs the CLI.
+type ConnectionProfile struct {
+	Binary string   `mapstructure:"binary" toml:"binary"`
+	Args   []string `mapstructure:"args" toml:"args"`
+	Env    []string `mapstructure:"env" toml:"env"`
+	// Host is [user@]host[:port], reached over a persistent SSH connection that
+	// authenticates with the SSH agent or IdentityFile and checks KnownHosts.
+	Host         string `mapstructure:"host" toml:"host"`
+	IdentityFile string `mapstructure:"identity_file" toml:"identity_file"`
+	KnownHosts   string `mapstructure:"known_hosts" toml:"known_hosts"`
+	// OpenSSH runs the system ssh client instead, so ~/.ssh/config applies.
+	OpenSSH bool `mapstructure:"openssh" toml:"openssh"`
+}
+
+// RetryConfig controls automatic retries of read-only commands, such as lists, inspects and
+// status checks, that fail with a transient error. Attempts counts the first run, so 1
+// disables retries. The delay starts at Backoff and doubles up to MaxBackoff.
+type RetryConfig struct {
+	Attempts   int           `mapstructure:"attempts" toml:"attempts"`
+	Backoff    time.Duration `mapstructure:"backoff" toml:"backoff"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff" toml:"max_backoff"`
+	// Kinds are the error kinds that are retried, such as "network" and "timeout".
+	Kinds []string `mapstructure:"kinds" toml:"kinds"`
+	// Warmup also retries daemon errors for this long after `system start` or
+	// `machine start`, while the services come up.
+	Warmup time.Duration `mapstructure:"warmup" toml:"warmup"`
+}
+
+// PolicyConfig lists subcommands that are explicitly allowed or denied, such as "machine delete".
+type PolicyConfig struct {
+	Allow []string `mapstructure:"allow" toml:"allow"`
+	Deny  []string `mapstructure:"deny" toml:"deny"`
+}
+
+// BuildPreset is a named set of build screen values. Empty fields keep the screen's value;
+// Tag may use the same placeholders as default_tag_pattern.
+type BuildPreset struct {
+	File       string `mapstructure:"file" toml:"file"`
+	Context    string `mapstructure:"context" toml:"context"`
+	Tag        string `mapstructure:"tag" toml:"tag"`
+	PullLatest *bool  `mapstructure:"pull_latest" toml:"pull_latest"`
+}
+
+// RunPreset is a named set of values for running a container.
+type RunPreset struct {
+	Image      string   `mapstructure:"image" toml:"image"`
+	Name       string   `mapstructure:"name" toml:"name"`
+	Detach     bool     `mapstructure:"detach" toml:"detach"`
+	Ports      []string `mapstructure:"ports" toml:"ports"`
+	Env        []string `mapstructure:"env" toml:"env"`
+	EnvFiles   []string `mapstructure:"env_files" toml:"env_files"`
+	Volumes    []string `mapstructure:"volumes" toml:"volumes"`
+	CPUs       int      `mapstructure:"cpus" toml:"cpus"`
+	Memory     string   `mapstructure:"memory" toml:"memory"`
+	Workdir    string   `mapstructure:"workdir" toml:"workdir"`
+	Entrypoint string   `mapstructure:"entrypoint" toml:"entrypoint"`
+	Command    []string `mapstructure:"command" toml:"command"`
+	Labels     []string `mapstructure:"labels" toml:"labels"`
 }
 
 // DefaultUserConfig returns app defaults.
 func DefaultUserConfig() UserConfig {
 	return UserConfig{
 		DefaultBuildFile:          "Containerfile",
+		PullLatest:                true,
 		ConfirmDestructiveActions: true,
 		ThemeMode:                 "auto",
 		RefreshOnFocus:            false,
 		LogRetentionDays:          7,
+		LogSegmentSizeMB:          16,
+		LogMaxSizeMB:              256,
+		LogCompress:               true,
+		CommandTimeouts: map[string]time.Duration{
+			"default":  2 * time.Minute,
+			"build":    time.Hour,
+			"pull":     30 * time.Minute,
+			"export":   30 * time.Minute,
+			"logs":     0,
+			"run":      30 * time.Minute,
+			"attached": 0,
+		},
+		Retry: RetryConfig{
+			Attempts:   5,
+			Backoff:    250 * time.Millisecond,
+			MaxBackoff: 4 * time.Second,
+			Kinds:      []string{"network"},
+			Warmup:     30 * time.Second,
+		},
 	}
 }
@@ -2,7 +2,9 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 )
 
 // ContainerStatus captures the lifecycle state of a container.
@@ -21,14 +23,76 @@ const (
 	ContainerStatusUnknown ContainerStatus = "unknown"
 )
 
-// Container represents a managed container instance.
+// Container represents a managed container instance. Labels, networks, mounts, platform
+// and CreatedAt are only known when the list was read as JSON.
 type Container struct {
-	ID      string
-	Name    string
-	Image   string
-	Status  ContainerStatus
-	Created string
-	Ports   []PortMapping
+	ID        string
+	Name      string
+	Image     string
+	Status    ContainerStatus
+	Created   string
+	Ports     []PortMapping
+	Labels    map[string]string
+	Networks  []ContainerNetwork
+	Mounts    []Mount
+	Platform  string
+	CreatedAt time.Time
+}
+
+// ContainerNetwork is a network a container is attached to.
+type ContainerNetwork struct {
+	Name     string
+	Address  string
+	Hostname string
+}
+
+// Mount is a host directory or volume mounted into a container.
+type Mount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// ParseMount parses SOURCE:DESTINATION[:ro|:rw], where the source is a host path or a
+// volume name and the destination is an absolute path in the container.
+func ParseMount(spec string) (Mount, error) {
+	spec = strings.TrimSpace(spec)
+	parts := strings.Split(spec, ":")
+	mount := Mount{}
+	switch {
+	case len(parts) == 3 && (parts[2] == "ro" || parts[2] == "rw"):
+		mount.ReadOnly = parts[2] == "ro"
+	case len(parts) != 2:
+		return Mount{}, fmt.Errorf("invalid volume %q: use SOURCE:DESTINATION[:ro]", spec)
+	}
+	mount.Source, mount.Destination = parts[0], parts[1]
+	if err := mount.Validate(); err != nil {
+		return Mount{}, fmt.Errorf("invalid volume %q: %w", spec, err)
+	}
+	return mount, nil
+}
+
+// Validate ensures the mount has a source and an absolute destination.
+func (m Mount) Validate() error {
+	if strings.TrimSpace(m.Source) == "" {
+		return errors.New("source is required")
+	}
+	if !strings.HasPrefix(m.Destination, "/") {
+		return errors.New("destination must be an absolute path")
+	}
+	if strings.Contains(m.Source, ":") || strings.Contains(m.Destination, ":") {
+		return errors.New("paths must not contain \":\"")
+	}
+	return nil
+}
+
+// String formats the mount as SOURCE:DESTINATION, adding :ro for read-only mounts.
+func (m Mount) String() string {
+	mount := m.Source + ":" + m.Destination
+	if m.ReadOnly {
+		mount += ":ro"
+	}
+	return mount
 }
 
 // Validate ensures required fields are present and valid.
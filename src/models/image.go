@@ -3,13 +3,20 @@ package models
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
-// Image represents a local container image.
+// Image represents a local container image. Platform, size, creation time and labels are
+// only known when the list was read as JSON.
 type Image struct {
 	Name   string
 	Tag    string
 	Digest string
+	// Platform lists the image's platforms, such as "linux/arm64".
+	Platform string
+	Size     int64
+	Created  time.Time
+	Labels   map[string]string
 }
 
 // Validate checks image fields.
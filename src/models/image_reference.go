@@ -3,10 +3,26 @@ package models
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
 
+// DefaultImageRegistry is the registry of references without a registry host.
+const DefaultImageRegistry = "docker.io"
+
+// maxImageNameLength is the longest registry and repository a reference may name.
+const maxImageNameLength = 255
+
+// The patterns follow the grammar of github.com/distribution/reference.
+var (
+	registryHostPattern  = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*|\[[a-fA-F0-9:]+\])(?::[0-9]+)?$`)
+	pathComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	imageTagPattern      = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigestPattern   = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+	sha256DigestPattern  = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
 // ImageReference represents a container image reference.
 type ImageReference struct {
 	Registry   string
@@ -15,29 +31,114 @@ type ImageReference struct {
 	Digest     string
 }
 
-// Validate ensures required fields are present.
+// ParseImageReference parses reference as [registry/]repository[:tag][@digest] and
+// normalizes it: references without a registry host are on docker.io, where single-name
+// repositories are in library/. Tag and digest are optional and kept as written.
+func ParseImageReference(reference string) (ImageReference, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return ImageReference{}, errors.New("image reference is required")
+	}
+	if strings.ContainsAny(reference, " \t\r\n") {
+		return ImageReference{}, errors.New("image reference must not contain whitespace")
+	}
+
+	parsed := ImageReference{}
+	name := reference
+	if at := strings.Index(name, "@"); at >= 0 {
+		name, parsed.Digest = name[:at], name[at+1:]
+		if err := validateImageDigest(parsed.Digest); err != nil {
+			return ImageReference{}, err
+		}
+	}
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, parsed.Tag = name[:colon], name[colon+1:]
+		if !imageTagPattern.MatchString(parsed.Tag) {
+			return ImageReference{}, fmt.Errorf("invalid tag %q: use up to 128 letters, digits, \"_\", \".\" or \"-\", starting with a letter, digit or \"_\"", parsed.Tag)
+		}
+	}
+
+	// The first component is a registry host when it could not be a repository name.
+	first, rest, hasSlash := strings.Cut(name, "/")
+	if hasSlash && (strings.ContainsAny(first, ".:") || first == "localhost" || strings.ToLower(first) != first) {
+		if !registryHostPattern.MatchString(first) {
+			return ImageReference{}, fmt.Errorf("invalid registry host %q", first)
+		}
+		parsed.Registry, name = first, rest
+	}
+	if len(parsed.Registry)+1+len(name) > maxImageNameLength {
+		return ImageReference{}, fmt.Errorf("image name must not be longer than %d characters", maxImageNameLength)
+	}
+	for _, component := range strings.Split(name, "/") {
+		if pathComponentPattern.MatchString(component) {
+			continue
+		}
+		if strings.ToLower(name) != name {
+			return ImageReference{}, fmt.Errorf("repository %q must be lowercase", name)
+		}
+		return ImageReference{}, fmt.Errorf("invalid repository %q: use lowercase letters and digits separated by \"/\", \".\", \"_\" or \"-\"", name)
+	}
+
+	switch parsed.Registry {
+	case "", "index.docker.io":
+		parsed.Registry = DefaultImageRegistry
+	}
+	parsed.Repository = name
+	if parsed.Registry == DefaultImageRegistry && !strings.Contains(name, "/") {
+		parsed.Repository = "library/" + name
+	}
+	return parsed, nil
+}
+
+// validateImageDigest checks an algorithm:encoded digest. sha256 digests must have 64
+// lowercase hex digits.
+func validateImageDigest(digest string) error {
+	if !imageDigestPattern.MatchString(digest) || strings.HasPrefix(digest, "sha256:") && !sha256DigestPattern.MatchString(digest) {
+		return fmt.Errorf("invalid digest %q: use sha256: followed by 64 hex digits", digest)
+	}
+	return nil
+}
+
+// Validate ensures required fields are present and form a valid reference.
 func (r ImageReference) Validate() error {
 	if strings.TrimSpace(r.Repository) == "" {
 		return errors.New("repository is required")
 	}
-	return nil
+	_, err := ParseImageReference(r.String())
+	return err
+}
+
+// Name returns the registry and repository without tag or digest.
+func (r ImageReference) Name() string {
+	name := strings.TrimSpace(r.Repository)
+	if r.Registry != "" {
+		name = strings.TrimSpace(r.Registry) + "/" + name
+	}
+	return name
 }
 
 // String formats the reference for CLI usage.
 func (r ImageReference) String() string {
-	reference := strings.TrimSpace(r.Repository)
-	if r.Registry != "" {
-		reference = strings.TrimSpace(r.Registry) + "/" + reference
+	reference := r.Name()
+	if r.Tag != "" {
+		reference += ":" + strings.TrimSpace(r.Tag)
 	}
 	if r.Digest != "" {
-		return reference + "@" + strings.TrimSpace(r.Digest)
-	}
-	if r.Tag != "" {
-		return reference + ":" + strings.TrimSpace(r.Tag)
+		reference += "@" + strings.TrimSpace(r.Digest)
 	}
 	return reference
 }
 
+// NormalizeImageName returns the fully qualified form of an image name, such as
+// docker.io/library/alpine for alpine, or name unchanged when it does not parse.
+func NormalizeImageName(name string) string {
+	reference, err := ParseImageReference(name)
+	if err != nil {
+		return name
+	}
+	return reference.Name()
+}
+
 // BuildExportImageReference creates a deterministic temporary image reference for export workflows.
 func BuildExportImageReference(containerName, containerID string, now time.Time) string {
 	slug := ExportNameSlug(containerName, containerID)
@@ -54,6 +54,40 @@ func TestPortMappingValidate(t *testing.T) {
 	}
 }
 
+func TestParsePortMapping(t *testing.T) {
+	mapping, err := ParsePortMapping("5353:53/udp")
+	if err != nil || mapping != (PortMapping{HostPort: 5353, ContainerPort: 53, Protocol: "udp"}) {
+		t.Fatalf("unexpected mapping %+v (%v)", mapping, err)
+	}
+	if mapping.String() != "5353:53/udp" {
+		t.Fatalf("unexpected string: %s", mapping.String())
+	}
+	mapping, err = ParsePortMapping("8080:80")
+	if err != nil || mapping.Protocol != "tcp" || mapping.String() != "8080:80" {
+		t.Fatalf("expected tcp by default, got %+v (%v)", mapping, err)
+	}
+	for _, spec := range []string{"80", "http:80", "8080:80/sctp", "70000:80"} {
+		if _, err := ParsePortMapping(spec); err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestParseMount(t *testing.T) {
+	mount, err := ParseMount("cache:/cache:ro")
+	if err != nil || mount != (Mount{Source: "cache", Destination: "/cache", ReadOnly: true}) {
+		t.Fatalf("unexpected mount %+v (%v)", mount, err)
+	}
+	if mount.String() != "cache:/cache:ro" {
+		t.Fatalf("unexpected string: %s", mount.String())
+	}
+	for _, spec := range []string{"/data", "data:relative", ":/data", "a:/b:rx"} {
+		if _, err := ParseMount(spec); err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+	}
+}
+
 func TestImageReferenceString(t *testing.T) {
 	ref := ImageReference{Registry: "docker.io", Repository: "library/alpine", Tag: "latest"}
 	if ref.String() != "docker.io/library/alpine:latest" {
@@ -1,6 +1,11 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // PortMapping represents a host-to-container port binding.
 type PortMapping struct {
@@ -9,6 +14,31 @@ type PortMapping struct {
 	Protocol      string
 }
 
+// ParsePortMapping parses HOST:CONTAINER[/PROTOCOL], where the protocol is tcp or udp and
+// defaults to tcp.
+func ParsePortMapping(spec string) (PortMapping, error) {
+	spec = strings.TrimSpace(spec)
+	ports, protocol, hasProtocol := strings.Cut(spec, "/")
+	if !hasProtocol {
+		protocol = "tcp"
+	}
+	host, container, ok := strings.Cut(ports, ":")
+	if !ok {
+		return PortMapping{}, fmt.Errorf("invalid port mapping %q: use HOST:CONTAINER[/tcp|udp]", spec)
+	}
+	mapping := PortMapping{Protocol: strings.ToLower(protocol)}
+	var hostErr, containerErr error
+	mapping.HostPort, hostErr = strconv.Atoi(host)
+	mapping.ContainerPort, containerErr = strconv.Atoi(container)
+	if hostErr != nil || containerErr != nil {
+		return PortMapping{}, fmt.Errorf("invalid port mapping %q: ports must be numbers", spec)
+	}
+	if err := mapping.Validate(); err != nil {
+		return PortMapping{}, fmt.Errorf("invalid port mapping %q: %w", spec, err)
+	}
+	return mapping, nil
+}
+
 // Validate ensures port mapping values are in range.
 func (p PortMapping) Validate() error {
 	if p.HostPort < 1 || p.HostPort > 65535 {
@@ -20,5 +50,17 @@ func (p PortMapping) Validate() error {
 	if p.Protocol == "" {
 		return errors.New("protocol is required")
 	}
+	if p.Protocol != "tcp" && p.Protocol != "udp" {
+		return errors.New("protocol must be tcp or udp")
+	}
 	return nil
 }
+
+// String formats the mapping as HOST:CONTAINER, adding the protocol unless it is tcp.
+func (p PortMapping) String() string {
+	mapping := fmt.Sprintf("%d:%d", p.HostPort, p.ContainerPort)
+	if p.Protocol != "" && p.Protocol != "tcp" {
+		mapping += "/" + p.Protocol
+	}
+	return mapping
+}
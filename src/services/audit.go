@@ -0,0 +1,391 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AuditMetadata identifies who wrote audited log entries.
+type AuditMetadata struct {
+	Host    string
+	User    string
+	Version string
+}
+
+// NewAuditMetadata describes the current host and user for actui version.
+func NewAuditMetadata(version string) AuditMetadata {
+	metadata := AuditMetadata{Version: version}
+	metadata.Host, _ = os.Hostname()
+	if current, err := user.Current(); err == nil {
+		metadata.User = current.Username
+	} else {
+		metadata.User = os.Getenv("USER")
+	}
+	return metadata
+}
+
+// chain stamps entry with the metadata and links it to head.
+func (m AuditMetadata) chain(entry LogEntry, head auditHead) LogEntry {
+	entry.Sequence = head.Sequence + 1
+	entry.PrevHash = head.Hash
+	entry.Host = m.Host
+	entry.User = m.User
+	entry.Version = m.Version
+	entry.Hash = hashLogEntry(entry)
+	return entry
+}
+
+// hashLogEntry hashes every field of entry except its own hash. PrevHash is included,
+// which chains the entry to its predecessor.
+func hashLogEntry(entry LogEntry) string {
+	entry.Hash = ""
+	data, _ := json.Marshal(entry)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditSeal records a rolled-over segment: the chain positions it covers and a digest of
+// its content. Seals are chained like entries and outlive the segments they describe, so
+// retention shows up as expired segments rather than gaps.
+type AuditSeal struct {
+	Segment       string    `json:"segment"`
+	Entries       int       `json:"entries"`
+	FirstSequence uint64    `json:"first_seq,omitempty"`
+	LastSequence  uint64    `json:"last_seq,omitempty"`
+	PrevHash      string    `json:"prev_hash,omitempty"`
+	LastHash      string    `json:"last_hash,omitempty"`
+	Digest        string    `json:"digest"`
+	SealedAt      time.Time `json:"sealed_at"`
+	PrevSeal      string    `json:"prev_seal,omitempty"`
+	Hash          string    `json:"hash"`
+}
+
+func hashAuditSeal(seal AuditSeal) string {
+	seal.Hash = ""
+	data, _ := json.Marshal(seal)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// digestSegment reads the segment at path and returns a seal describing it, without the
+// seal chain fields.
+func digestSegment(path string, compressed bool) (AuditSeal, error) {
+	seal := AuditSeal{Segment: strings.TrimSuffix(filepath.Base(path), ".gz")}
+	digest := sha256.New()
+	err := forEachLogLine(path, compressed, func(line []byte) {
+		digest.Write(line)
+		digest.Write([]byte{'\n'})
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err != nil || strings.TrimSpace(entry.Command) == "" {
+			return
+		}
+		seal.Entries++
+		if entry.Hash == "" {
+			return
+		}
+		if seal.FirstSequence == 0 {
+			seal.FirstSequence = entry.Sequence
+			seal.PrevHash = entry.PrevHash
+		}
+		seal.LastSequence = entry.Sequence
+		seal.LastHash = entry.Hash
+	})
+	seal.Digest = hex.EncodeToString(digest.Sum(nil))
+	return seal, err
+}
+
+// sealSegment appends a seal for the archived segment name. The caller holds the
+// exclusive lock.
+func (f logFiles) sealSegment(name string, now time.Time) error {
+	seal, err := digestSegment(filepath.Join(f.dir, name), strings.HasSuffix(name, ".gz"))
+	if err != nil {
+		return err
+	}
+	return f.addSeal(seal, now)
+}
+
+// sealHead records the newest audited entry of the active segment as a head seal. It has
+// no digest, since the active segment still grows, but chaining it with the other seals
+// means entries cut from the end of the log are found even without the index. The caller
+// holds the exclusive lock.
+func (f logFiles) sealHead(active LogSegment, now time.Time) error {
+	return f.addSeal(AuditSeal{
+		Segment:      filepath.Base(f.path),
+		Entries:      active.Entries,
+		LastSequence: active.LastSequence,
+		LastHash:     active.LastHash,
+	}, now)
+}
+
+// addSeal chains seal to the newest seal and writes it last. A head seal is only ever the
+// last line, and is replaced by the next seal. The caller holds the exclusive lock.
+func (f logFiles) addSeal(seal AuditSeal, now time.Time) error {
+	data, err := os.ReadFile(f.sealsPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	kept, last := splitLastLine(data)
+	var previous AuditSeal
+	if len(last) > 0 {
+		_ = json.Unmarshal(last, &previous)
+		if previous.Segment == filepath.Base(f.path) {
+			_, last = splitLastLine(kept)
+			previous = AuditSeal{}
+			_ = json.Unmarshal(last, &previous)
+		} else {
+			kept = data
+		}
+	}
+	if len(kept) > 0 && kept[len(kept)-1] != '\n' {
+		kept = append(kept, '\n')
+	}
+	seal.PrevSeal = previous.Hash
+	seal.SealedAt = now
+	seal.Hash = hashAuditSeal(seal)
+
+	line, err := json.Marshal(seal)
+	if err != nil {
+		return err
+	}
+	tempPath := f.sealsPath() + ".tmp"
+	if err := os.WriteFile(tempPath, append(kept, append(line, '\n')...), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, f.sealsPath())
+}
+
+// splitLastLine splits data before its last non-empty line.
+func splitLastLine(data []byte) ([]byte, []byte) {
+	trimmed := strings.TrimRight(string(data), "\n")
+	start := strings.LastIndexByte(trimmed, '\n') + 1
+	return data[:start], []byte(trimmed[start:])
+}
+
+// readSeals returns the seals in the order they were written. Unreadable lines are kept
+// as empty seals so verification reports them.
+func (f logFiles) readSeals() ([]AuditSeal, error) {
+	seals := make([]AuditSeal, 0)
+	err := forEachLogLine(f.sealsPath(), false, func(line []byte) {
+		var seal AuditSeal
+		_ = json.Unmarshal(line, &seal)
+		seals = append(seals, seal)
+	})
+	return seals, err
+}
+
+// AuditIssue is one problem found while verifying the audit trail.
+type AuditIssue struct {
+	Segment  string `json:"segment,omitempty" yaml:"segment,omitempty"`
+	Sequence uint64 `json:"seq,omitempty" yaml:"seq,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+func (i AuditIssue) String() string {
+	if i.Segment == "" {
+		return i.Message
+	}
+	return i.Segment + ": " + i.Message
+}
+
+// AuditReport summarizes a verification of the audit trail.
+type AuditReport struct {
+	Segments      int          `json:"segments" yaml:"segments"`
+	Sealed        int          `json:"sealed" yaml:"sealed"`
+	Expired       int          `json:"expired" yaml:"expired"`
+	Entries       int          `json:"entries" yaml:"entries"`
+	Audited       int          `json:"audited" yaml:"audited"`
+	FirstSequence uint64       `json:"firstSeq,omitempty" yaml:"firstSeq,omitempty"`
+	LastSequence  uint64       `json:"lastSeq,omitempty" yaml:"lastSeq,omitempty"`
+	Issues        []AuditIssue `json:"issues" yaml:"issues"`
+}
+
+// OK reports whether no gaps, edits or truncation were found.
+func (r AuditReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *AuditReport) issue(segment string, sequence uint64, format string, args ...any) {
+	r.Issues = append(r.Issues, AuditIssue{Segment: segment, Sequence: sequence, Message: fmt.Sprintf(format, args...)})
+}
+
+// ErrNoAuditTrail is returned when the log holds no audited entries or seals.
+var ErrNoAuditTrail = errors.New("command log has no audit trail; set audit = true in the config")
+
+// VerifyAuditLog checks the hash chain of the command log at path across every segment.
+// Segments removed by retention are accepted when their seals are intact and they precede
+// every remaining segment; anything else missing, edited or reordered is reported.
+func VerifyAuditLog(path string) (AuditReport, error) {
+	files := newLogFiles(path)
+	report := AuditReport{Issues: []AuditIssue{}}
+	err := files.withLock(false, func() error {
+		seals, err := files.readSeals()
+		if err != nil {
+			return err
+		}
+		names, err := files.listSegments()
+		if err != nil {
+			return err
+		}
+		var stored logIndex
+		hasIndex := false
+		if data, err := os.ReadFile(files.indexPath()); err == nil && json.Unmarshal(data, &stored) == nil {
+			hasIndex = true
+		}
+
+		chain := verifySeals(files, seals, names, &report)
+		for _, name := range names {
+			report.Segments++
+			compressed := strings.HasSuffix(name, ".gz")
+			segmentPath := filepath.Join(files.dir, name)
+			seal, sealed := chain.sealFor(name)
+			if sealed {
+				report.Sealed++
+				current, err := digestSegment(segmentPath, compressed)
+				if err != nil {
+					return err
+				}
+				if current.Digest != seal.Digest {
+					report.issue(name, 0, "segment content changed after it was sealed")
+				}
+			}
+			audited, err := chain.walk(name, segmentPath, compressed, &report)
+			if err != nil {
+				return err
+			}
+			if audited && !sealed {
+				report.issue(name, 0, "segment is not sealed")
+			}
+		}
+		report.Segments++
+		if _, err := chain.walk(filepath.Base(path), path, false, &report); err != nil {
+			return err
+		}
+
+		// The index holds the head too; without it, only a head seal catches a truncated tail.
+		if !hasIndex && (report.Audited > 0 || len(seals) > 0) {
+			report.issue(filepath.Base(files.indexPath()), 0, "index is missing or unreadable")
+		}
+		head := uint64(0)
+		if hasIndex && stored.Head != nil {
+			head = stored.Head.Sequence
+		}
+		if chain.head != nil && chain.head.LastSequence > head {
+			head = chain.head.LastSequence
+		}
+		if head > chain.sequence {
+			report.issue(filepath.Base(path), chain.sequence+1, "log truncated: entries %d-%d are missing from the end", chain.sequence+1, head)
+		}
+		if report.Audited == 0 && len(seals) == 0 && (!hasIndex || stored.Head == nil) {
+			return ErrNoAuditTrail
+		}
+		return nil
+	})
+	return report, err
+}
+
+// auditChain tracks the expected next link while walking segments oldest first.
+type auditChain struct {
+	seals    map[string]AuditSeal
+	head     *AuditSeal
+	started  bool
+	sequence uint64
+	hash     string
+}
+
+func (c *auditChain) sealFor(name string) (AuditSeal, bool) {
+	seal, ok := c.seals[strings.TrimSuffix(name, ".gz")]
+	return seal, ok
+}
+
+// verifySeals checks the seal chain and accounts for segments deleted by retention. It
+// returns the chain positioned after the newest expired segment, with the head seal if any.
+func verifySeals(files logFiles, seals []AuditSeal, names []string, report *AuditReport) *auditChain {
+	chain := &auditChain{seals: make(map[string]AuditSeal, len(seals))}
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[strings.TrimSuffix(name, ".gz")] = true
+	}
+	oldestPresent := ""
+	if len(names) > 0 {
+		oldestPresent = strings.TrimSuffix(names[0], ".gz")
+	}
+
+	previous := ""
+	sealsName := filepath.Base(files.sealsPath())
+	for position, seal := range seals {
+		if seal.Hash == "" || hashAuditSeal(seal) != seal.Hash {
+			report.issue(sealsName, 0, "seal %d was modified", position+1)
+		} else if seal.PrevSeal != previous {
+			report.issue(sealsName, 0, "seal %d does not follow seal %d", position+1, position)
+		}
+		previous = seal.Hash
+		if seal.Segment == filepath.Base(files.path) {
+			if position != len(seals)-1 {
+				report.issue(sealsName, 0, "head seal %d is followed by other seals", position+1)
+			}
+			head := seal
+			chain.head = &head
+			continue
+		}
+		chain.seals[seal.Segment] = seal
+		if present[seal.Segment] {
+			continue
+		}
+		if oldestPresent != "" && seal.Segment > oldestPresent {
+			report.issue(seal.Segment, seal.FirstSequence, "sealed segment is missing")
+		} else {
+			report.Expired++
+		}
+		if seal.LastSequence > 0 {
+			chain.started = true
+			chain.sequence = seal.LastSequence
+			chain.hash = seal.LastHash
+		}
+	}
+	return chain
+}
+
+// walk verifies the entries of one segment and reports whether it held audited entries.
+func (c *auditChain) walk(name string, path string, compressed bool, report *AuditReport) (bool, error) {
+	audited := false
+	err := forEachLogEntry(path, compressed, func(entry LogEntry) {
+		report.Entries++
+		if entry.Hash == "" {
+			if c.started {
+				report.issue(name, 0, "entry %q at %s has no audit hash", entry.Command, entry.StartTime.Format(time.RFC3339))
+			}
+			return
+		}
+		audited = true
+		report.Audited++
+		if report.FirstSequence == 0 {
+			report.FirstSequence = entry.Sequence
+		}
+		report.LastSequence = entry.Sequence
+
+		if hashLogEntry(entry) != entry.Hash {
+			report.issue(name, entry.Sequence, "entry %d was modified", entry.Sequence)
+		}
+		expected := c.sequence + 1
+		switch {
+		case entry.Sequence > expected:
+			report.issue(name, expected, "entries %d-%d are missing", expected, entry.Sequence-1)
+		case entry.Sequence < expected:
+			report.issue(name, entry.Sequence, "entry %d is duplicated or out of order", entry.Sequence)
+		case entry.PrevHash != c.hash:
+			report.issue(name, entry.Sequence, "entry %d does not chain to entry %d", entry.Sequence, c.sequence)
+		}
+		c.started = true
+		c.sequence = entry.Sequence
+		c.hash = entry.Hash
+	})
+	return audited, err
+}
@@ -17,7 +17,7 @@ type BuildImageBuilder struct {
 
 // Validate ensures required inputs are provided.
 func (b BuildImageBuilder) Validate() error {
-	if _, err := normalizeRequiredToken(b.Tag, "tag"); err != nil {
+	if _, err := normalizeImageReference(b.Tag, "tag"); err != nil {
 		return err
 	}
 	if strings.TrimSpace(b.FilePath) == "" {
@@ -34,7 +34,7 @@ func (b BuildImageBuilder) Build() (models.Command, error) {
 	if err := b.Validate(); err != nil {
 		return models.Command{}, err
 	}
-	tag, _ := normalizeRequiredToken(b.Tag, "tag")
+	tag, _ := normalizeImageReference(b.Tag, "tag")
 	filePath := strings.TrimSpace(b.FilePath)
 	contextPath := strings.TrimSpace(b.ContextPath)
 	args := []string{"build"}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"container-tui/src/models"
+)
+
+// DefaultBulkConcurrency is how many items of a bulk action run at once.
+const DefaultBulkConcurrency = 4
+
+// BulkItem is one item of a bulk action and the commands that act on it, in order.
+type BulkItem struct {
+	Name     string
+	Commands []models.Command
+}
+
+// BulkOutcome reports how one item of a bulk action ended. Result is the result of the
+// last command that ran for the item.
+type BulkOutcome struct {
+	Name   string
+	Result models.Result
+	Err    error
+}
+
+// Succeeded reports whether every command of the item ran successfully.
+func (o BulkOutcome) Succeeded() bool {
+	return o.Err == nil
+}
+
+// BulkCommands returns the commands of items in the order they are listed.
+func BulkCommands(items []BulkItem) []models.Command {
+	commands := []models.Command{}
+	for _, item := range items {
+		commands = append(commands, item.Commands...)
+	}
+	return commands
+}
+
+// RunBulk runs items with at most concurrency of them at a time, DefaultBulkConcurrency
+// when it is not positive. The commands of an item run in order and stop at its first
+// failure. Once a command is canceled, items that have not started are canceled too.
+// Outcomes are returned in the order of items.
+func RunBulk(ctx context.Context, executor CommandExecutor, items []BulkItem, concurrency int) []BulkOutcome {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make([]BulkOutcome, len(items))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for index, item := range items {
+		outcomes[index].Name = item.Name
+		if !acquireSlot(ctx, slots) {
+			outcomes[index].Result, outcomes[index].Err = interruptedResult(ctx, 0)
+			continue
+		}
+		wg.Add(1)
+		go func(index int, item BulkItem) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			for _, command := range item.Commands {
+				result, err := ExecuteContext(ctx, executor, command)
+				outcomes[index].Result, outcomes[index].Err = result, err
+				if IsCanceled(err) {
+					cancel()
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(index, item)
+	}
+	wg.Wait()
+	return outcomes
+}
+
+// acquireSlot waits for a free slot, and reports false once ctx is done.
+func acquireSlot(ctx context.Context, slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		if ctx.Err() == nil {
+			return true
+		}
+		<-slots
+	case <-ctx.Done():
+	}
+	return false
+}
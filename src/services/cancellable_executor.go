@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"container-tui/src/models"
+)
+
+// CancellableExecutor tracks in-flight commands so the UI can abort them on request.
+type CancellableExecutor struct {
+	delegate CommandExecutor
+	mu       sync.Mutex
+	nextID   int
+	inFlight map[int]context.CancelFunc
+}
+
+// NewCancellableExecutor builds a cancellable executor.
+func NewCancellableExecutor(delegate CommandExecutor) *CancellableExecutor {
+	return &CancellableExecutor{delegate: delegate, inFlight: map[int]context.CancelFunc{}}
+}
+
+// Execute runs the command until it finishes or CancelAll is called.
+func (c *CancellableExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return c.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command until it finishes, ctx is done, or CancelAll is called.
+func (c *CancellableExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	id := c.register(cancel)
+	defer c.unregister(id)
+	return ExecuteContext(runCtx, c.delegate, cmd)
+}
+
+// CancelAll cancels every in-flight command and returns how many were canceled.
+func (c *CancellableExecutor) CancelAll() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := len(c.inFlight)
+	for id, cancel := range c.inFlight {
+		cancel()
+		delete(c.inFlight, id)
+	}
+	return count
+}
+
+// InFlight returns the number of running commands.
+func (c *CancellableExecutor) InFlight() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.inFlight)
+}
+
+func (c *CancellableExecutor) register(cancel context.CancelFunc) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	c.inFlight[c.nextID] = cancel
+	return c.nextID
+}
+
+func (c *CancellableExecutor) unregister(id int) {
+	c.mu.Lock()
+	cancel, ok := c.inFlight[id]
+	delete(c.inFlight, id)
+	c.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// Stream runs the command until it finishes, ctx is done, or CancelAll is called.
+func (c *CancellableExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	id := c.register(cancel)
+	defer c.unregister(id)
+	return StreamContext(runCtx, c.delegate, cmd, onLine)
+}
+
+// Unwrap returns the wrapped executor.
+func (c *CancellableExecutor) Unwrap() CommandExecutor {
+	return c.delegate
+}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// Capability names a subcommand or flag that not every container CLI release has.
+type Capability string
+
+const (
+	CapabilityMachine  Capability = "machine"
+	CapabilityRegistry Capability = "registry"
+	CapabilityBuilder  Capability = "builder"
+	CapabilityNetwork  Capability = "network"
+	CapabilityVolume   Capability = "volume"
+	// CapabilityContainerListJSON is `container list --format json`.
+	CapabilityContainerListJSON Capability = "list --format json"
+	// CapabilityImageListJSON is `container image list --format json`.
+	CapabilityImageListJSON Capability = "image list --format json"
+)
+
+// subcommandCapabilities are discovered from the subcommands listed by `container --help`.
+var subcommandCapabilities = []Capability{CapabilityMachine, CapabilityRegistry, CapabilityBuilder, CapabilityNetwork, CapabilityVolume}
+
+// formatProbes are discovered from the --format flag in the help of each command.
+var formatProbes = []struct {
+	capability Capability
+	args       []string
+}{
+	{CapabilityContainerListJSON, []string{"list"}},
+	{CapabilityImageListJSON, []string{"image", "list"}},
+}
+
+// capabilityEffects describes what actui does without the capabilities it uses, in the
+// order the compatibility report lists them.
+var capabilityEffects = []struct {
+	capability Capability
+	effect     string
+}{
+	{CapabilityMachine, "the Machines screen is hidden"},
+	{CapabilityRegistry, "the registry browser is hidden"},
+	{CapabilityBuilder, "image builds are hidden"},
+	{CapabilityContainerListJSON, "containers are read from table output"},
+	{CapabilityImageListJSON, "images are read from table output"},
+}
+
+// CLIVersion is a container CLI release.
+type CLIVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// MinimumCLIVersion is the oldest container CLI release actui supports. It is 0.10.0, the
+// release its `list --format json` and `image list --format json` parsing was verified on
+// (see the validation profile in docs/binary-build-automation.md). Newer features, such
+// as machines, are detected separately.
+var MinimumCLIVersion = CLIVersion{Minor: 10}
+
+var cliVersionPattern = regexp.MustCompile(`version\s+v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseCLIVersion reads the first version number in `container system version` output,
+// which belongs to the CLI rather than the API server.
+func ParseCLIVersion(output string) (CLIVersion, bool) {
+	match := cliVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return CLIVersion{}, false
+	}
+	version := CLIVersion{}
+	version.Major, _ = strconv.Atoi(match[1])
+	version.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		version.Patch, _ = strconv.Atoi(match[3])
+	}
+	return version, true
+}
+
+// IsZero reports whether the version is unknown.
+func (v CLIVersion) IsZero() bool {
+	return v == CLIVersion{}
+}
+
+// Less reports whether v is an older release than other.
+func (v CLIVersion) Less(other CLIVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+func (v CLIVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Capabilities is what the container CLI supports. The zero value is unknown: every
+// capability is assumed present and builders keep their table output.
+type Capabilities struct {
+	// VersionLine is the CLI line of `container system version`, which keys the cache.
+	VersionLine string              `json:"version_line"`
+	Version     CLIVersion          `json:"version"`
+	Features    map[Capability]bool `json:"features"`
+	ProbedAt    time.Time           `json:"probed_at"`
+}
+
+// Known reports whether the capabilities were probed.
+func (c Capabilities) Known() bool {
+	return c.Features != nil
+}
+
+// Has reports whether the CLI supports capability. Unknown capabilities are assumed
+// present, so a failed probe never hides an action.
+func (c Capabilities) Has(capability Capability) bool {
+	if !c.Known() {
+		return true
+	}
+	return c.Features[capability]
+}
+
+// OutputFormat returns "json" when the probe found `--format json` for the command named by
+// capability, and "table" otherwise.
+func (c Capabilities) OutputFormat(capability Capability) string {
+	if c.Known() && c.Features[capability] {
+		return "json"
+	}
+	return "table"
+}
+
+// Supported reports whether the CLI version is known and at least MinimumCLIVersion.
+func (c Capabilities) Supported() bool {
+	return !c.Version.IsZero() && !c.Version.Less(MinimumCLIVersion)
+}
+
+// CompatibilityReport explains how the CLI falls short of what actui expects, or returns
+// "" when it does not or when nothing was detected.
+func (c Capabilities) CompatibilityReport() string {
+	if c.VersionLine == "" {
+		return ""
+	}
+	lines := []string{}
+	switch {
+	case c.Version.IsZero():
+		lines = append(lines, fmt.Sprintf("could not read the container CLI version from %q; actui supports %s and later", c.VersionLine, MinimumCLIVersion))
+	case !c.Supported():
+		lines = append(lines, fmt.Sprintf("container CLI %s is older than %s, the oldest release actui supports; upgrade from https://github.com/apple/container/releases", c.Version, MinimumCLIVersion))
+	}
+	for _, missing := range c.Missing() {
+		for _, info := range capabilityEffects {
+			if info.capability == missing {
+				lines = append(lines, fmt.Sprintf("no `%s`: %s", missing, info.effect))
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "CLI compatibility:\n  " + strings.Join(lines, "\n  ") + "\n"
+}
+
+// Missing returns the capabilities actui uses that the CLI lacks.
+func (c Capabilities) Missing() []Capability {
+	missing := []Capability{}
+	for _, info := range capabilityEffects {
+		if !c.Has(info.capability) {
+			missing = append(missing, info.capability)
+		}
+	}
+	return missing
+}
+
+// CapabilityService probes the container CLI behind an executor and caches the result per
+// CLI version, so only `container system version` runs once a version has been seen.
+type CapabilityService struct {
+	executor  CommandExecutor
+	cachePath string
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewCapabilityService builds a service that probes through executor. An empty cachePath
+// disables the cache.
+func NewCapabilityService(executor CommandExecutor, cachePath string) *CapabilityService {
+	return &CapabilityService{executor: executor, cachePath: cachePath, now: time.Now}
+}
+
+// DefaultCapabilityCachePath returns capabilities.json in the state directory.
+func DefaultCapabilityCachePath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "capabilities.json"), nil
+}
+
+// CapabilityVersionCommand returns the command Detect runs first to read the CLI version.
+func CapabilityVersionCommand() models.Command {
+	return models.Command{Executable: "container", Args: []string{"system", "version"}}
+}
+
+// Detect reads the CLI version and returns its capabilities, probing `--help` output when
+// the version is not cached. When the version cannot be read the capabilities are unknown;
+// when the help cannot be read they are unknown but keep the version.
+func (s *CapabilityService) Detect(ctx context.Context) (Capabilities, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output, err := s.probe(ctx, CapabilityVersionCommand().Args...)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	cache := s.readCache()
+	if cached, ok := cache[line]; ok && cached.Known() {
+		return cached, nil
+	}
+
+	capabilities := Capabilities{VersionLine: line}
+	capabilities.Version, _ = ParseCLIVersion(line)
+	help, err := s.probe(ctx, "--help")
+	if err != nil {
+		return capabilities, err
+	}
+	subcommands := parseHelpSubcommands(help)
+	capabilities.Features = map[Capability]bool{}
+	for _, capability := range subcommandCapabilities {
+		capabilities.Features[capability] = subcommands[string(capability)]
+	}
+	for _, probe := range formatProbes {
+		if !subcommands[probe.args[0]] {
+			continue
+		}
+		if help, err := s.probe(ctx, append(probe.args, "--help")...); err == nil {
+			capabilities.Features[probe.capability] = helpHasJSONFormat(help)
+		}
+	}
+	capabilities.ProbedAt = s.now()
+
+	cache[line] = capabilities
+	_ = s.writeCache(cache)
+	return capabilities, nil
+}
+
+// probe runs a `container` command and returns its output.
+func (s *CapabilityService) probe(ctx context.Context, args ...string) (string, error) {
+	cmd := models.Command{Executable: "container", Args: args}
+	result, err := ExecuteContext(ctx, s.executor, cmd)
+	if err != nil {
+		message := strings.TrimSpace(result.Stderr)
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("probe %s: %s", cmd.String(), message)
+	}
+	return result.Stdout, nil
+}
+
+func (s *CapabilityService) readCache() map[string]Capabilities {
+	cache := map[string]Capabilities{}
+	if s.cachePath == "" {
+		return cache
+	}
+	data, err := os.ReadFile(s.cachePath)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return map[string]Capabilities{}
+	}
+	return cache
+}
+
+func (s *CapabilityService) writeCache(cache map[string]Capabilities) error {
+	if s.cachePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.cachePath, append(data, '\n'), 0o644)
+}
+
+// parseHelpSubcommands returns the names and aliases listed in the SUBCOMMANDS sections of
+// help output, where each entry reads "name, alias   description".
+func parseHelpSubcommands(help string) map[string]bool {
+	names := map[string]bool{}
+	inSection := false
+	for _, line := range strings.Split(help, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasSuffix(trimmed, "SUBCOMMANDS:"):
+			inSection = true
+		case trimmed == "":
+			inSection = false
+		case inSection && strings.HasPrefix(line, " "):
+			entry, _, _ := strings.Cut(trimmed, "  ")
+			for _, name := range strings.Split(entry, ",") {
+				name = strings.TrimSpace(name)
+				name = strings.TrimSuffix(name, " (default)")
+				if name != "" {
+					names[name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// helpHasJSONFormat reports whether help output documents a --format flag that accepts
+// json. The flag's description may wrap onto the next line.
+func helpHasJSONFormat(help string) bool {
+	lines := strings.Split(help, "\n")
+	for index, line := range lines {
+		if !strings.Contains(line, "--format") {
+			continue
+		}
+		description := line
+		if index+1 < len(lines) {
+			description += " " + lines[index+1]
+		}
+		if strings.Contains(strings.ToLower(description), "json") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"container-tui/src/models"
+)
+
+// ClassifyingExecutor returns failures as *RuntimeError, so callers branch on the kind,
+// resource and remedy of an error instead of its message.
+type ClassifyingExecutor struct {
+	delegate CommandExecutor
+}
+
+// NewClassifyingExecutor builds a classifying executor.
+func NewClassifyingExecutor(delegate CommandExecutor) *ClassifyingExecutor {
+	return &ClassifyingExecutor{delegate: delegate}
+}
+
+// Execute runs the command and classifies its failure.
+func (c *ClassifyingExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return c.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command under ctx and classifies its failure.
+func (c *ClassifyingExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	result, err := ExecuteContext(ctx, c.delegate, cmd)
+	return result, classify(cmd, result, err)
+}
+
+// Stream streams the command and classifies its failure.
+func (c *ClassifyingExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	result, err := StreamContext(ctx, c.delegate, cmd, onLine)
+	return result, classify(cmd, result, err)
+}
+
+// Unwrap returns the wrapped executor.
+func (c *ClassifyingExecutor) Unwrap() CommandExecutor {
+	return c.delegate
+}
+
+// classify converts err to a *RuntimeError, keeping a nil error nil.
+func classify(cmd models.Command, result models.Result, err error) error {
+	if runtimeErr := NewRuntimeError(cmd, result, err); runtimeErr != nil {
+		return runtimeErr
+	}
+	return nil
+}
@@ -6,23 +6,33 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"container-tui/src/models"
 )
 
-// CheckCLI verifies the Apple Container CLI is available and responding.
-func CheckCLI(ctx context.Context) error {
-	if _, err := exec.LookPath("container"); err != nil {
-		return fmt.Errorf("apple container CLI not found in PATH. Please install from https://github.com/apple/container")
+// CheckCLI verifies the Apple Container CLI of profile is available and responding. Profiles
+// reached over the built-in SSH client are checked by ProfileExecutor.CheckCLI.
+func CheckCLI(ctx context.Context, profile Profile) error {
+	command := profile.Apply(models.Command{Executable: "container", Args: []string{"system", "version"}})
+	if _, err := exec.LookPath(command.Executable); err != nil {
+		if command.Executable == "container" {
+			return fmt.Errorf("apple container CLI not found in PATH. Please install from https://github.com/apple/container")
+		}
+		return fmt.Errorf("profile %s: %s not found", profile.Name, command.Executable)
 	}
 
-	command := exec.CommandContext(ctx, "container", "system", "version")
 	var stderr bytes.Buffer
-	command.Stderr = &stderr
+	process := newProcess(ctx, command)
+	process.Stderr = &stderr
 
-	if err := command.Run(); err != nil {
+	if err := process.Run(); err != nil {
 		message := strings.TrimSpace(stderr.String())
 		if message == "" {
 			message = err.Error()
 		}
+		if profile.Host != "" {
+			return fmt.Errorf("failed to verify Apple Container CLI on %s: %s", profile.Host, message)
+		}
 		return fmt.Errorf("failed to verify Apple Container CLI: %s", message)
 	}
 
@@ -0,0 +1,66 @@
+package services
+
+import "container-tui/src/models"
+
+// CommandKind groups builder commands that share execution policies such as timeouts.
+type CommandKind string
+
+const (
+	// CommandKindDefault covers short-lived commands such as list, inspect and start.
+	CommandKindDefault CommandKind = "default"
+	// CommandKindBuild covers `container build`.
+	CommandKindBuild CommandKind = "build"
+	// CommandKindPull covers `container image pull`.
+	CommandKindPull CommandKind = "pull"
+	// CommandKindExport covers `container export` and `container image save`.
+	CommandKindExport CommandKind = "export"
+	// CommandKindLogs covers container and machine log commands.
+	CommandKindLogs CommandKind = "logs"
+	// CommandKindRun covers `container run --detach`, which may pull the image first.
+	CommandKindRun CommandKind = "run"
+	// CommandKindAttached covers `container run` in the foreground, which lasts as long as
+	// the container.
+	CommandKindAttached CommandKind = "attached"
+)
+
+// CommandKinds lists every known command kind.
+func CommandKinds() []CommandKind {
+	return []CommandKind{CommandKindDefault, CommandKindBuild, CommandKindPull, CommandKindExport, CommandKindLogs, CommandKindRun, CommandKindAttached}
+}
+
+// ClassifyCommand maps a built command to its kind.
+func ClassifyCommand(cmd models.Command) CommandKind {
+	args := cmd.Args
+	if len(args) == 0 {
+		return CommandKindDefault
+	}
+	switch args[0] {
+	case "build":
+		return CommandKindBuild
+	case "export":
+		return CommandKindExport
+	case "logs":
+		return CommandKindLogs
+	case "run":
+		for _, arg := range args[1:] {
+			if arg == "--detach" || arg == "-d" {
+				return CommandKindRun
+			}
+		}
+		return CommandKindAttached
+	case "image":
+		if len(args) > 1 {
+			switch args[1] {
+			case "pull":
+				return CommandKindPull
+			case "save":
+				return CommandKindExport
+			}
+		}
+	case "machine":
+		if len(args) > 1 && args[1] == "logs" {
+			return CommandKindLogs
+		}
+	}
+	return CommandKindDefault
+}
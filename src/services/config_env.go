@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"container-tui/src/models"
+)
+
+// EnvPrefix starts the environment variables that override config values. The rest of
+// the name is the config key in upper case with dots as underscores, such as
+// ACTUI_READ_ONLY or ACTUI_POLICY_DENY. Map entries append the entry name, with dashes
+// as underscores: ACTUI_COMMAND_TIMEOUTS_BUILD, ACTUI_CONFIRMATIONS_DELETE_CONTAINER.
+// Presets and profiles append the entry name and then the setting, as in
+// ACTUI_PROFILES_MINI_HOST or ACTUI_BUILD_PRESETS_API_PULL_LATEST. Because underscores
+// in an entry name read as dashes, an entry whose name has an underscore cannot be
+// overridden.
+const EnvPrefix = "ACTUI_"
+
+// applyEnvOverrides sets every config key that has an ACTUI_* variable in environ.
+func applyEnvOverrides(v *viper.Viper, environ []string) error {
+	variables := map[string]string{}
+	for _, variable := range environ {
+		name, value, ok := strings.Cut(variable, "=")
+		if ok && strings.HasPrefix(name, EnvPrefix) {
+			variables[name] = value
+		}
+	}
+	if len(variables) == 0 {
+		return nil
+	}
+
+	var problems []string
+	set := func(name string, key string, raw string, fieldType reflect.Type) {
+		value, err := parseEnvValue(raw, fieldType)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			return
+		}
+		v.Set(key, value)
+	}
+	walkConfigKeys(reflect.TypeOf(models.UserConfig{}), "", func(key string, fieldType reflect.Type) {
+		name := EnvVarName(key)
+		if fieldType.Kind() != reflect.Map {
+			if raw, ok := variables[name]; ok {
+				set(name, key, raw, fieldType)
+			}
+			return
+		}
+		for variable, raw := range variables {
+			entry, ok := strings.CutPrefix(variable, name+"_")
+			if !ok || entry == "" {
+				continue
+			}
+			if fieldType.Elem().Kind() != reflect.Struct {
+				set(variable, key+"."+envEntryName(entry), raw, fieldType.Elem())
+				continue
+			}
+			entry, setting, settingType, ok := cutEnvEntrySetting(entry, fieldType.Elem())
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: names no setting of %s", variable, key))
+				continue
+			}
+			set(variable, key+"."+envEntryName(entry)+"."+setting, raw, settingType)
+		}
+	})
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid environment override: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// envEntryName turns the entry part of a variable name back into a map key.
+func envEntryName(entry string) string {
+	return strings.ReplaceAll(strings.ToLower(entry), "_", "-")
+}
+
+// cutEnvEntrySetting splits the entry part of a variable into the entry name and the
+// setting of entryType it ends with, preferring the longest setting that matches.
+func cutEnvEntrySetting(entry string, entryType reflect.Type) (string, string, reflect.Type, bool) {
+	var name, setting string
+	var settingType reflect.Type
+	walkConfigKeys(entryType, "", func(key string, keyType reflect.Type) {
+		candidate, ok := strings.CutSuffix(entry, "_"+strings.ToUpper(key))
+		if ok && candidate != "" && len(key) > len(setting) {
+			name, setting, settingType = candidate, key, keyType
+		}
+	})
+	return name, setting, settingType, setting != ""
+}
+
+// EnvVarName returns the environment variable that overrides key.
+func EnvVarName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// walkConfigKeys calls fn for every leaf key of a config struct, descending into tables.
+func walkConfigKeys(structType reflect.Type, prefix string, fn func(key string, fieldType reflect.Type)) {
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		key := prefix + field.Tag.Get("mapstructure")
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			walkConfigKeys(field.Type, key+".", fn)
+			continue
+		}
+		fn(key, field.Type)
+	}
+}
+
+// parseEnvValue converts raw to the type of a config field. Lists are comma separated, or
+// a JSON array when an item needs a comma.
+func parseEnvValue(raw string, fieldType reflect.Type) (any, error) {
+	if fieldType == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", raw)
+		}
+		return duration, nil
+	}
+	switch fieldType.Kind() {
+	case reflect.Pointer:
+		return parseEnvValue(raw, fieldType.Elem())
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", raw)
+		}
+		return value, nil
+	case reflect.Int, reflect.Int64:
+		value, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", raw)
+		}
+		return value, nil
+	case reflect.Slice:
+		if fieldType.Elem().Kind() != reflect.String {
+			break
+		}
+		items := []string{}
+		if trimmed := strings.TrimSpace(raw); strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+				return nil, fmt.Errorf("invalid JSON list %q", raw)
+			}
+			return items, nil
+		}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", fieldType)
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"container-tui/src/models"
+)
+
+// configKey is a resolved config key: its path through the config tables and the type
+// of the value it names.
+type configKey struct {
+	path []string
+	leaf reflect.Type
+}
+
+// isTable reports whether the key names a whole table rather than a single value.
+func (k configKey) isTable() bool {
+	kind := k.leaf.Kind()
+	return kind == reflect.Map || kind == reflect.Struct
+}
+
+// resolveConfigKey parses a dotted key such as "theme_mode", "policy.deny",
+// "command_timeouts.build" or "build_presets.api.tag".
+func resolveConfigKey(key string) (configKey, error) {
+	resolved := configKey{leaf: reflect.TypeOf(models.UserConfig{})}
+	for _, part := range strings.Split(strings.TrimSpace(key), ".") {
+		switch resolved.leaf.Kind() {
+		case reflect.Struct:
+			field, ok := configField(resolved.leaf, part)
+			if !ok {
+				return configKey{}, fmt.Errorf("unknown config key %q", key)
+			}
+			resolved.leaf = field.Type
+		case reflect.Map:
+			if part == "" {
+				return configKey{}, fmt.Errorf("unknown config key %q", key)
+			}
+			resolved.leaf = resolved.leaf.Elem()
+		default:
+			return configKey{}, fmt.Errorf("unknown config key %q", key)
+		}
+		resolved.path = append(resolved.path, part)
+	}
+	return resolved, nil
+}
+
+func configField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	for index := 0; index < structType.NumField(); index++ {
+		if field := structType.Field(index); field.Tag.Get("toml") == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Path returns the config file Load reads, or WritePath when none exists yet.
+func (m *ConfigManager) Path() string {
+	for _, path := range m.ReadPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return m.WritePath
+}
+
+// ConfigValue returns the value of key in config as it would be written to TOML:
+// durations as strings and tables as maps. An empty key returns the whole config.
+func ConfigValue(config models.UserConfig, key string) (any, error) {
+	if strings.TrimSpace(key) == "" {
+		table := map[string]any{}
+		mergeConfigTable(table, reflect.ValueOf(config))
+		return table, nil
+	}
+	resolved, err := resolveConfigKey(key)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.ValueOf(config)
+	for _, part := range resolved.path {
+		if value.Kind() == reflect.Map {
+			value = value.MapIndex(reflect.ValueOf(part))
+			if !value.IsValid() {
+				return nil, fmt.Errorf("%s is not set", key)
+			}
+			continue
+		}
+		field, _ := configField(value.Type(), part)
+		value = value.FieldByIndex(field.Index)
+	}
+	if value.Kind() == reflect.Struct {
+		table := map[string]any{}
+		mergeConfigTable(table, value)
+		return table, nil
+	}
+	if converted, ok := tomlValue(value); ok {
+		return converted, nil
+	}
+	// Empty maps and lists, and unset optional values.
+	switch value.Kind() {
+	case reflect.Map:
+		return map[string]any{}, nil
+	case reflect.Pointer:
+		return nil, fmt.Errorf("%s is not set", key)
+	}
+	return []string{}, nil
+}
+
+// Set parses values for key and stores them in the config file at WritePath. Lists take
+// one value per item, or a single comma separated value; other keys take one value. The
+// file is only written when the new value is valid.
+func (m *ConfigManager) Set(key string, values []string) error {
+	resolved, err := resolveConfigKey(key)
+	if err != nil {
+		return err
+	}
+	if resolved.isTable() {
+		return fmt.Errorf("%s is a table; set one of its keys, such as %s.<name>", key, key)
+	}
+	leafType := resolved.leaf
+	if len(values) == 0 || (len(values) > 1 && leafType.Kind() != reflect.Slice) {
+		return fmt.Errorf("%s takes exactly one value", key)
+	}
+	var value any
+	if len(values) > 1 {
+		value = values
+	} else if value, err = parseEnvValue(values[0], leafType); err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	switch typed := value.(type) {
+	case time.Duration:
+		value = typed.String()
+	case int:
+		value = int64(typed)
+	}
+
+	return m.update(key, func(table map[string]any) bool {
+		parent := table
+		for _, part := range resolved.path[:len(resolved.path)-1] {
+			next, ok := parent[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				parent[part] = next
+			}
+			parent = next
+		}
+		parent[resolved.path[len(resolved.path)-1]] = value
+		return true
+	})
+}
+
+// Unset removes key from the config file at WritePath so its default applies again. It
+// reports whether the key was set.
+func (m *ConfigManager) Unset(key string) (bool, error) {
+	resolved, err := resolveConfigKey(key)
+	if err != nil {
+		return false, err
+	}
+	removed := false
+	err = m.update(key, func(table map[string]any) bool {
+		parent := table
+		for _, part := range resolved.path[:len(resolved.path)-1] {
+			next, ok := parent[part].(map[string]any)
+			if !ok {
+				return false
+			}
+			parent = next
+		}
+		last := resolved.path[len(resolved.path)-1]
+		_, removed = parent[last]
+		delete(parent, last)
+		return removed
+	})
+	return removed, err
+}
+
+// update applies change to the decoded config file and writes it back, unless change
+// reports no change or the result has a problem with key.
+func (m *ConfigManager) update(key string, change func(table map[string]any) bool) error {
+	table := map[string]any{}
+	data, err := os.ReadFile(m.WritePath)
+	if err == nil {
+		if err := toml.Unmarshal(data, &table); err != nil {
+			return fmt.Errorf("cannot update %s: %w", m.WritePath, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if !change(table) {
+		return nil
+	}
+	data, err = toml.Marshal(table)
+	if err != nil {
+		return err
+	}
+	for _, problem := range ValidateConfigData(m.WritePath, data) {
+		if problem.Key == key || strings.HasPrefix(problem.Key, key+".") {
+			return fmt.Errorf("%s: %s", problem.Key, problem.Message)
+		}
+	}
+	return writeFileAtomic(m.WritePath, data, 0o600)
+}
+
+// SaveRaw validates data and, when it has no problems, writes it to WritePath as is.
+func (m *ConfigManager) SaveRaw(data []byte) ([]ConfigProblem, error) {
+	if problems := ValidateConfigData(m.WritePath, data); len(problems) > 0 {
+		return problems, nil
+	}
+	return nil, writeFileAtomic(m.WritePath, data, 0o600)
+}
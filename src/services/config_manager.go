@@ -1,61 +1,187 @@
 package services
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"time"
 
 	"container-tui/src/models"
 
 	"github.com/spf13/viper"
 )
 
-// ConfigManager loads configuration from supported paths.
+// ConfigManager loads configuration from supported paths. When ProjectPath is set, that
+// project config is merged over the user config.
 type ConfigManager struct {
-	ReadPaths []string
-	WritePath string
+	ReadPaths   []string
+	WritePath   string
+	ProjectPath string
 }
 
-// NewConfigManager builds a manager with macOS default paths.
+// NewConfigManager builds a manager that searches $XDG_CONFIG_HOME/actui/config,
+// ~/.config/actui/config and ~/Library/Application Support/actui/config in that order.
+// Writes go to the first file that exists, or to the XDG location when XDG_CONFIG_HOME is
+// set and the Application Support location otherwise.
 func NewConfigManager() (*ConfigManager, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
 	}
+	supportDir, err := macSupportDir()
+	if err != nil {
+		return nil, err
+	}
 
-	readPaths := []string{
-		filepath.Join(home, ".config", "actui", "config"),
-		filepath.Join(home, "Library", "Application Support", "actui", "config"),
+	readPaths := []string{filepath.Join(configDir, "config")}
+	for _, path := range []string{filepath.Join(home, ".config", appName, "config"), filepath.Join(supportDir, "config")} {
+		if path != readPaths[0] {
+			readPaths = append(readPaths, path)
+		}
+	}
+	writePath := filepath.Join(supportDir, "config")
+	if xdgDir("XDG_CONFIG_HOME") != "" {
+		writePath = readPaths[0]
+	}
+	for _, path := range readPaths {
+		if _, err := os.Stat(path); err == nil {
+			writePath = path
+			break
+		}
 	}
-	writePath := filepath.Join(home, "Library", "Application Support", "actui", "config")
 
 	return &ConfigManager{ReadPaths: readPaths, WritePath: writePath}, nil
 }
 
-// Load reads the first available config file or returns defaults.
+// NewConfigManagerAt builds a manager that reads and writes only the file at path.
+func NewConfigManagerAt(path string) *ConfigManager {
+	return &ConfigManager{ReadPaths: []string{path}, WritePath: path}
+}
+
+// Load reads the first available config file, merges the project config over it, applies
+// ACTUI_* environment overrides and returns the result along with the user config file
+// that was read, if any.
 func (m *ConfigManager) Load() (models.UserConfig, string, error) {
 	config := models.DefaultUserConfig()
+	v := viper.New()
+	v.SetConfigType("toml")
+	v.SetDefault("default_build_file", config.DefaultBuildFile)
+	v.SetDefault("pull_latest", config.PullLatest)
+	v.SetDefault("confirm_destructive_actions", config.ConfirmDestructiveActions)
+	v.SetDefault("theme_mode", config.ThemeMode)
+	v.SetDefault("refresh_on_focus", config.RefreshOnFocus)
+	v.SetDefault("log_retention_days", config.LogRetentionDays)
+	v.SetDefault("log_segment_size_mb", config.LogSegmentSizeMB)
+	v.SetDefault("log_max_size_mb", config.LogMaxSizeMB)
+	v.SetDefault("log_compress", config.LogCompress)
+	v.SetDefault("audit", config.Audit)
+	v.SetDefault("read_only", config.ReadOnly)
+	for kind, timeout := range config.CommandTimeouts {
+		v.SetDefault("command_timeouts."+kind, timeout.String())
+	}
+	v.SetDefault("retry.attempts", config.Retry.Attempts)
+	v.SetDefault("retry.backoff", config.Retry.Backoff.String())
+	v.SetDefault("retry.max_backoff", config.Retry.MaxBackoff.String())
+	v.SetDefault("retry.kinds", config.Retry.Kinds)
+	v.SetDefault("retry.warmup", config.Retry.Warmup.String())
+
+	used := ""
 	for _, path := range m.ReadPaths {
-		if _, err := os.Stat(path); err != nil {
-			continue
+		if _, err := os.Stat(path); err == nil {
+			used = path
+			break
 		}
-
-		v := viper.New()
-		v.SetConfigFile(path)
-		v.SetConfigType("toml")
-		v.SetDefault("default_build_file", config.DefaultBuildFile)
-		v.SetDefault("confirm_destructive_actions", config.ConfirmDestructiveActions)
-		v.SetDefault("theme_mode", config.ThemeMode)
-		v.SetDefault("refresh_on_focus", config.RefreshOnFocus)
-		v.SetDefault("log_retention_days", config.LogRetentionDays)
-
+	}
+	if used != "" {
+		v.SetConfigFile(used)
 		if err := v.ReadInConfig(); err != nil {
-			return config, path, err
+			return config, used, err
 		}
-		if err := v.Unmarshal(&config); err != nil {
-			return config, path, err
+	}
+	if m.ProjectPath != "" {
+		project, err := readProjectConfig(m.ProjectPath)
+		if err != nil {
+			return config, used, err
+		}
+		if err := v.MergeConfigMap(project); err != nil {
+			return config, used, err
 		}
-		return config, path, nil
 	}
+	if err := applyEnvOverrides(v, os.Environ()); err != nil {
+		return config, used, err
+	}
+	if err := v.Unmarshal(&config); err != nil {
+		return config, used, err
+	}
+	return config, used, nil
+}
 
-	return config, "", nil
+// mergeConfigTable writes the fields of a config struct into table. Nested tables are
+// merged key by key so unknown keys inside them are kept too.
+func mergeConfigTable(table map[string]any, value reflect.Value) {
+	for index := 0; index < value.NumField(); index++ {
+		key := value.Type().Field(index).Tag.Get("toml")
+		field := value.Field(index)
+		if field.Kind() == reflect.Struct {
+			nested, _ := table[key].(map[string]any)
+			if nested == nil {
+				nested = map[string]any{}
+			}
+			mergeConfigTable(nested, field)
+			if len(nested) > 0 {
+				table[key] = nested
+			} else {
+				delete(table, key)
+			}
+			continue
+		}
+		delete(table, key)
+		if entry, ok := tomlValue(field); ok {
+			table[key] = entry
+		}
+	}
+}
+
+// tomlValue converts a config field to the value written to the file. Durations are
+// written as strings such as "30m"; empty maps and lists and unset optional values are
+// omitted.
+func tomlValue(value reflect.Value) (any, bool) {
+	if value.Type() == reflect.TypeOf(time.Duration(0)) {
+		return time.Duration(value.Int()).String(), true
+	}
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, false
+		}
+		return tomlValue(value.Elem())
+	case reflect.Struct:
+		table := map[string]any{}
+		mergeConfigTable(table, value)
+		return table, true
+	case reflect.Map:
+		if value.Len() == 0 {
+			return nil, false
+		}
+		table := make(map[string]any, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			if entry, ok := tomlValue(iterator.Value()); ok {
+				table[fmt.Sprint(iterator.Key().Interface())] = entry
+			}
+		}
+		return table, true
+	case reflect.Slice:
+		if value.Len() == 0 {
+			return nil, false
+		}
+		return value.Interface(), true
+	default:
+		return value.Interface(), true
+	}
 }
@@ -0,0 +1,383 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/spf13/viper"
+
+	"container-tui/src/models"
+)
+
+// ConfigProblem is one problem found in a config file. Line and Column are zero when the
+// problem has no position, such as a bad environment override.
+type ConfigProblem struct {
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int    `json:"column,omitempty" yaml:"column,omitempty"`
+	Key     string `json:"key,omitempty" yaml:"key,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// String formats the problem as path:line:column: key: message.
+func (p ConfigProblem) String() string {
+	parts := make([]string, 0, 3)
+	if p.Path != "" {
+		location := p.Path
+		if p.Line > 0 {
+			location += fmt.Sprintf(":%d:%d", p.Line, p.Column)
+		}
+		parts = append(parts, location)
+	}
+	if p.Key != "" {
+		parts = append(parts, p.Key)
+	}
+	return strings.Join(append(parts, p.Message), ": ")
+}
+
+// configRules check values that have the right type but are out of range.
+var configRules = map[string]func(value any) string{
+	"default_build_file": func(value any) string {
+		if strings.TrimSpace(value.(string)) == "" {
+			return "must not be empty"
+		}
+		return ""
+	},
+	"theme_mode": func(value any) string {
+		switch value.(string) {
+		case "auto", "light", "dark":
+			return ""
+		}
+		return fmt.Sprintf("unknown theme %q (use auto, light or dark)", value)
+	},
+	"default_tag_pattern": func(value any) string {
+		return checkTagPattern(value.(string))
+	},
+	"log_retention_days":  nonNegative,
+	"log_segment_size_mb": nonNegative,
+	"log_max_size_mb":     nonNegative,
+	"retry.attempts": func(value any) string {
+		if value.(int) < 1 {
+			return "must be at least 1"
+		}
+		return ""
+	},
+	"retry.backoff":     nonNegativeDuration,
+	"retry.max_backoff": nonNegativeDuration,
+	"retry.warmup":      nonNegativeDuration,
+	"retry.kinds": func(value any) string {
+		for _, kind := range value.([]string) {
+			if !slices.Contains(RetryableKinds(), ErrorCategory(kind)) {
+				return fmt.Sprintf("unknown retry kind %q (use network, timeout, daemon or unknown)", kind)
+			}
+		}
+		return ""
+	},
+	"redact_patterns": func(value any) string {
+		for _, pattern := range value.([]string) {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Sprintf("invalid pattern %q: %v", pattern, err)
+			}
+		}
+		return ""
+	},
+}
+
+// configEntryRules check the entries of map tables.
+var configEntryRules = map[string]func(entry string, value any) string{
+	"command_timeouts": func(entry string, value any) string {
+		for _, kind := range CommandKinds() {
+			if string(kind) == entry {
+				if value.(time.Duration) < 0 {
+					return "must not be negative"
+				}
+				return ""
+			}
+		}
+		return fmt.Sprintf("unknown command kind %q", entry)
+	},
+	"confirmations": func(entry string, value any) string {
+		if _, ok := DestructiveActionMetadata()[DestructiveAction(entry)]; !ok {
+			return fmt.Sprintf("unknown action %q", entry)
+		}
+		if _, err := ParseConfirmationMode(value.(string)); err != nil {
+			return err.Error()
+		}
+		return ""
+	},
+}
+
+func nonNegative(value any) string {
+	if value.(int) < 0 {
+		return "must not be negative"
+	}
+	return ""
+}
+
+func nonNegativeDuration(value any) string {
+	if value.(time.Duration) < 0 {
+		return "must not be negative"
+	}
+	return ""
+}
+
+// ValidateConfigFile checks the config file at path for syntax errors, unknown keys,
+// wrong types and invalid values. A file named ProjectConfigName is checked as a project
+// config.
+func ValidateConfigFile(path string) ([]ConfigProblem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Base(path) == ProjectConfigName {
+		return ValidateProjectConfigData(path, data), nil
+	}
+	return ValidateConfigData(path, data), nil
+}
+
+// ValidateConfigData checks config file contents; path only labels the problems.
+func ValidateConfigData(path string, data []byte) []ConfigProblem {
+	return validateConfigData(path, data, false)
+}
+
+// ValidateProjectConfigData checks project config contents, which may only set the build
+// defaults and presets.
+func ValidateProjectConfigData(path string, data []byte) []ConfigProblem {
+	return validateConfigData(path, data, true)
+}
+
+func validateConfigData(path string, data []byte, project bool) []ConfigProblem {
+	values := map[string]any{}
+	if err := toml.Unmarshal(data, &values); err != nil {
+		problem := ConfigProblem{Path: path, Message: err.Error()}
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			problem.Line, problem.Column = decodeErr.Position()
+		}
+		return []ConfigProblem{problem}
+	}
+
+	positions := configKeyPositions(data)
+	problems := make([]ConfigProblem, 0)
+	report := func(key string, message string) {
+		position := positions[key]
+		problems = append(problems, ConfigProblem{Path: path, Line: position.Line, Column: position.Column, Key: key, Message: message})
+	}
+	if project {
+		for key := range values {
+			if !projectConfigKeys[key] {
+				report(key, "cannot be set in a project config")
+				delete(values, key)
+			}
+		}
+	}
+	validateConfigTable(values, reflect.TypeOf(models.UserConfig{}), "", report)
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Line < problems[j].Line
+	})
+	return problems
+}
+
+// CheckEnvOverrides reports invalid ACTUI_* environment variables.
+func CheckEnvOverrides() error {
+	return applyEnvOverrides(viper.New(), os.Environ())
+}
+
+func validateConfigTable(values map[string]any, structType reflect.Type, prefix string, report func(key string, message string)) {
+	fields := map[string]reflect.StructField{}
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		fields[field.Tag.Get("toml")] = field
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		full := prefix + key
+		field, ok := fields[key]
+		if !ok {
+			report(full, "unknown key")
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			table, ok := values[key].(map[string]any)
+			if !ok {
+				report(full, "expected a table, got "+tomlTypeName(values[key]))
+				continue
+			}
+			validateConfigTable(table, field.Type, full+".", report)
+		case reflect.Map:
+			table, ok := values[key].(map[string]any)
+			if !ok {
+				report(full, "expected a table, got "+tomlTypeName(values[key]))
+				continue
+			}
+			entries := make([]string, 0, len(table))
+			for entry := range table {
+				entries = append(entries, entry)
+			}
+			sort.Strings(entries)
+			for _, entry := range entries {
+				if field.Type.Elem().Kind() == reflect.Struct {
+					nested, ok := table[entry].(map[string]any)
+					if !ok {
+						report(full+"."+entry, "expected a table, got "+tomlTypeName(table[entry]))
+						continue
+					}
+					validateConfigTable(nested, field.Type.Elem(), full+"."+entry+".", report)
+					continue
+				}
+				value, message := configValue(table[entry], field.Type.Elem())
+				if message == "" && configEntryRules[full] != nil {
+					message = configEntryRules[full](entry, value)
+				}
+				if message != "" {
+					report(full+"."+entry, message)
+				}
+			}
+		default:
+			value, message := configValue(values[key], field.Type)
+			if message == "" && configRules[full] != nil {
+				message = configRules[full](value)
+			}
+			if message != "" {
+				report(full, message)
+			}
+		}
+	}
+}
+
+// configValue converts a decoded TOML value to the Go type of a config field, or returns
+// a message explaining why it cannot.
+func configValue(value any, fieldType reflect.Type) (any, string) {
+	if fieldType == reflect.TypeOf(time.Duration(0)) {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Sprintf("expected a duration such as \"30m\", got %s", tomlTypeName(value))
+		}
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return nil, fmt.Sprintf("invalid duration %q", text)
+		}
+		return duration, ""
+	}
+	switch fieldType.Kind() {
+	case reflect.Pointer:
+		return configValue(value, fieldType.Elem())
+	case reflect.String:
+		if text, ok := value.(string); ok {
+			return text, ""
+		}
+		return nil, "expected a string, got " + tomlTypeName(value)
+	case reflect.Bool:
+		if flag, ok := value.(bool); ok {
+			return flag, ""
+		}
+		return nil, "expected true or false, got " + tomlTypeName(value)
+	case reflect.Int, reflect.Int64:
+		if number, ok := value.(int64); ok {
+			return int(number), ""
+		}
+		return nil, "expected an integer, got " + tomlTypeName(value)
+	case reflect.Slice:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, "expected an array of strings, got " + tomlTypeName(value)
+		}
+		texts := make([]string, 0, len(items))
+		for _, item := range items {
+			text, ok := item.(string)
+			if !ok {
+				return nil, "expected an array of strings, found " + tomlTypeName(item)
+			}
+			texts = append(texts, text)
+		}
+		return texts, ""
+	}
+	return nil, "unsupported type " + fieldType.String()
+}
+
+func tomlTypeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case int64:
+		return "an integer"
+	case float64:
+		return "a float"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "a table"
+	default:
+		return "a date or time"
+	}
+}
+
+// configKeyPositions maps each dotted key and table header in data to where it appears.
+func configKeyPositions(data []byte) map[string]unstable.Position {
+	positions := map[string]unstable.Position{}
+	parser := unstable.Parser{}
+	parser.Reset(data)
+	table := ""
+	for parser.NextExpression() {
+		expression := parser.Expression()
+		switch expression.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			key, position := nodeKey(&parser, expression)
+			table = key
+			positions[key] = position
+		case unstable.KeyValue:
+			recordKeyValue(&parser, expression, table, positions)
+		}
+	}
+	return positions
+}
+
+func recordKeyValue(parser *unstable.Parser, expression *unstable.Node, table string, positions map[string]unstable.Position) {
+	key, position := nodeKey(parser, expression)
+	if table != "" {
+		key = table + "." + key
+	}
+	if _, seen := positions[key]; !seen {
+		positions[key] = position
+	}
+	if value := expression.Value(); value.Kind == unstable.InlineTable {
+		children := value.Children()
+		for children.Next() {
+			recordKeyValue(parser, children.Node(), key, positions)
+		}
+	}
+}
+
+// nodeKey returns the dotted key of a table or key-value node and the position of its
+// first part.
+func nodeKey(parser *unstable.Parser, node *unstable.Node) (string, unstable.Position) {
+	parts := []string{}
+	var position unstable.Position
+	iterator := node.Key()
+	for iterator.Next() {
+		part := iterator.Node()
+		if len(parts) == 0 {
+			position = parser.Shape(part.Raw).Start
+		}
+		parts = append(parts, string(part.Data))
+	}
+	return strings.Join(parts, "."), position
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"container-tui/src/models"
+)
+
+// configWatchDelay coalesces the burst of events an editor produces when it saves.
+const configWatchDelay = 150 * time.Millisecond
+
+// ConfigChange is the result of reloading the config files. When a file has Problems or
+// cannot be read (Err), Effective is the zero value and the previous settings should be
+// kept.
+type ConfigChange struct {
+	Path      string
+	Effective EffectiveConfig
+	Problems  []ConfigProblem
+	Err       error
+}
+
+// OK reports whether Effective can be applied.
+func (c ConfigChange) OK() bool {
+	return c.Err == nil && len(c.Problems) == 0
+}
+
+func (c ConfigChange) equal(other ConfigChange) bool {
+	sameErr := (c.Err == nil) == (other.Err == nil) && (c.Err == nil || c.Err.Error() == other.Err.Error())
+	return sameErr && c.Path == other.Path && reflect.DeepEqual(c.Effective, other.Effective) && reflect.DeepEqual(c.Problems, other.Problems)
+}
+
+// Reload validates the config file Load would read and the project config, and loads them
+// when neither has problems. A missing file is not a problem; the defaults apply.
+func (m *ConfigManager) Reload() ConfigChange {
+	path := m.Path()
+	problems := []ConfigProblem{}
+	for _, file := range []string{path, m.ProjectPath} {
+		if file == "" {
+			continue
+		}
+		found, err := ValidateConfigFile(file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return ConfigChange{Path: file, Err: err}
+		}
+		if len(found) > 0 && len(problems) == 0 {
+			path = file
+		}
+		problems = append(problems, found...)
+	}
+	if len(problems) > 0 {
+		return ConfigChange{Path: path, Problems: problems}
+	}
+	effective, err := m.Effective()
+	if err != nil {
+		return ConfigChange{Path: path, Err: err}
+	}
+	return ConfigChange{Path: path, Effective: effective}
+}
+
+// Watch sends a ConfigChange whenever the effective config, or the problems with it, change.
+// It watches the directories of every read path and of the project config, so editors that
+// save by renaming a new file into place and a file created at a higher-precedence location
+// are both noticed. The channel is closed once ctx is done.
+func (m *ConfigManager) Watch(ctx context.Context) (<-chan ConfigChange, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	watched := map[string]bool{}
+	paths := m.ReadPaths
+	if m.ProjectPath != "" {
+		paths = append(append([]string{}, paths...), m.ProjectPath)
+	}
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		watched[path] = true
+		if err := watcher.Add(filepath.Dir(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			_ = watcher.Close()
+			return nil, err
+		}
+	}
+	if len(watcher.WatchList()) == 0 {
+		_ = watcher.Close()
+		return nil, errors.New("no config directory exists to watch")
+	}
+
+	last := m.Reload()
+	changes := make(chan ConfigChange)
+	go func() {
+		defer close(changes)
+		defer func() {
+			_ = watcher.Close()
+		}()
+		var due <-chan time.Time
+		for {
+			var change ConfigChange
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op != fsnotify.Chmod && watched[filepath.Clean(event.Name)] {
+					due = time.After(configWatchDelay)
+				}
+				continue
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				change = ConfigChange{Path: m.Path(), Err: err}
+			case <-due:
+				due = nil
+				change = m.Reload()
+				if change.equal(last) {
+					continue
+				}
+				last = change
+			}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// RestartRequiredKeys lists the keys that differ between previous and next but only take
+// effect when actui starts, because they decide which executors are in the chain or which
+// profiles can be switched to.
+func RestartRequiredKeys(previous, next models.UserConfig) []string {
+	keys := []string{}
+	if previous.ReadOnly != next.ReadOnly {
+		keys = append(keys, "read_only")
+	}
+	if !slices.Equal(previous.Policy.Allow, next.Policy.Allow) || !slices.Equal(previous.Policy.Deny, next.Policy.Deny) {
+		keys = append(keys, "policy")
+	}
+	if previous.Audit != next.Audit {
+		keys = append(keys, "audit")
+	}
+	if previous.Profile != next.Profile || !reflect.DeepEqual(previous.Profiles, next.Profiles) {
+		keys = append(keys, "profiles")
+	}
+	return keys
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConfirmationMode chooses how a guarded action is confirmed.
+type ConfirmationMode string
+
+const (
+	// ConfirmNone runs the action without asking.
+	ConfirmNone ConfirmationMode = "none"
+	// ConfirmYesNo asks for a y/n answer.
+	ConfirmYesNo ConfirmationMode = "yes-no"
+	// ConfirmTypeToConfirm asks the user to type the target name.
+	ConfirmTypeToConfirm ConfirmationMode = "type"
+)
+
+// ConfirmationPolicy decides how each guarded action is confirmed. Per-action overrides win;
+// otherwise actions follow their risk level, or skip confirmation when Enabled is false.
+type ConfirmationPolicy struct {
+	Enabled   bool
+	Overrides map[DestructiveAction]ConfirmationMode
+}
+
+// DefaultConfirmationPolicy confirms every guarded action according to its risk.
+func DefaultConfirmationPolicy() ConfirmationPolicy {
+	return ConfirmationPolicy{Enabled: true}
+}
+
+// NewConfirmationPolicy builds a policy from the `confirm_destructive_actions` switch and the
+// `[confirmations]` table. Unknown actions or modes are reported and skipped.
+func NewConfirmationPolicy(enabled bool, overrides map[string]string) (ConfirmationPolicy, error) {
+	policy := ConfirmationPolicy{Enabled: enabled, Overrides: map[DestructiveAction]ConfirmationMode{}}
+	metadata := DestructiveActionMetadata()
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		action := DestructiveAction(strings.ToLower(strings.TrimSpace(key)))
+		if _, ok := metadata[action]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown action %q", key))
+			continue
+		}
+		mode, err := ParseConfirmationMode(overrides[key])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		policy.Overrides[action] = mode
+	}
+	if len(problems) > 0 {
+		return policy, errors.New("invalid confirmations: " + strings.Join(problems, "; "))
+	}
+	return policy, nil
+}
+
+// ParseConfirmationMode parses none, yes-no or type.
+func ParseConfirmationMode(value string) (ConfirmationMode, error) {
+	switch ConfirmationMode(strings.ToLower(strings.TrimSpace(value))) {
+	case ConfirmNone:
+		return ConfirmNone, nil
+	case ConfirmYesNo, "y/n", "yesno":
+		return ConfirmYesNo, nil
+	case ConfirmTypeToConfirm, "type-to-confirm":
+		return ConfirmTypeToConfirm, nil
+	default:
+		return "", fmt.Errorf("unknown confirmation mode %q (use none, yes-no or type)", value)
+	}
+}
+
+// ModeFor returns the confirmation mode for action.
+func (p ConfirmationPolicy) ModeFor(action DestructiveAction) ConfirmationMode {
+	if mode, ok := p.Overrides[action]; ok {
+		return mode
+	}
+	if !p.Enabled {
+		return ConfirmNone
+	}
+	return DefaultConfirmationMode(DestructiveActionMetadata()[action].Risk)
+}
+
+// DefaultConfirmationMode maps a risk level to its default confirmation.
+func DefaultConfirmationMode(risk RiskLevel) ConfirmationMode {
+	if risk == RiskHigh {
+		return ConfirmTypeToConfirm
+	}
+	return ConfirmYesNo
+}
@@ -24,3 +24,8 @@ func (b DeleteContainerBuilder) Build() (models.Command, error) {
 	containerID, _ := normalizeRequiredToken(b.ContainerID, "container id")
 	return models.Command{Executable: "container", Args: []string{"delete", containerID}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (DeleteContainerBuilder) GuardedAction() DestructiveAction {
+	return ActionDeleteContainer
+}
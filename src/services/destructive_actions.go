@@ -1,19 +1,157 @@
 package services
 
+import (
+	"sort"
+	"strings"
+
+	"container-tui/src/models"
+)
+
 // DestructiveAction identifies an action that needs extra confirmation.
 type DestructiveAction string
 
 const (
 	// ActionDeleteContainer represents a container delete operation.
 	ActionDeleteContainer DestructiveAction = "delete-container"
+	// ActionStopContainer represents a container stop operation.
+	ActionStopContainer DestructiveAction = "stop-container"
 	// ActionStopDaemon represents a daemon stop operation.
 	ActionStopDaemon DestructiveAction = "stop-daemon"
+	// ActionDeleteImage represents an image delete operation.
+	ActionDeleteImage DestructiveAction = "delete-image"
+	// ActionPruneImages represents an image prune operation.
+	ActionPruneImages DestructiveAction = "prune-images"
+	// ActionDeleteMachine represents a machine delete operation.
+	ActionDeleteMachine DestructiveAction = "delete-machine"
+	// ActionStopMachine represents a machine stop operation.
+	ActionStopMachine DestructiveAction = "stop-machine"
+	// ActionExportCleanup represents deleting the temporary image left by an export.
+	ActionExportCleanup DestructiveAction = "export-cleanup"
+)
+
+// RiskLevel grades how much an action can lose.
+type RiskLevel int
+
+const (
+	// RiskLow marks actions that are easy to undo.
+	RiskLow RiskLevel = iota
+	// RiskMedium marks actions that interrupt running workloads.
+	RiskMedium
+	// RiskHigh marks actions that permanently remove data.
+	RiskHigh
 )
 
+// String returns the lowercase risk name.
+func (r RiskLevel) String() string {
+	switch r {
+	case RiskHigh:
+		return "high"
+	case RiskMedium:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// GuardedBuilder is implemented by builders whose commands need confirmation before running.
+type GuardedBuilder interface {
+	GuardedAction() DestructiveAction
+}
+
+// CommandGuard is the guarded action a built command performs.
+type CommandGuard struct {
+	Action DestructiveAction
+	// Expected is the text a type-to-confirm prompt wants typed back: the command's
+	// targets, the last word of a command without one, or the verb and "all".
+	Expected string
+	// AllTargets is set when --all acts on every container, image or machine.
+	AllTargets bool
+}
+
+// GuardedAction returns the action, so a guard picks its confirmation like a builder.
+func (g CommandGuard) GuardedAction() DestructiveAction {
+	return g.Action
+}
+
+// Mode raises mode, the policy's confirmation for the action, to the high-risk
+// type-to-confirm when the command acts on all targets. A policy that turns the
+// confirmation off is kept.
+func (g CommandGuard) Mode(mode ConfirmationMode) ConfirmationMode {
+	if g.AllTargets && mode != ConfirmNone {
+		return DefaultConfirmationMode(RiskHigh)
+	}
+	return mode
+}
+
+// valueFlags are the options of guarded commands that take the next argument as a value.
+var valueFlags = map[string]bool{
+	"-s": true, "--signal": true, "-t": true, "--time": true,
+	"--format": true, "--platform": true, "--arch": true, "--os": true,
+}
+
+// CommandAction returns the guard of a built command. ok is false for unguarded commands.
+func CommandAction(cmd models.Command) (guard CommandGuard, ok bool) {
+	words, all := commandWords(cmd.Args)
+	verbs := 1
+	if len(words) > 1 && (words[0] == "image" || words[0] == "machine" || words[0] == "system") {
+		verbs = 2
+	}
+	if len(words) < verbs {
+		return CommandGuard{}, false
+	}
+	targets := words[verbs:]
+	switch strings.Join(words[:verbs], " ") {
+	case "delete", "rm":
+		guard.Action = ActionDeleteContainer
+	case "stop":
+		guard.Action = ActionStopContainer
+	case "system stop":
+		return CommandGuard{Action: ActionStopDaemon, Expected: "stop"}, len(targets) == 0
+	case "image delete", "image rm":
+		guard.Action = ActionDeleteImage
+	case "image prune":
+		guard = CommandGuard{Action: ActionPruneImages, Expected: "prune"}
+		targets = nil
+	case "machine delete", "machine rm":
+		guard.Action = ActionDeleteMachine
+	case "machine stop":
+		guard.Action = ActionStopMachine
+	default:
+		return CommandGuard{}, false
+	}
+	switch {
+	case all:
+		guard.AllTargets = true
+		guard.Expected = words[verbs-1] + " all"
+	case len(targets) > 0:
+		guard.Expected = strings.Join(targets, " ")
+	}
+	return guard, guard.Expected != ""
+}
+
+// commandWords returns the positional arguments of args, leaving out options and the
+// values of options that take one, and whether --all or -a is among them.
+func commandWords(args []string) (words []string, all bool) {
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		switch {
+		case arg == "--all" || arg == "-a":
+			all = true
+		case valueFlags[arg]:
+			index++
+		case strings.HasPrefix(arg, "-"):
+		default:
+			words = append(words, arg)
+		}
+	}
+	return words, all
+}
+
 // ActionMetadata describes a destructive action in the UI.
 type ActionMetadata struct {
 	Label       string
 	Description string
+	Risk        RiskLevel
 }
 
 // DestructiveActionMetadata returns metadata for destructive actions.
@@ -22,10 +160,53 @@ func DestructiveActionMetadata() map[DestructiveAction]ActionMetadata {
 		ActionDeleteContainer: {
 			Label:       "Delete Container",
 			Description: "Permanently deletes a stopped container",
+			Risk:        RiskHigh,
+		},
+		ActionStopContainer: {
+			Label:       "Stop Container",
+			Description: "Stops a running container",
+			Risk:        RiskMedium,
 		},
 		ActionStopDaemon: {
 			Label:       "Stop Daemon",
 			Description: "Stops container services and running containers",
+			Risk:        RiskMedium,
+		},
+		ActionDeleteImage: {
+			Label:       "Delete Image",
+			Description: "Removes a local image",
+			Risk:        RiskHigh,
+		},
+		ActionPruneImages: {
+			Label:       "Prune Images",
+			Description: "Removes every image not used by a container",
+			Risk:        RiskHigh,
+		},
+		ActionDeleteMachine: {
+			Label:       "Delete Machine",
+			Description: "Permanently deletes a container machine",
+			Risk:        RiskHigh,
+		},
+		ActionStopMachine: {
+			Label:       "Stop Machine",
+			Description: "Stops a running container machine",
+			Risk:        RiskMedium,
 		},
+		ActionExportCleanup: {
+			Label:       "Delete Export Image",
+			Description: "Deletes the temporary image created by a container export",
+			Risk:        RiskLow,
+		},
+	}
+}
+
+// DestructiveActions returns every guarded action in a stable order.
+func DestructiveActions() []DestructiveAction {
+	metadata := DestructiveActionMetadata()
+	actions := make([]DestructiveAction, 0, len(metadata))
+	for action := range metadata {
+		actions = append(actions, action)
 	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i] < actions[j] })
+	return actions
 }
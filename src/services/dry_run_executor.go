@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,8 +12,16 @@ import (
 type DryRunExecutor struct{}
 
 // Execute returns a successful dry-run result.
-func (DryRunExecutor) Execute(cmd models.Command) (models.Result, error) {
+func (e DryRunExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return e.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext returns a dry-run result unless ctx is already done.
+func (DryRunExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
 	start := time.Now()
+	if ctx.Err() != nil {
+		return interruptedResult(ctx, time.Since(start))
+	}
 	return models.Result{
 		ExitCode: 0,
 		Stdout:   fmt.Sprintf("dry-run: %s", cmd.String()),
@@ -20,3 +29,10 @@ func (DryRunExecutor) Execute(cmd models.Command) (models.Result, error) {
 		Status:   models.ResultSuccess,
 	}, nil
 }
+
+// Stream emits the dry-run line instead of running the command.
+func (e DryRunExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	result, err := e.ExecuteContext(ctx, cmd)
+	replayLines(result.Stdout, StreamStdout, onLine)
+	return result, err
+}
@@ -1,11 +1,92 @@
 package services
 
 import (
+	"errors"
 	"strings"
 )
 
+// ErrorCategory groups runtime failures by their likely cause.
+type ErrorCategory string
+
+const (
+	// ErrorUnknown marks failures that match no known cause.
+	ErrorUnknown ErrorCategory = "unknown"
+	// ErrorCanceled marks commands canceled by the user.
+	ErrorCanceled ErrorCategory = "canceled"
+	// ErrorTimeout marks commands that exceeded their timeout.
+	ErrorTimeout ErrorCategory = "timeout"
+	// ErrorPolicy marks commands blocked by the execution policy.
+	ErrorPolicy ErrorCategory = "policy"
+	// ErrorNotFound marks missing containers, images or files on a registry.
+	ErrorNotFound ErrorCategory = "not-found"
+	// ErrorAlreadyRunning marks start requests for running containers.
+	ErrorAlreadyRunning ErrorCategory = "already-running"
+	// ErrorAlreadyStopped marks stop requests for stopped containers.
+	ErrorAlreadyStopped ErrorCategory = "already-stopped"
+	// ErrorPermission marks missing privileges.
+	ErrorPermission ErrorCategory = "permission"
+	// ErrorDaemon marks an unreachable container daemon.
+	ErrorDaemon ErrorCategory = "daemon"
+	// ErrorAuth marks registry authentication failures.
+	ErrorAuth ErrorCategory = "auth"
+	// ErrorBuilder marks an unavailable image builder.
+	ErrorBuilder ErrorCategory = "builder"
+	// ErrorNetwork marks transient failures reaching a registry or remote host.
+	ErrorNetwork ErrorCategory = "network"
+	// ErrorInvalidReference marks malformed image references.
+	ErrorInvalidReference ErrorCategory = "invalid-reference"
+	// ErrorBuildPath marks a missing build file or context.
+	ErrorBuildPath ErrorCategory = "build-path"
+	// ErrorBuildContext marks a build context that is not a directory.
+	ErrorBuildContext ErrorCategory = "build-context"
+)
+
+// errorCategoryMessages holds the user-facing message for each recognized category.
+var errorCategoryMessages = map[ErrorCategory]string{
+	ErrorNotFound:         "resource not found; check the name or reference",
+	ErrorAlreadyRunning:   "container is already running",
+	ErrorAlreadyStopped:   "container is already stopped",
+	ErrorPermission:       "permission denied; check your privileges",
+	ErrorDaemon:           "container daemon is not running; start it from the Daemon screen",
+	ErrorAuth:             "authentication required; check registry credentials",
+	ErrorBuilder:          "builder is not running; start it and retry",
+	ErrorNetwork:          "network error; check the connection and retry",
+	ErrorInvalidReference: "invalid image reference",
+	ErrorBuildPath:        "build file or context path not found",
+	ErrorBuildContext:     "build context must be a directory",
+}
+
+// Remedy returns the action that fixes failures of the category, or RemedyNone.
+func (c ErrorCategory) Remedy() Remedy {
+	switch c {
+	case ErrorDaemon:
+		return RemedyStartDaemon
+	case ErrorAuth:
+		return RemedyRegistryLogin
+	case ErrorBuilder:
+		return RemedyStartBuilder
+	default:
+		return RemedyNone
+	}
+}
+
 // FormatError returns a user-friendly error message based on stderr and err.
 func FormatError(err error, stderr string) string {
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return runtimeErr.Error()
+	}
+	if IsCanceled(err) {
+		return "command canceled"
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return timeoutErr.Error() + "; raise command_timeouts in the config to allow longer runs"
+	}
+	var policyErr *PolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Error()
+	}
 	message := strings.TrimSpace(stderr)
 	if message == "" && err != nil {
 		message = err.Error()
@@ -13,34 +94,114 @@ func FormatError(err error, stderr string) string {
 	return formatErrorMessage(message)
 }
 
+// ClassifyError returns the category FormatError uses for err and stderr. A *RuntimeError
+// keeps the kind the executor gave it.
+func ClassifyError(err error, stderr string) ErrorCategory {
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return runtimeErr.Kind
+	}
+	if IsCanceled(err) {
+		return ErrorCanceled
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return ErrorTimeout
+	}
+	var policyErr *PolicyError
+	if errors.As(err, &policyErr) {
+		return ErrorPolicy
+	}
+	message := strings.TrimSpace(stderr)
+	if message == "" && err != nil {
+		message = err.Error()
+	}
+	return classifyErrorMessage(message)
+}
+
 func formatErrorMessage(message string) string {
 	if strings.TrimSpace(message) == "" {
 		return "unknown error"
 	}
+	if formatted, ok := errorCategoryMessages[classifyErrorMessage(message)]; ok {
+		return formatted
+	}
+	return message
+}
 
+// classifyErrorMessage matches stderr against known failures. Phrases only match as whole
+// words, so names and references the CLI echoes back, such as a web-builder container or an
+// acme/daemonset image, do not pick the category. Authentication is checked before the
+// daemon and builder, whose messages also say "not running", and the daemon before network
+// failures so a refused XPC connection is not reported as a network error.
+func classifyErrorMessage(message string) ErrorCategory {
 	lower := strings.ToLower(message)
 	switch {
-	case strings.Contains(lower, "not found") || strings.Contains(lower, "no such container") || strings.Contains(lower, "manifest unknown"):
-		return "resource not found; check the name or reference"
-	case strings.Contains(lower, "already running"):
-		return "container is already running"
-	case strings.Contains(lower, "already stopped") || strings.Contains(lower, "not running"):
-		return "container is already stopped"
-	case strings.Contains(lower, "permission") || strings.Contains(lower, "sudo"):
-		return "permission denied; check your privileges"
-	case strings.Contains(lower, "daemon") || strings.Contains(lower, "connection"):
-		return "container daemon is not running; start it from the Daemon screen"
-	case strings.Contains(lower, "unauthorized") || strings.Contains(lower, "authentication"):
-		return "authentication required; check registry credentials"
-	case strings.Contains(lower, "builder") || strings.Contains(lower, "buildkit"):
-		return "builder is not running; start it and retry"
-	case strings.Contains(lower, "invalid reference format"):
-		return "invalid image reference"
-	case strings.Contains(lower, "no such file") || strings.Contains(lower, "file not found"):
-		return "build file or context path not found"
-	case strings.Contains(lower, "not a directory") && strings.Contains(lower, "context"):
-		return "build context must be a directory"
+	case containsPhrase(lower, "unauthorized", "authentication required", "denied: requested access"):
+		return ErrorAuth
+	case containsPhrase(lower, "not found", "no such container", "manifest unknown"):
+		return ErrorNotFound
+	case containsPhrase(lower, "already running"):
+		return ErrorAlreadyRunning
+	case containsPhrase(lower, "daemon", "xpc connection", "apiserver", "container system start"):
+		return ErrorDaemon
+	case containsPhrase(lower, "builder", "buildkit"):
+		return ErrorBuilder
+	case containsPhrase(lower, "already stopped", "not running"):
+		return ErrorAlreadyStopped
+	case containsPhrase(lower, "authentication"):
+		return ErrorAuth
+	case containsPhrase(lower, "permission", "sudo"):
+		return ErrorPermission
+	case containsPhrase(lower, "connection refused", "connection reset", "i/o timeout", "tls handshake timeout", "no route to host", "network is unreachable", "no such host", "name resolution", "too many requests", "service unavailable"):
+		return ErrorNetwork
+	case containsPhrase(lower, "invalid reference format"):
+		return ErrorInvalidReference
+	case containsPhrase(lower, "no such file", "file not found"):
+		return ErrorBuildPath
+	case containsPhrase(lower, "not a directory") && containsPhrase(lower, "context"):
+		return ErrorBuildContext
 	default:
-		return message
+		return ErrorUnknown
+	}
+}
+
+// containsPhrase reports whether text contains one of phrases as whole words: not inside a
+// name such as web-builder, acme/daemonset or builder.local.
+func containsPhrase(text string, phrases ...string) bool {
+	for _, phrase := range phrases {
+		for offset := 0; offset < len(text); {
+			index := strings.Index(text[offset:], phrase)
+			if index < 0 {
+				break
+			}
+			start, end := offset+index, offset+index+len(phrase)
+			if !continuesName(text, start-1, -1) && !continuesName(text, end, 1) {
+				return true
+			}
+			offset = start + 1
+		}
+	}
+	return false
+}
+
+// continuesName reports whether the byte of text at index, next to a match in direction
+// step, makes the match part of a longer name.
+func continuesName(text string, index int, step int) bool {
+	if !isNameByte(text, index) {
+		return false
+	}
+	if text[index] == '.' {
+		// A dot only joins a name when one follows it, so a phrase may end a sentence.
+		return isNameByte(text, index+step) && text[index+step] != '.'
+	}
+	return true
+}
+
+func isNameByte(text string, index int) bool {
+	if index < 0 || index >= len(text) {
+		return false
 	}
+	char := text[index]
+	return char >= 'a' && char <= 'z' || char >= '0' && char <= '9' || char == '-' || char == '_' || char == '/' || char == '.'
 }
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// readOnlySubcommands lists the commands that never change runtime state.
+var readOnlySubcommands = []string{
+	"list",
+	"ls",
+	"logs",
+	"inspect",
+	"image list",
+	"image ls",
+	"image inspect",
+	"machine list",
+	"machine ls",
+	"machine inspect",
+	"machine logs",
+	"registry list",
+	"registry ls",
+	"system status",
+	"system version",
+}
+
+// ExecutionPolicy decides which commands may run. Rules are subcommand prefixes such as
+// "delete", "machine delete" or "image prune"; flags and their values are ignored when matching.
+//
+// Deny rules always win. Read-only subcommands are otherwise always allowed. In read-only mode
+// every other command is blocked, and a non-empty allow list blocks commands it does not match.
+type ExecutionPolicy struct {
+	ReadOnly bool
+	Allow    []string
+	Deny     []string
+}
+
+// PolicyError reports a command blocked by the execution policy.
+type PolicyError struct {
+	Command models.Command
+	Reason  string
+}
+
+func (e *PolicyError) Error() string {
+	return "blocked by policy: " + e.Reason
+}
+
+// IsZero reports whether the policy allows every command.
+func (p ExecutionPolicy) IsZero() bool {
+	return !p.ReadOnly && len(p.Allow) == 0 && len(p.Deny) == 0
+}
+
+// Check returns a *PolicyError when cmd may not run.
+func (p ExecutionPolicy) Check(cmd models.Command) error {
+	subcommand := policySubcommand(cmd)
+	if rule, ok := matchPolicyRule(p.Deny, subcommand); ok {
+		return &PolicyError{Command: cmd, Reason: fmt.Sprintf("%q is denied by the %q rule", subcommand, rule)}
+	}
+	if _, ok := matchPolicyRule(readOnlySubcommands, subcommand); ok {
+		return nil
+	}
+	if p.ReadOnly {
+		return &PolicyError{Command: cmd, Reason: fmt.Sprintf("%q changes runtime state and actui is in read-only mode", subcommand)}
+	}
+	if len(p.Allow) > 0 {
+		if _, ok := matchPolicyRule(p.Allow, subcommand); !ok {
+			return &PolicyError{Command: cmd, Reason: fmt.Sprintf("%q is not in the policy allow list", subcommand)}
+		}
+	}
+	return nil
+}
+
+// policySubcommand returns the command's arguments without flags, joined by spaces.
+func policySubcommand(cmd models.Command) string {
+	words := make([]string, 0, len(cmd.Args))
+	for _, arg := range cmd.Args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+func matchPolicyRule(rules []string, subcommand string) (string, bool) {
+	for _, rule := range rules {
+		normalized := strings.Join(strings.Fields(strings.ToLower(rule)), " ")
+		if normalized == "" {
+			continue
+		}
+		if subcommand == normalized || strings.HasPrefix(subcommand, normalized+" ") {
+			return normalized, true
+		}
+	}
+	return "", false
+}
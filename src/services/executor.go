@@ -1,8 +1,108 @@
 package services
 
-import "container-tui/src/models"
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"container-tui/src/models"
+)
 
 // CommandExecutor runs a command and returns a result.
 type CommandExecutor interface {
 	Execute(cmd models.Command) (models.Result, error)
 }
+
+// ContextExecutor runs a command bound to a context so it can be canceled or timed out.
+type ContextExecutor interface {
+	CommandExecutor
+	ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error)
+}
+
+// ExecuteContext runs cmd through executor, honoring ctx even when executor is not context-aware.
+// Executors without ExecuteContext keep running in the background after ctx is done; only the
+// caller is released.
+func ExecuteContext(ctx context.Context, executor CommandExecutor, cmd models.Command) (models.Result, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if contextual, ok := executor.(ContextExecutor); ok {
+		return contextual.ExecuteContext(ctx, cmd)
+	}
+	if err := ctx.Err(); err != nil {
+		return interruptedResult(ctx, 0)
+	}
+
+	type outcome struct {
+		result models.Result
+		err    error
+	}
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := executor.Execute(cmd)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case finished := <-done:
+		return finished.result, finished.err
+	case <-ctx.Done():
+		return interruptedResult(ctx, time.Since(start))
+	}
+}
+
+// CloseExecutor closes every executor in the chain that holds a connection.
+func CloseExecutor(executor CommandExecutor) error {
+	var errs []error
+	for executor != nil {
+		if closer, ok := executor.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+		wrapper, ok := executor.(WrappingExecutor)
+		if !ok {
+			break
+		}
+		executor = wrapper.Unwrap()
+	}
+	return errors.Join(errs...)
+}
+
+// IsCanceled reports whether err was caused by a user cancellation.
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// IsTimeout reports whether err was caused by a command exceeding its timeout.
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// interruptedResult builds the result for a command stopped by its context.
+func interruptedResult(ctx context.Context, duration time.Duration) (models.Result, error) {
+	err := ctx.Err()
+	result := models.Result{ExitCode: -1, Duration: duration, Status: models.ResultError}
+	if IsCanceled(err) {
+		result.Status = models.ResultCanceled
+		return result, err
+	}
+	return result, &TimeoutError{Err: err}
+}
+
+// TimeoutError reports a command that was stopped after exceeding its timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return "command timed out after " + e.Timeout.String()
+	}
+	return "command timed out"
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
@@ -12,7 +12,7 @@ func (b ExportContainerBuilder) Validate() error {
 	if _, err := normalizeRequiredToken(b.ContainerID, "container id"); err != nil {
 		return err
 	}
-	_, err := normalizeRequiredToken(b.ImageReference, "image reference")
+	_, err := normalizeImageReference(b.ImageReference, "image reference")
 	return err
 }
 
@@ -21,6 +21,6 @@ func (b ExportContainerBuilder) Build() (models.Command, error) {
 		return models.Command{}, err
 	}
 	containerID, _ := normalizeRequiredToken(b.ContainerID, "container id")
-	imageRef, _ := normalizeRequiredToken(b.ImageReference, "image reference")
+	imageRef, _ := normalizeImageReference(b.ImageReference, "image reference")
 	return models.Command{Executable: "container", Args: []string{"export", "--image", imageRef, containerID}}, nil
 }
@@ -21,6 +21,17 @@ type ContainerExportPlan struct {
 	CleanupCommand       models.Command
 }
 
+// CleanupAction declares the confirmation guard for the cleanup command.
+func (ContainerExportPlan) CleanupAction() DestructiveAction {
+	return ActionExportCleanup
+}
+
+// CleanupConfirmation is the text a type-to-confirm prompt wants typed back before the
+// cleanup runs: the reference of the image it deletes.
+func (p ContainerExportPlan) CleanupConfirmation() string {
+	return p.GeneratedImageRef
+}
+
 // ExportWorkflowResult captures the final export result.
 type ExportWorkflowResult struct {
 	Result      models.Result
@@ -42,25 +53,16 @@ func (s ExportWorkflowService) Plan(container models.Container, destinationDirec
 	if container.Status != models.ContainerStatusStopped {
 		return ContainerExportPlan{}, errors.New("only stopped containers can be exported")
 	}
-	destination := strings.TrimSpace(destinationDirectory)
-	if destination == "" {
-		return ContainerExportPlan{}, errors.New("destination directory is required")
-	}
-	info, err := os.Stat(destination)
+	destination, err := exportDestination(destinationDirectory)
 	if err != nil {
-		return ContainerExportPlan{}, fmt.Errorf("destination directory is not available: %w", err)
-	}
-	if !info.IsDir() {
-		return ContainerExportPlan{}, errors.New("destination must be a directory")
+		return ContainerExportPlan{}, err
 	}
 
 	now := s.Now()
 	imageRef := models.BuildExportImageReference(container.Name, container.ID, now)
-	archivePath := filepath.Join(destination, models.BuildExportArchiveName(container.Name, container.ID, now))
-	if _, err := os.Stat(archivePath); err == nil {
-		return ContainerExportPlan{}, errors.New("generated export archive already exists")
-	} else if !errors.Is(err, os.ErrNotExist) {
-		return ContainerExportPlan{}, fmt.Errorf("cannot validate export archive path: %w", err)
+	archivePath, err := exportArchivePath(destination, models.BuildExportArchiveName(container.Name, container.ID, now))
+	if err != nil {
+		return ContainerExportPlan{}, err
 	}
 
 	exportCmd, err := (ExportContainerBuilder{ContainerID: container.ID, ImageReference: imageRef}).Build()
@@ -86,6 +88,48 @@ func (s ExportWorkflowService) Plan(container models.Container, destinationDirec
 	}, nil
 }
 
+// PlanImageSave returns the command that saves image to an OCI archive in
+// destinationDirectory, named after the image reference.
+func (s ExportWorkflowService) PlanImageSave(image models.Image, destinationDirectory string) (models.Command, error) {
+	destination, err := exportDestination(destinationDirectory)
+	if err != nil {
+		return models.Command{}, err
+	}
+	reference := image.Reference()
+	archivePath, err := exportArchivePath(destination, models.BuildExportArchiveName(reference, image.Digest, s.Now()))
+	if err != nil {
+		return models.Command{}, err
+	}
+	return (ImageSaveBuilder{OutputPath: archivePath, ImageReference: reference}).Build()
+}
+
+// exportDestination checks that the destination directory exists.
+func exportDestination(destinationDirectory string) (string, error) {
+	destination := strings.TrimSpace(destinationDirectory)
+	if destination == "" {
+		return "", errors.New("destination directory is required")
+	}
+	info, err := os.Stat(destination)
+	if err != nil {
+		return "", fmt.Errorf("destination directory is not available: %w", err)
+	}
+	if !info.IsDir() {
+		return "", errors.New("destination must be a directory")
+	}
+	return destination, nil
+}
+
+// exportArchivePath joins the archive name to destination, refusing to overwrite a file.
+func exportArchivePath(destination, name string) (string, error) {
+	archivePath := filepath.Join(destination, name)
+	if _, err := os.Stat(archivePath); err == nil {
+		return "", errors.New("generated export archive already exists")
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("cannot validate export archive path: %w", err)
+	}
+	return archivePath, nil
+}
+
 // Execute runs the export and save steps in order.
 func (s ExportWorkflowService) Execute(plan ContainerExportPlan) (ExportWorkflowResult, error) {
 	if s.Executor == nil {
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// FakeBackend simulates the Apple Container CLI in memory. It answers every builder's command
+// with output in the formats the parsers expect, so the TUI can run without the macOS runtime.
+type FakeBackend struct {
+	// Latency paces streamed progress and log lines. Zero streams everything immediately and
+	// stops `logs -f` once the existing lines are delivered.
+	Latency time.Duration
+	// Now supplies timestamps for created resources.
+	Now func() time.Time
+
+	mu             sync.Mutex
+	daemonRunning  bool
+	builderRunning bool
+	containers     []fakeContainer
+	images         []models.Image
+	machines       []models.ContainerMachine
+	registries     []models.RegistryLogin
+	nextAddress    int
+}
+
+type fakeContainer struct {
+	ID      string
+	Image   string
+	State   models.ContainerStatus
+	Address string
+	Created time.Time
+}
+
+// fakeAPIServerVersion is reported by `system status` and `system version`.
+const fakeAPIServerVersion = "container-apiserver version 1.0.0 (build: release, commit: fake)"
+
+// fakeDaemonDownMessage mirrors the CLI error printed when the API server is unreachable.
+const fakeDaemonDownMessage = `Error: interrupted: "XPC connection error: Connection invalid". Ensure container system service has been started with ` + "`container system start`."
+
+// NewFakeBackend returns a backend seeded with a small demo environment.
+func NewFakeBackend() *FakeBackend {
+	f := &FakeBackend{Now: time.Now, daemonRunning: true, builderRunning: true, nextAddress: 2}
+	created := time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC)
+	for _, reference := range []string{"docker.io/library/nginx:latest", "docker.io/library/alpine:3.20", "docker.io/library/postgres:16", "ghcr.io/apple/containerization/vminit:0.1.0"} {
+		f.images = append(f.images, fakeImage(reference))
+	}
+	f.containers = []fakeContainer{
+		{ID: "web", Image: "docker.io/library/nginx:latest", State: models.ContainerStatusRunning, Address: f.allocateAddress(), Created: created},
+		{ID: "db", Image: "docker.io/library/postgres:16", State: models.ContainerStatusRunning, Address: f.allocateAddress(), Created: created},
+		{ID: "scratchpad", Image: "docker.io/library/alpine:3.20", State: models.ContainerStatusStopped, Created: created},
+	}
+	f.machines = []models.ContainerMachine{
+		{ID: "default", Image: "docker.io/library/alpine:3.20", State: models.MachineStateRunning, IsDefault: true, CPUs: 4, Memory: "8G", HomeMount: "rw"},
+		{ID: "builder", Image: "docker.io/library/alpine:3.20", State: models.MachineStateStopped, CPUs: 2, Memory: "4G", HomeMount: "ro"},
+	}
+	f.registries = []models.RegistryLogin{
+		{Hostname: "ghcr.io", Username: "demo", CreatedDate: created, ModifiedDate: created},
+	}
+	return f
+}
+
+// Execute answers the command from the in-memory model.
+func (f *FakeBackend) Execute(cmd models.Command) (models.Result, error) {
+	return f.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext answers the command unless ctx is already done.
+func (f *FakeBackend) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	start := time.Now()
+	if ctx.Err() != nil {
+		return interruptedResult(ctx, 0)
+	}
+	progress := f.progressLines(cmd)
+	result, err := f.dispatch(cmd)
+	if len(progress) > 0 && err == nil {
+		result.Stdout = strings.Join(progress, "\n") + "\n" + result.Stdout
+	}
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+// Stream emits progress lines paced by Latency, then the command output. With a non-zero
+// Latency, `logs -f` on a running container keeps following until ctx is canceled.
+func (f *FakeBackend) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	start := time.Now()
+	for _, line := range f.progressLines(cmd) {
+		if !f.pause(ctx) {
+			return interruptedResult(ctx, time.Since(start))
+		}
+		onLine(StreamLine{Source: StreamStdout, Text: line})
+	}
+	result, err := f.dispatch(cmd)
+	replayLines(result.Stdout, StreamStdout, onLine)
+	replayLines(result.Stderr, StreamStderr, onLine)
+	if err == nil && f.Latency > 0 && f.followsRunningContainer(cmd) {
+		for tick := 1; ; tick++ {
+			select {
+			case <-ctx.Done():
+				return interruptedResult(ctx, time.Since(start))
+			case <-time.After(10 * f.Latency):
+			}
+			onLine(StreamLine{Source: StreamStdout, Text: f.Now().UTC().Format(time.RFC3339) + fmt.Sprintf(" heartbeat %d: ok", tick)})
+		}
+	}
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+func (f *FakeBackend) pause(ctx context.Context) bool {
+	if f.Latency <= 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(f.Latency):
+		return true
+	}
+}
+
+func (f *FakeBackend) dispatch(cmd models.Command) (models.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	args := cmd.Args
+	if len(args) == 0 {
+		return fakeFailure("Error: missing subcommand")
+	}
+	if isHelpRequest(args) {
+		return f.help(args)
+	}
+	if args[0] == "system" {
+		return f.system(args[1:])
+	}
+	if !f.daemonRunning {
+		return fakeFailure(fakeDaemonDownMessage)
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return f.listContainers(args[1:])
+	case "run":
+		return f.runContainer(args[1:])
+	case "start":
+		return f.setContainerState(args[1:], models.ContainerStatusRunning)
+	case "stop":
+		return f.setContainerState(args[1:], models.ContainerStatusStopped)
+	case "delete", "rm":
+		return f.deleteContainer(args[1:])
+	case "logs":
+		return f.containerLogs(args[1:])
+	case "exec":
+		return f.execProbe(args[1:])
+	case "export":
+		return f.exportContainer(args[1:])
+	case "build":
+		return f.build(args[1:])
+	case "builder":
+		return f.builder(args[1:])
+	case "image":
+		return f.image(args[1:])
+	case "machine":
+		return f.machine(args[1:])
+	case "registry":
+		return f.registry(args[1:])
+	default:
+		return fakeFailure(fmt.Sprintf("Error: unknown subcommand %q", args[0]))
+	}
+}
+
+func (f *FakeBackend) system(args []string) (models.Result, error) {
+	if len(args) == 0 {
+		return fakeFailure("Error: missing system subcommand")
+	}
+	switch args[0] {
+	case "status":
+		if !f.daemonRunning {
+			return fakeSuccess(`{"status":"stopped"}`)
+		}
+		return fakeSuccess(fmt.Sprintf(`{"status":"running","apiServerVersion":%q,"installRoot":"/usr/local/","appRoot":"/Users/demo/Library/Application Support/com.apple.container/"}`, fakeAPIServerVersion))
+	case "start":
+		f.daemonRunning = true
+		return fakeSuccess("Verifying apiserver is running...\nDone")
+	case "stop":
+		f.daemonRunning = false
+		for i := range f.containers {
+			f.containers[i].State = models.ContainerStatusStopped
+			f.containers[i].Address = ""
+		}
+		return fakeSuccess("Stopping container services...\nDone")
+	case "version":
+		return fakeSuccess("container CLI version 1.0.0 (build: release, commit: fake)\n" + fakeAPIServerVersion)
+	default:
+		return fakeFailure(fmt.Sprintf("Error: unknown system subcommand %q", args[0]))
+	}
+}
+
+// progressLines returns the lines a long-running command prints before it completes.
+func (f *FakeBackend) progressLines(cmd models.Command) []string {
+	f.mu.Lock()
+	running, building := f.daemonRunning, f.builderRunning
+	f.mu.Unlock()
+	if !running {
+		return nil
+	}
+	switch ClassifyCommand(cmd) {
+	case CommandKindPull:
+		reference := lastArg(cmd.Args)
+		return []string{
+			"Fetching image " + reference,
+			"Resolving manifest... done",
+			"Downloading layer 1/3... done",
+			"Downloading layer 2/3... done",
+			"Downloading layer 3/3... done",
+			"Unpacking image... done",
+		}
+	case CommandKindBuild:
+		if !building {
+			return nil
+		}
+		return []string{
+			"[+] Building",
+			"#1 [internal] load build definition",
+			"#2 [internal] load metadata for base image",
+			"#3 [1/3] FROM base image",
+			"#4 [2/3] COPY . /app",
+			"#5 [3/3] RUN make install",
+			"#6 exporting to oci image format",
+		}
+	}
+	return nil
+}
+
+func (f *FakeBackend) followsRunningContainer(cmd models.Command) bool {
+	if len(cmd.Args) < 3 || cmd.Args[0] != "logs" || cmd.Args[1] != "-f" {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index := f.findContainer(cmd.Args[2])
+	return index >= 0 && f.containers[index].State == models.ContainerStatusRunning
+}
+
+func (f *FakeBackend) allocateAddress() string {
+	address := fmt.Sprintf("192.168.64.%d", f.nextAddress)
+	f.nextAddress++
+	return address
+}
+
+func fakeSuccess(stdout string) (models.Result, error) {
+	if stdout != "" && !strings.HasSuffix(stdout, "\n") {
+		stdout += "\n"
+	}
+	return models.Result{ExitCode: 0, Stdout: stdout, Status: models.ResultSuccess}, nil
+}
+
+func fakeFailure(stderr string) (models.Result, error) {
+	return models.Result{ExitCode: 1, Stderr: stderr + "\n", Status: models.ResultError}, errors.New("exit status 1")
+}
+
+// fakeDigest derives a stable digest from a reference.
+func fakeDigest(reference string) string {
+	sum := sha256.Sum256([]byte(reference))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func fakeImage(reference string) models.Image {
+	name, tag := splitFakeReference(reference)
+	digest := fakeDigest(name + ":" + tag)
+	return models.Image{
+		Name:     name,
+		Tag:      tag,
+		Digest:   digest,
+		Platform: "linux/arm64",
+		Size:     int64(8+digest[len("sha256:")]%32) << 20,
+		Created:  time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// wantsJSON reports whether args request `--format json`.
+func wantsJSON(args []string) bool {
+	for index, arg := range args {
+		if arg == "--format=json" || arg == "--format" && index+1 < len(args) && args[index+1] == "json" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFakeReference normalizes a reference into a fully qualified name and tag, which
+// defaults to latest.
+func splitFakeReference(reference string) (string, string) {
+	parsed, err := models.ParseImageReference(reference)
+	if err != nil {
+		return reference, "latest"
+	}
+	if parsed.Tag == "" {
+		return parsed.Name(), "latest"
+	}
+	return parsed.Name(), parsed.Tag
+}
+
+func lastArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[len(args)-1]
+}
+
+// flagValue returns the value following any of the given flags.
+func flagValue(args []string, flags ...string) string {
+	for i := 0; i < len(args)-1; i++ {
+		for _, flag := range flags {
+			if args[i] == flag {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"container-tui/src/models"
+)
+
+func (f *FakeBackend) findContainer(id string) int {
+	for i, container := range f.containers {
+		if container.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *FakeBackend) listContainers(args []string) (models.Result, error) {
+	all := len(args) > 0 && (args[0] == "--all" || args[0] == "-a")
+	if wantsJSON(args) {
+		return f.listContainersJSON(all)
+	}
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tIMAGE\tOS\tARCH\tSTATE\tADDR")
+	for _, container := range f.containers {
+		if !all && container.State != models.ContainerStatusRunning {
+			continue
+		}
+		fmt.Fprintf(writer, "%s\t%s\tlinux\tarm64\t%s\t%s\n", container.ID, container.Image, container.State, container.Address)
+	}
+	writer.Flush()
+	return fakeSuccess(builder.String())
+}
+
+// listContainersJSON prints containers in the shape of `container list --format json`.
+func (f *FakeBackend) listContainersJSON(all bool) (models.Result, error) {
+	entries := []map[string]any{}
+	for _, container := range f.containers {
+		if !all && container.State != models.ContainerStatusRunning {
+			continue
+		}
+		networks := []map[string]any{}
+		if container.Address != "" {
+			networks = append(networks, map[string]any{"network": "default", "address": container.Address + "/24", "hostname": container.ID})
+		}
+		entries = append(entries, map[string]any{
+			"status": container.State,
+			"configuration": map[string]any{
+				"id":       container.ID,
+				"image":    map[string]any{"reference": container.Image},
+				"labels":   map[string]string{"com.example.demo": "true"},
+				"mounts":   []any{},
+				"platform": map[string]string{"os": "linux", "architecture": "arm64"},
+			},
+			"networks":     networks,
+			"creationDate": container.Created.UTC().Format(time.RFC3339),
+		})
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fakeFailure("Error: " + err.Error())
+	}
+	return fakeSuccess(string(data))
+}
+
+// runContainer creates a container, pulling its image first when it is missing. Detached
+// containers keep running; attached ones print their startup logs and exit.
+func (f *FakeBackend) runContainer(args []string) (models.Result, error) {
+	name, reference, detach := "", "", false
+	for i := 0; i < len(args) && reference == ""; i++ {
+		switch arg := args[i]; {
+		case arg == "--detach" || arg == "-d":
+			detach = true
+		case arg == "--name":
+			name = flagValue(args[i:], "--name")
+			i++
+		case strings.HasPrefix(arg, "-"):
+			// Every other run flag takes a value.
+			i++
+		default:
+			reference = arg
+		}
+	}
+	if reference == "" {
+		return fakeFailure("Error: run requires an image")
+	}
+	if _, err := models.ParseImageReference(reference); err != nil {
+		return fakeFailure("Error: invalid reference format: " + err.Error())
+	}
+	image := fakeImage(reference)
+	if f.findImage(image.Reference()) < 0 {
+		if registry := referenceRegistry(reference); registry != DefaultRegistry && f.findRegistry(registry) < 0 {
+			return fakeFailure(fmt.Sprintf("Error: unauthorized: \"authentication required for %s\"", registry))
+		}
+		f.addImage(image)
+	}
+	if name == "" {
+		name = strings.TrimPrefix(fakeDigest(fmt.Sprintf("%s-%d", reference, len(f.containers))), "sha256:")[:12]
+	}
+	if f.findContainer(name) >= 0 {
+		return fakeFailure(fmt.Sprintf("Error: exists: \"container with id %s already exists\"", name))
+	}
+
+	container := fakeContainer{ID: name, Image: image.Reference(), State: models.ContainerStatusStopped, Created: f.Now()}
+	if detach {
+		container.State = models.ContainerStatusRunning
+		container.Address = f.allocateAddress()
+	}
+	f.containers = append(f.containers, container)
+	if detach {
+		return fakeSuccess(name)
+	}
+	stamp := container.Created.UTC().Format("2006-01-02T15:04:05Z")
+	return fakeSuccess(stamp + " starting " + container.Image + "\n" + stamp + " done, exiting")
+}
+
+func (f *FakeBackend) setContainerState(args []string, state models.ContainerStatus) (models.Result, error) {
+	id := lastArg(args)
+	index := f.findContainer(id)
+	if index < 0 {
+		return fakeContainerNotFound(id)
+	}
+	container := &f.containers[index]
+	container.State = state
+	switch {
+	case state == models.ContainerStatusRunning && container.Address == "":
+		container.Address = f.allocateAddress()
+	case state != models.ContainerStatusRunning:
+		container.Address = ""
+	}
+	return fakeSuccess(id)
+}
+
+func (f *FakeBackend) deleteContainer(args []string) (models.Result, error) {
+	id := lastArg(args)
+	index := f.findContainer(id)
+	if index < 0 {
+		return fakeContainerNotFound(id)
+	}
+	if f.containers[index].State == models.ContainerStatusRunning {
+		return fakeFailure(fmt.Sprintf("Error: invalidState: \"container %s is running and can not be deleted\"", id))
+	}
+	f.containers = append(f.containers[:index], f.containers[index+1:]...)
+	return fakeSuccess(id)
+}
+
+func (f *FakeBackend) containerLogs(args []string) (models.Result, error) {
+	id := lastArg(args)
+	index := f.findContainer(id)
+	if index < 0 {
+		return fakeContainerNotFound(id)
+	}
+	container := f.containers[index]
+	stamp := container.Created.UTC().Format("2006-01-02T15:04:05Z")
+	lines := []string{
+		stamp + " starting " + container.Image,
+		stamp + " listening on " + fallback(container.Address, "127.0.0.1"),
+		stamp + " ready to accept connections",
+	}
+	if container.State != models.ContainerStatusRunning {
+		lines = append(lines, stamp+" received shutdown signal, exiting")
+	}
+	return fakeSuccess(strings.Join(lines, "\n"))
+}
+
+// execProbe answers the shell probes issued by ShellDetector. Every running container has
+// sh; alpine-based images lack bash.
+func (f *FakeBackend) execProbe(args []string) (models.Result, error) {
+	if len(args) < 2 {
+		return fakeFailure("Error: exec requires a container and a command")
+	}
+	id := args[0]
+	index := f.findContainer(id)
+	if index < 0 {
+		return fakeContainerNotFound(id)
+	}
+	container := f.containers[index]
+	if container.State != models.ContainerStatusRunning {
+		return fakeFailure(fmt.Sprintf("Error: invalidState: \"container %s is not running\"", id))
+	}
+	shell := lastArg(args[1:])
+	if !fakeHasShell(container.Image, shell) {
+		return models.Result{ExitCode: 1, Status: models.ResultError}, fmt.Errorf("exit status 1")
+	}
+	if args[1] == "which" {
+		return fakeSuccess("/bin/" + strings.TrimPrefix(shell, "/bin/"))
+	}
+	return fakeSuccess("")
+}
+
+func fakeHasShell(image string, shell string) bool {
+	switch strings.TrimPrefix(shell, "/bin/") {
+	case "sh":
+		return true
+	case "ash":
+		return strings.Contains(image, "alpine")
+	case "bash":
+		return !strings.Contains(image, "alpine")
+	default:
+		return false
+	}
+}
+
+func (f *FakeBackend) exportContainer(args []string) (models.Result, error) {
+	reference := flagValue(args, "--image")
+	id := lastArg(args)
+	index := f.findContainer(id)
+	if index < 0 {
+		return fakeContainerNotFound(id)
+	}
+	if reference == "" {
+		return fakeFailure("Error: --image is required")
+	}
+	if f.containers[index].State == models.ContainerStatusRunning {
+		return fakeFailure(fmt.Sprintf("Error: invalidState: \"container %s must be stopped before export\"", id))
+	}
+	f.addImage(fakeImage(reference))
+	return fakeSuccess("Exported container " + id + " to image " + reference)
+}
+
+func fakeContainerNotFound(id string) (models.Result, error) {
+	return fakeFailure(fmt.Sprintf("Error: notFound: \"container %s not found\"", id))
+}
+
+func fallback(value string, defaultValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+	return value
+}
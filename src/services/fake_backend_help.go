@@ -0,0 +1,82 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// fakeHelp is the `container --help` output of the simulated CLI.
+const fakeHelp = `OVERVIEW: A container platform for macOS
+
+USAGE: container [--debug] <subcommand>
+
+OPTIONS:
+  --debug                 Enable debug output [environment: CONTAINER_DEBUG]
+  --version               Show the version.
+  -h, --help              Show help information.
+
+CONTAINER SUBCOMMANDS:
+  create                  Create a new container
+  delete, rm              Delete one or more containers
+  exec                    Run a new command in a running container
+  export                  Export a container's filesystem as a tar archive
+  inspect                 Display information about one or more containers
+  list, ls                List containers
+  logs                    Fetch container logs
+  run                     Run a container
+  start                   Start a container
+  stop                    Stop one or more running containers
+
+IMAGE SUBCOMMANDS:
+  build                   Build an image from a Dockerfile or Containerfile
+  image, i                Manage images
+  registry, r             Manage registry logins
+
+MACHINE SUBCOMMANDS:
+  machine, m              Manage container machines
+
+OTHER SUBCOMMANDS:
+  builder                 Manage an image builder instance
+  network, n              Manage container networks
+  system, s               Manage system components
+  volume, v               Manage container volumes
+`
+
+// fakeListHelp documents the --format flag shared by `list` and `image list`.
+const fakeListHelp = `OVERVIEW: %s
+
+USAGE: container %s [--format <format>]
+
+OPTIONS:
+  --format <format>       Format of the output (values: json, table; default:
+                          table)
+  -h, --help              Show help information.
+`
+
+// isHelpRequest reports whether args end in --help. Only the last argument counts, so a
+// command run by `exec` may take its own -h or --help.
+func isHelpRequest(args []string) bool {
+	return len(args) > 0 && args[len(args)-1] == "--help"
+}
+
+// help answers `--help` for the top level and any subcommand.
+func (f *FakeBackend) help(args []string) (models.Result, error) {
+	words := []string{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			words = append(words, arg)
+		}
+	}
+	switch strings.Join(words, " ") {
+	case "":
+		return fakeSuccess(fakeHelp)
+	case "list", "ls":
+		return fakeSuccess(fmt.Sprintf(fakeListHelp, "List containers", "list"))
+	case "image list", "image ls":
+		return fakeSuccess(fmt.Sprintf(fakeListHelp, "List images", "image list"))
+	default:
+		return fakeSuccess("OVERVIEW: container " + strings.Join(words, " ") + "\n\nOPTIONS:\n  -h, --help              Show help information.")
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"container-tui/src/models"
+)
+
+func (f *FakeBackend) image(args []string) (models.Result, error) {
+	if len(args) == 0 {
+		return fakeFailure("Error: missing image subcommand")
+	}
+	switch args[0] {
+	case "list", "ls":
+		if wantsJSON(args[1:]) {
+			return f.listImagesJSON()
+		}
+		return f.listImages()
+	case "pull":
+		reference := lastArg(args[1:])
+		if reference == "" {
+			return fakeFailure("Error: image pull requires a reference")
+		}
+		if _, err := models.ParseImageReference(reference); err != nil {
+			return fakeFailure("Error: invalid reference format: " + err.Error())
+		}
+		if registry := referenceRegistry(reference); registry != DefaultRegistry && f.findRegistry(registry) < 0 {
+			return fakeFailure(fmt.Sprintf("Error: unauthorized: \"authentication required for %s\"", registry))
+		}
+		image := fakeImage(reference)
+		f.addImage(image)
+		return fakeSuccess("Pulled " + image.Reference() + "\nDigest: " + image.Digest)
+	case "rm", "delete":
+		return f.deleteImage(lastArg(args[1:]))
+	case "prune":
+		return f.pruneImages()
+	case "inspect":
+		return f.inspectImage(lastArg(args[1:]))
+	case "save":
+		reference := lastArg(args[1:])
+		if f.findImage(reference) < 0 {
+			return fakeImageNotFound(reference)
+		}
+		return fakeSuccess(fmt.Sprintf("Saved %s to %s (fake backend: archive not written)", reference, flagValue(args, "--output", "-o")))
+	default:
+		return fakeFailure(fmt.Sprintf("Error: unknown image subcommand %q", args[0]))
+	}
+}
+
+func (f *FakeBackend) build(args []string) (models.Result, error) {
+	if !f.builderRunning {
+		return fakeFailure(fakeBuilderDownMessage)
+	}
+	tag := flagValue(args, "-t", "--tag")
+	if tag == "" {
+		return fakeFailure("Error: build requires --tag")
+	}
+	image := fakeImage(tag)
+	f.addImage(image)
+	return fakeSuccess("Successfully built " + image.Reference())
+}
+
+// fakeBuilderDownMessage mirrors the CLI error printed when the builder is stopped.
+const fakeBuilderDownMessage = `Error: unavailable: "builder is not running". Start it with ` + "`container builder start`."
+
+func (f *FakeBackend) builder(args []string) (models.Result, error) {
+	if len(args) == 0 {
+		return fakeFailure("Error: missing builder subcommand")
+	}
+	switch args[0] {
+	case "start":
+		f.builderRunning = true
+		return fakeSuccess("Builder started")
+	case "stop":
+		f.builderRunning = false
+		return fakeSuccess("Builder stopped")
+	case "status":
+		if !f.builderRunning {
+			return fakeSuccess("builder is stopped")
+		}
+		return fakeSuccess("builder is running")
+	default:
+		return fakeFailure(fmt.Sprintf("Error: unknown builder subcommand %q", args[0]))
+	}
+}
+
+func (f *FakeBackend) listImages() (models.Result, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tTAG\tDIGEST")
+	for _, image := range f.images {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", image.Name, image.Tag, image.Digest)
+	}
+	writer.Flush()
+	return fakeSuccess(builder.String())
+}
+
+// listImagesJSON prints images in the shape of `container image list --format json`.
+func (f *FakeBackend) listImagesJSON() (models.Result, error) {
+	entries := []map[string]any{}
+	for _, image := range f.images {
+		entries = append(entries, map[string]any{
+			"reference":  image.Reference(),
+			"descriptor": map[string]any{"mediaType": "application/vnd.oci.image.index.v1+json", "digest": image.Digest, "size": 1024},
+			"variants": []map[string]any{{
+				"platform": map[string]string{"os": "linux", "architecture": "arm64"},
+				"size":     image.Size,
+				"config":   map[string]any{"created": image.Created.UTC().Format(time.RFC3339)},
+			}},
+		})
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fakeFailure("Error: " + err.Error())
+	}
+	return fakeSuccess(string(data))
+}
+
+// findImage matches a reference in its short or fully qualified form.
+func (f *FakeBackend) findImage(reference string) int {
+	name, tag := splitFakeReference(reference)
+	for i, image := range f.images {
+		if image.Reference() == reference || (image.Name == name && image.Tag == tag) {
+			return i
+		}
+	}
+	return -1
+}
+
+// addImage records image, replacing an existing image with the same reference.
+func (f *FakeBackend) addImage(image models.Image) {
+	if index := f.findImage(image.Reference()); index >= 0 {
+		f.images[index] = image
+		return
+	}
+	f.images = append(f.images, image)
+}
+
+func (f *FakeBackend) imageInUse(image models.Image) bool {
+	for _, container := range f.containers {
+		name, tag := splitFakeReference(container.Image)
+		if name == image.Name && tag == image.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeBackend) deleteImage(reference string) (models.Result, error) {
+	index := f.findImage(reference)
+	if index < 0 {
+		return fakeImageNotFound(reference)
+	}
+	if f.imageInUse(f.images[index]) {
+		return fakeFailure(fmt.Sprintf("Error: invalidState: \"image %s is in use by a container\"", reference))
+	}
+	f.images = append(f.images[:index], f.images[index+1:]...)
+	return fakeSuccess(reference)
+}
+
+func (f *FakeBackend) pruneImages() (models.Result, error) {
+	kept := make([]models.Image, 0, len(f.images))
+	removed := make([]string, 0)
+	for _, image := range f.images {
+		if f.imageInUse(image) || strings.Contains(image.Name, "vminit") {
+			kept = append(kept, image)
+			continue
+		}
+		removed = append(removed, image.Reference())
+	}
+	f.images = kept
+	lines := append([]string{fmt.Sprintf("Removed %d images", len(removed))}, removed...)
+	return fakeSuccess(strings.Join(lines, "\n"))
+}
+
+func (f *FakeBackend) inspectImage(reference string) (models.Result, error) {
+	index := f.findImage(reference)
+	if index < 0 {
+		return fakeImageNotFound(reference)
+	}
+	image := f.images[index]
+	payload := []map[string]any{{
+		"name":   image.Reference(),
+		"digest": image.Digest,
+		"variants": []map[string]any{{
+			"platform": map[string]string{"os": "linux", "architecture": "arm64"},
+			"size":     len(image.Digest) * 1024 * 1024,
+		}},
+	}}
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fakeFailure("Error: " + err.Error())
+	}
+	return fakeSuccess(string(data))
+}
+
+func fakeImageNotFound(reference string) (models.Result, error) {
+	return fakeFailure(fmt.Sprintf("Error: notFound: \"image %s not found\"", reference))
+}
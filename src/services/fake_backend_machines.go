@@ -0,0 +1,177 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+func (f *FakeBackend) machine(args []string) (models.Result, error) {
+	if len(args) == 0 {
+		return fakeFailure("Error: missing machine subcommand")
+	}
+	switch args[0] {
+	case "list", "ls":
+		return fakeJSON(f.machines)
+	case "create":
+		return f.createMachine(args[1:])
+	case "inspect":
+		index, result, err := f.requireMachine(lastArg(args[1:]))
+		if index < 0 {
+			return result, err
+		}
+		return fakeJSON(f.machines[index])
+	case "logs":
+		index, result, err := f.requireMachine(lastArg(args[1:]))
+		if index < 0 {
+			return result, err
+		}
+		machine := f.machines[index]
+		return fakeSuccess(strings.Join([]string{
+			"booting machine " + machine.ID,
+			fmt.Sprintf("configured %d cpus and %s memory", machine.CPUs, machine.Memory),
+			"home mount: " + machine.NormalizedHomeMount(),
+			"machine " + machine.ID + " is " + string(machine.State),
+		}, "\n"))
+	case "run", "start":
+		return f.setMachineState(flagValue(args, "-n", "--name"), models.MachineStateRunning)
+	case "stop":
+		return f.setMachineState(lastArg(args[1:]), models.MachineStateStopped)
+	case "set":
+		return f.setMachineResources(args[1:])
+	case "set-default":
+		id := lastArg(args[1:])
+		if index, result, err := f.requireMachine(id); index < 0 {
+			return result, err
+		}
+		for i := range f.machines {
+			f.machines[i].IsDefault = f.machines[i].ID == id
+		}
+		return fakeSuccess(id)
+	case "delete", "rm":
+		id := lastArg(args[1:])
+		index, result, err := f.requireMachine(id)
+		if index < 0 {
+			return result, err
+		}
+		if f.machines[index].State == models.MachineStateRunning {
+			return fakeFailure(fmt.Sprintf("Error: invalidState: \"machine %s is running and can not be deleted\"", id))
+		}
+		f.machines = append(f.machines[:index], f.machines[index+1:]...)
+		return fakeSuccess(id)
+	default:
+		return fakeFailure(fmt.Sprintf("Error: unknown machine subcommand %q", args[0]))
+	}
+}
+
+func (f *FakeBackend) requireMachine(id string) (int, models.Result, error) {
+	for i, machine := range f.machines {
+		if machine.ID == id {
+			return i, models.Result{}, nil
+		}
+	}
+	result, err := fakeFailure(fmt.Sprintf("Error: notFound: \"machine %s not found\"", id))
+	return -1, result, err
+}
+
+func (f *FakeBackend) createMachine(args []string) (models.Result, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fakeFailure("Error: machine create requires an image")
+	}
+	id := flagValue(args, "--name", "-n")
+	if id == "" {
+		id = fmt.Sprintf("machine-%d", len(f.machines)+1)
+	}
+	if index, _, _ := f.requireMachine(id); index >= 0 {
+		return fakeFailure(fmt.Sprintf("Error: exists: \"machine %s already exists\"", id))
+	}
+	f.machines = append(f.machines, models.ContainerMachine{
+		ID:        id,
+		Image:     args[0],
+		State:     models.MachineStateStopped,
+		IsDefault: len(f.machines) == 0,
+		CPUs:      2,
+		Memory:    "4G",
+		HomeMount: "rw",
+	})
+	return fakeSuccess(id)
+}
+
+func (f *FakeBackend) setMachineState(id string, state models.MachineState) (models.Result, error) {
+	index, result, err := f.requireMachine(id)
+	if index < 0 {
+		return result, err
+	}
+	f.machines[index].State = state
+	return fakeSuccess(id)
+}
+
+// setMachineResources applies the key=value pairs of `machine set -n <id> ...`.
+func (f *FakeBackend) setMachineResources(args []string) (models.Result, error) {
+	id := flagValue(args, "-n", "--name")
+	index, result, err := f.requireMachine(id)
+	if index < 0 {
+		return result, err
+	}
+	machine := f.machines[index]
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "cpus":
+			cpus, convErr := strconv.Atoi(value)
+			if convErr != nil || cpus <= 0 {
+				return fakeFailure(fmt.Sprintf("Error: invalidArgument: \"cpus must be a positive integer, got %q\"", value))
+			}
+			machine.CPUs = cpus
+		case "memory":
+			machine.Memory = value
+		case "home-mount":
+			machine.HomeMount = value
+		default:
+			return fakeFailure(fmt.Sprintf("Error: invalidArgument: \"unknown machine setting %q\"", key))
+		}
+	}
+	f.machines[index] = machine
+	return fakeSuccess(id)
+}
+
+func (f *FakeBackend) registry(args []string) (models.Result, error) {
+	if len(args) == 0 || (args[0] != "list" && args[0] != "ls") {
+		return fakeFailure("Error: unsupported registry subcommand")
+	}
+	return fakeJSON(f.registries)
+}
+
+func fakeJSON(value any) (models.Result, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fakeFailure("Error: " + err.Error())
+	}
+	return fakeSuccess(string(data))
+}
+
+func (f *FakeBackend) findRegistry(hostname string) int {
+	for i, registry := range f.registries {
+		if registry.Hostname == hostname {
+			return i
+		}
+	}
+	return -1
+}
+
+// login records a registry login, replacing an existing login for the same host.
+func (f *FakeBackend) login(hostname string, username string) {
+	now := f.Now()
+	if index := f.findRegistry(hostname); index >= 0 {
+		f.registries[index].Username = username
+		f.registries[index].ModifiedDate = now
+		return
+	}
+	f.registries = append(f.registries, models.RegistryLogin{Hostname: hostname, Username: username, CreatedDate: now, ModifiedDate: now})
+}
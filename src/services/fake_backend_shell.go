@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// Interactive returns a simulated shell for `exec -it`, or a simulated prompt for
+// `registry login`, so demo sessions never reach a real CLI.
+func (f *FakeBackend) Interactive(cmd models.Command) InteractiveProcess {
+	if len(cmd.Args) >= 3 && cmd.Args[0] == "registry" && cmd.Args[1] == "login" {
+		return &fakeLogin{backend: f, server: lastArg(cmd.Args)}
+	}
+	shell := &fakeShell{backend: f}
+	args := cmd.Args
+	if len(args) >= 4 && args[0] == "exec" {
+		shell.container = args[len(args)-2]
+		shell.shell = args[len(args)-1]
+	}
+	return shell
+}
+
+// fakeShell is a line-based stand-in for a container shell.
+type fakeShell struct {
+	backend   *FakeBackend
+	container string
+	shell     string
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+}
+
+func (s *fakeShell) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *fakeShell) SetStdout(w io.Writer) { s.stdout = w }
+func (s *fakeShell) SetStderr(w io.Writer) { s.stderr = w }
+
+// Run reads commands until exit or end of input.
+func (s *fakeShell) Run() error {
+	if s.stdin == nil || s.stdout == nil {
+		return fmt.Errorf("fake shell requires stdin and stdout")
+	}
+	s.backend.mu.Lock()
+	index := s.backend.findContainer(s.container)
+	running := index >= 0 && s.backend.containers[index].State == models.ContainerStatusRunning
+	s.backend.mu.Unlock()
+	if !running {
+		return fmt.Errorf("container %s is not running", s.container)
+	}
+
+	fmt.Fprintf(s.stdout, "Simulated %s session in %s (fake backend). Type exit to return.\n", s.shell, s.container)
+	prompt := fmt.Sprintf("%s:/# ", s.container)
+	scanner := bufio.NewScanner(s.stdin)
+	for {
+		fmt.Fprint(s.stdout, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(s.stdout)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case line == "exit" || line == "logout":
+			return nil
+		case line == "hostname":
+			fmt.Fprintln(s.stdout, s.container)
+		case line == "whoami":
+			fmt.Fprintln(s.stdout, "root")
+		case line == "pwd":
+			fmt.Fprintln(s.stdout, "/")
+		case strings.HasPrefix(line, "echo "):
+			fmt.Fprintln(s.stdout, strings.TrimPrefix(line, "echo "))
+		default:
+			fmt.Fprintf(s.stdout, "%s: %s: simulated shell supports echo, hostname, pwd, whoami and exit\n", s.shell, strings.Fields(line)[0])
+		}
+	}
+}
+
+// fakeLogin prompts for registry credentials and accepts any non-empty username.
+type fakeLogin struct {
+	backend *FakeBackend
+	server  string
+	stdin   io.Reader
+	stdout  io.Writer
+}
+
+func (l *fakeLogin) SetStdin(r io.Reader)  { l.stdin = r }
+func (l *fakeLogin) SetStdout(w io.Writer) { l.stdout = w }
+func (l *fakeLogin) SetStderr(io.Writer)   {}
+
+// Run reads a username and password and records the login.
+func (l *fakeLogin) Run() error {
+	if l.stdin == nil || l.stdout == nil {
+		return fmt.Errorf("fake login requires stdin and stdout")
+	}
+	scanner := bufio.NewScanner(l.stdin)
+	fmt.Fprintf(l.stdout, "Logging in to %s (fake backend: any password is accepted)\nUsername: ", l.server)
+	if !scanner.Scan() {
+		return fmt.Errorf("login to %s canceled", l.server)
+	}
+	username := strings.TrimSpace(scanner.Text())
+	fmt.Fprint(l.stdout, "Password: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("login to %s canceled", l.server)
+	}
+	if username == "" {
+		return fmt.Errorf("unauthorized: username is required")
+	}
+	l.backend.mu.Lock()
+	l.backend.login(l.server, username)
+	l.backend.mu.Unlock()
+	fmt.Fprintln(l.stdout, "Login succeeded")
+	return nil
+}
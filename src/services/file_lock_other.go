@@ -0,0 +1,16 @@
+//go:build !unix
+
+package services
+
+import "os"
+
+// lockFile is a no-op where advisory locks are unavailable; concurrent actui processes
+// may then interleave log maintenance.
+func lockFile(file *os.File, exclusive bool) error {
+	return nil
+}
+
+// unlockFile releases a lock taken with lockFile.
+func unlockFile(file *os.File) error {
+	return nil
+}
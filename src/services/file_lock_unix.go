@@ -0,0 +1,28 @@
+//go:build unix
+
+package services
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an advisory lock on file, blocking until it is available. Shared locks
+// admit other readers; exclusive locks admit nobody else.
+func lockFile(file *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	for {
+		err := syscall.Flock(int(file.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases a lock taken with lockFile.
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
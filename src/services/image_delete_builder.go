@@ -19,3 +19,8 @@ func (b ImageDeleteBuilder) Build() (models.Command, error) {
 	ref, _ := normalizeRequiredToken(b.ImageReference, "image reference")
 	return models.Command{Executable: "container", Args: []string{"image", "rm", ref}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (ImageDeleteBuilder) GuardedAction() DestructiveAction {
+	return ActionDeleteImage
+}
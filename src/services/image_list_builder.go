@@ -2,11 +2,29 @@ package services
 
 import "container-tui/src/models"
 
-// ImageListBuilder builds `container image list`.
-type ImageListBuilder struct{}
+// ImageListBuilder builds `container image list`. Capabilities selects JSON output when
+// the CLI supports it; unknown capabilities keep the table.
+type ImageListBuilder struct {
+	Capabilities Capabilities
+}
 
 func (b ImageListBuilder) Validate() error { return nil }
 
 func (b ImageListBuilder) Build() (models.Command, error) {
-	return models.Command{Executable: "container", Args: []string{"image", "list"}}, nil
+	args := []string{"image", "list"}
+	if b.Format() == "json" {
+		args = append(args, "--format", "json")
+	}
+	return models.Command{Executable: "container", Args: args}, nil
+}
+
+// Format returns the output format Build requests: "json" or "table".
+func (b ImageListBuilder) Format() string {
+	return b.Capabilities.OutputFormat(CapabilityImageListJSON)
+}
+
+// Parse reads the output of the built command. Table output, including a table printed
+// despite --format json, goes through ParseImageList.
+func (b ImageListBuilder) Parse(output string) ([]models.Image, error) {
+	return parseListOutput("container image list", b.Format(), b.Capabilities.Version, output, ParseImageListJSON, ParseImageList)
 }
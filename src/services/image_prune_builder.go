@@ -10,3 +10,8 @@ func (b ImagePruneBuilder) Validate() error { return nil }
 func (b ImagePruneBuilder) Build() (models.Command, error) {
 	return models.Command{Executable: "container", Args: []string{"image", "prune"}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (ImagePruneBuilder) GuardedAction() DestructiveAction {
+	return ActionPruneImages
+}
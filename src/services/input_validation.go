@@ -3,6 +3,8 @@ package services
 import (
 	"fmt"
 	"strings"
+
+	"container-tui/src/models"
 )
 
 // normalizeRequiredToken trims input and rejects whitespace for single-token args.
@@ -16,3 +18,16 @@ func normalizeRequiredToken(value, field string) (string, error) {
 	}
 	return trimmed, nil
 }
+
+// normalizeImageReference trims value and checks it against the image reference grammar.
+// The reference is returned as written, not normalized.
+func normalizeImageReference(value, field string) (string, error) {
+	trimmed, err := normalizeRequiredToken(value, field)
+	if err != nil {
+		return "", err
+	}
+	if _, err := models.ParseImageReference(trimmed); err != nil {
+		return "", err
+	}
+	return trimmed, nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os/exec"
+
+	"container-tui/src/models"
+)
+
+// InteractiveProcess is a command that takes over the terminal. Its method set matches
+// tea.ExecCommand so the UI can hand it straight to Bubble Tea.
+type InteractiveProcess interface {
+	Run() error
+	SetStdin(io.Reader)
+	SetStdout(io.Writer)
+	SetStderr(io.Writer)
+}
+
+// InteractiveExecutor is implemented by backends that run interactive sessions themselves
+// rather than through the local `container` binary.
+type InteractiveExecutor interface {
+	Interactive(cmd models.Command) InteractiveProcess
+}
+
+// WrappingExecutor is implemented by middleware that delegates to another executor.
+type WrappingExecutor interface {
+	Unwrap() CommandExecutor
+}
+
+// InteractiveCommand prepares cmd for an interactive session, using the innermost backend that
+// supports interactive sessions and falling back to a local process.
+func InteractiveCommand(executor CommandExecutor, cmd models.Command) InteractiveProcess {
+	for executor != nil {
+		if interactive, ok := executor.(InteractiveExecutor); ok {
+			return interactive.Interactive(cmd)
+		}
+		wrapper, ok := executor.(WrappingExecutor)
+		if !ok {
+			break
+		}
+		executor = wrapper.Unwrap()
+	}
+	return localProcess{newProcess(context.Background(), cmd)}
+}
+
+// localProcess adapts exec.Cmd to InteractiveProcess.
+type localProcess struct {
+	*exec.Cmd
+}
+
+func (p localProcess) SetStdin(r io.Reader) {
+	if p.Stdin == nil {
+		p.Stdin = r
+	}
+}
+
+func (p localProcess) SetStdout(w io.Writer) {
+	if p.Stdout == nil {
+		p.Stdout = w
+	}
+}
+
+func (p localProcess) SetStderr(w io.Writer) {
+	if p.Stderr == nil {
+		p.Stderr = w
+	}
+}
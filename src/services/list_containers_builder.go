@@ -4,8 +4,11 @@ import (
 	"container-tui/src/models"
 )
 
-// ListContainersBuilder builds the list containers command.
-type ListContainersBuilder struct{}
+// ListContainersBuilder builds the list containers command. Capabilities selects JSON
+// output when the CLI supports it; unknown capabilities keep the table.
+type ListContainersBuilder struct {
+	Capabilities Capabilities
+}
 
 // Validate returns nil because there are no inputs.
 func (ListContainersBuilder) Validate() error {
@@ -13,6 +16,21 @@ func (ListContainersBuilder) Validate() error {
 }
 
 // Build returns the list command.
-func (ListContainersBuilder) Build() (models.Command, error) {
-	return models.Command{Executable: "container", Args: []string{"list", "--all"}}, nil
+func (b ListContainersBuilder) Build() (models.Command, error) {
+	args := []string{"list", "--all"}
+	if b.Format() == "json" {
+		args = append(args, "--format", "json")
+	}
+	return models.Command{Executable: "container", Args: args}, nil
+}
+
+// Format returns the output format Build requests: "json" or "table".
+func (b ListContainersBuilder) Format() string {
+	return b.Capabilities.OutputFormat(CapabilityContainerListJSON)
+}
+
+// Parse reads the output of the built command. Table output, including a table printed
+// despite --format json, goes through ParseContainerList.
+func (b ListContainersBuilder) Parse(output string) ([]models.Container, error) {
+	return parseListOutput("container list", b.Format(), b.Capabilities.Version, output, ParseContainerListJSON, ParseContainerList)
 }
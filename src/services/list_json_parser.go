@@ -0,0 +1,256 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// OutputParseError reports list output that could not be parsed, with the output format
+// that was requested and the CLI version that printed it.
+type OutputParseError struct {
+	Command string
+	Format  string
+	Version CLIVersion
+	Err     error
+}
+
+func (e *OutputParseError) Error() string {
+	version := "of unknown version"
+	if !e.Version.IsZero() {
+		version = e.Version.String()
+	}
+	return fmt.Sprintf("parse `%s` %s output from container CLI %s: %v", e.Command, e.Format, version, e.Err)
+}
+
+func (e *OutputParseError) Unwrap() error {
+	return e.Err
+}
+
+// isJSONOutput reports whether output is JSON rather than the table a CLI prints when it
+// ignores --format.
+func isJSONOutput(output string) bool {
+	trimmed := strings.TrimSpace(output)
+	return strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{")
+}
+
+// cliTime decodes the CLI's timestamps: RFC 3339 strings, or the seconds since 2001-01-01
+// that Foundation's JSON encoder writes by default.
+type cliTime time.Time
+
+var foundationEpoch = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func (t *cliTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err == nil {
+		*t = cliTime(foundationEpoch.Add(time.Duration(seconds * float64(time.Second))))
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return err
+	}
+	*t = cliTime(parsed)
+	return nil
+}
+
+type cliPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant"`
+}
+
+func (p cliPlatform) String() string {
+	parts := []string{}
+	for _, part := range []string{p.OS, p.Architecture, p.Variant} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+type cliNetwork struct {
+	Network  string `json:"network"`
+	Address  string `json:"address"`
+	Hostname string `json:"hostname"`
+}
+
+// containerListEntry is one container in `container list --format json`.
+type containerListEntry struct {
+	Status        string `json:"status"`
+	Configuration struct {
+		ID    string `json:"id"`
+		Image struct {
+			Reference string `json:"reference"`
+		} `json:"image"`
+		Labels map[string]string `json:"labels"`
+		Mounts []struct {
+			Source      string   `json:"source"`
+			Destination string   `json:"destination"`
+			Options     []string `json:"options"`
+		} `json:"mounts"`
+		Platform       cliPlatform `json:"platform"`
+		PublishedPorts []struct {
+			HostPort      int    `json:"hostPort"`
+			ContainerPort int    `json:"containerPort"`
+			Proto         string `json:"proto"`
+		} `json:"publishedPorts"`
+		CreationDate cliTime `json:"creationDate"`
+	} `json:"configuration"`
+	Networks     []cliNetwork `json:"networks"`
+	CreationDate cliTime      `json:"creationDate"`
+}
+
+// ParseContainerListJSON parses `container list --format json` output.
+func ParseContainerListJSON(output string) ([]models.Container, error) {
+	trimmed := strings.TrimSpace(output)
+	if trimmed == "" {
+		return []models.Container{}, nil
+	}
+	var entries []containerListEntry
+	if err := json.Unmarshal([]byte(trimmed), &entries); err != nil {
+		return nil, err
+	}
+
+	containers := make([]models.Container, 0, len(entries))
+	for _, entry := range entries {
+		config := entry.Configuration
+		if config.ID == "" {
+			continue
+		}
+		container := models.Container{
+			ID:       config.ID,
+			Name:     config.ID,
+			Image:    config.Image.Reference,
+			Status:   parseContainerStatus(entry.Status),
+			Labels:   config.Labels,
+			Platform: config.Platform.String(),
+		}
+		for _, port := range config.PublishedPorts {
+			container.Ports = append(container.Ports, models.PortMapping{HostPort: port.HostPort, ContainerPort: port.ContainerPort, Protocol: port.Proto})
+		}
+		for _, mount := range config.Mounts {
+			readOnly := false
+			for _, option := range mount.Options {
+				readOnly = readOnly || option == "ro" || option == "readonly"
+			}
+			container.Mounts = append(container.Mounts, models.Mount{Source: mount.Source, Destination: mount.Destination, ReadOnly: readOnly})
+		}
+		for _, network := range entry.Networks {
+			container.Networks = append(container.Networks, models.ContainerNetwork{Name: network.Network, Address: network.Address, Hostname: network.Hostname})
+		}
+		container.CreatedAt = time.Time(entry.CreationDate)
+		if container.CreatedAt.IsZero() {
+			container.CreatedAt = time.Time(config.CreationDate)
+		}
+		if !container.CreatedAt.IsZero() {
+			container.Created = container.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// imageListEntry is one image in `container image list --format json`.
+type imageListEntry struct {
+	Reference  string `json:"reference"`
+	Descriptor struct {
+		Digest string `json:"digest"`
+		Size   int64  `json:"size"`
+	} `json:"descriptor"`
+	Variants []struct {
+		Platform cliPlatform `json:"platform"`
+		Size     int64       `json:"size"`
+		Config   struct {
+			Created cliTime `json:"created"`
+			Config  struct {
+				Labels map[string]string `json:"Labels"`
+			} `json:"config"`
+		} `json:"config"`
+	} `json:"variants"`
+}
+
+// ParseImageListJSON parses `container image list --format json` output. An image's size
+// is the sum of its platform variants, or its descriptor size without variants.
+func ParseImageListJSON(output string) ([]models.Image, error) {
+	trimmed := strings.TrimSpace(output)
+	if trimmed == "" {
+		return []models.Image{}, nil
+	}
+	var entries []imageListEntry
+	if err := json.Unmarshal([]byte(trimmed), &entries); err != nil {
+		return nil, err
+	}
+
+	images := make([]models.Image, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Reference == "" {
+			continue
+		}
+		name, tag := splitImageListReference(entry.Reference)
+		image := models.Image{Name: name, Tag: tag, Digest: entry.Descriptor.Digest, Size: entry.Descriptor.Size}
+		platforms := []string{}
+		var size int64
+		for _, variant := range entry.Variants {
+			if platform := variant.Platform.String(); platform != "" && platform != "unknown/unknown" {
+				platforms = append(platforms, platform)
+			}
+			size += variant.Size
+			if image.Created.IsZero() {
+				image.Created = time.Time(variant.Config.Created)
+			}
+			if image.Labels == nil {
+				image.Labels = variant.Config.Config.Labels
+			}
+		}
+		if size > 0 {
+			image.Size = size
+		}
+		image.Platform = strings.Join(platforms, ", ")
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// splitImageListReference splits a listed reference into its normalized name and tag, or
+// "<none>" for images listed by digest. References that do not parse are split as written.
+func splitImageListReference(reference string) (string, string) {
+	if parsed, err := models.ParseImageReference(reference); err == nil {
+		if parsed.Tag == "" {
+			return parsed.Name(), "<none>"
+		}
+		return parsed.Name(), parsed.Tag
+	}
+	name, _, _ := strings.Cut(reference, "@")
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		return name[:colon], name[colon+1:]
+	}
+	return name, "<none>"
+}
+
+// parseListOutput parses output with parseJSON when format is "json" and the CLI printed
+// JSON, and with the table parser otherwise. Failures name the command, format and version.
+func parseListOutput[T any](command string, format string, version CLIVersion, output string, parseJSON func(string) ([]T, error), parseTable func(string) ([]T, error)) ([]T, error) {
+	parse := parseTable
+	if format == "json" && isJSONOutput(output) {
+		parse = parseJSON
+	} else {
+		format = "table"
+	}
+	items, err := parse(output)
+	if err != nil {
+		return nil, &OutputParseError{Command: command, Format: format, Version: version, Err: err}
+	}
+	return items, nil
+}
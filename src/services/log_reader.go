@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// maxLogLineBytes bounds a single command log line; long build output can exceed bufio's default.
+const maxLogLineBytes = 4 * 1024 * 1024
+
+// ReadLogEntries reads every segment of the command log at path, newest entry first. A
+// missing log yields no entries; lines that are not valid entries are skipped.
+func ReadLogEntries(path string) ([]LogEntry, error) {
+	return QueryLogEntries(path, LogQuery{})
+}
+
+// ParsedCommand returns the logged command. Entries written before the executable and
+// arguments were recorded are parsed back from the preview string.
+func (e LogEntry) ParsedCommand() (models.Command, error) {
+	if e.Executable != "" {
+		return models.Command{Executable: e.Executable, Args: append([]string(nil), e.Args...)}, nil
+	}
+	parts, err := splitCommandLine(e.Command)
+	if err != nil {
+		return models.Command{}, fmt.Errorf("cannot parse logged command %q: %w", e.Command, err)
+	}
+	if len(parts) == 0 {
+		return models.Command{}, errors.New("log entry has no command")
+	}
+	return models.Command{Executable: parts[0], Args: parts[1:]}, nil
+}
+
+// splitCommandLine reverses models.Command.String: words are space separated and words
+// containing spaces or quotes are Go-quoted.
+func splitCommandLine(line string) ([]string, error) {
+	var parts []string
+	rest := strings.TrimSpace(line)
+	for rest != "" {
+		if rest[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, err
+			}
+			value, _ := strconv.Unquote(quoted)
+			parts = append(parts, value)
+			rest = strings.TrimSpace(rest[len(quoted):])
+			continue
+		}
+		end := strings.IndexByte(rest, ' ')
+		if end < 0 {
+			end = len(rest)
+		}
+		parts = append(parts, rest[:end])
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return parts, nil
+}
+
+// CommandLogPath returns the command log written by executor or any executor it wraps.
+func CommandLogPath(executor CommandExecutor) (string, bool) {
+	for executor != nil {
+		if logging, ok := executor.(*LoggingExecutor); ok {
+			path := logging.LogPath()
+			return path, path != ""
+		}
+		wrapper, ok := executor.(WrappingExecutor)
+		if !ok {
+			return "", false
+		}
+		executor = wrapper.Unwrap()
+	}
+	return "", false
+}
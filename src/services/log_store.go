@@ -0,0 +1,453 @@
+package services
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The command log is a set of JSON lines segments next to the log path. New entries are
+// appended to the active segment (the log path itself); when it spans a day boundary or
+// grows past the segment size it is renamed to <stem>-YYYYMMDD-NNN<ext>, optionally
+// gzipped. A small index keeps each segment's time range and status counts so queries can
+// skip segments without reading them, and an advisory lock file serializes writers across
+// processes.
+
+// LogSegment describes one command log segment.
+type LogSegment struct {
+	Name       string         `json:"name"`
+	Compressed bool           `json:"compressed,omitempty"`
+	First      time.Time      `json:"first"`
+	Last       time.Time      `json:"last"`
+	Entries    int            `json:"entries"`
+	Bytes      int64          `json:"bytes"`
+	Statuses   map[string]int `json:"statuses,omitempty"`
+	// LastSequence and LastHash identify the segment's newest audited entry.
+	LastSequence uint64 `json:"last_seq,omitempty"`
+	LastHash     string `json:"last_hash,omitempty"`
+}
+
+// add records entry in the segment's time range and status counts.
+func (s *LogSegment) add(entry LogEntry) {
+	s.Entries++
+	if !entry.StartTime.IsZero() {
+		if s.First.IsZero() || entry.StartTime.Before(s.First) {
+			s.First = entry.StartTime
+		}
+		if entry.StartTime.After(s.Last) {
+			s.Last = entry.StartTime
+		}
+	}
+	if s.Statuses == nil {
+		s.Statuses = map[string]int{}
+	}
+	s.Statuses[entry.Status]++
+	if entry.Hash != "" {
+		s.LastSequence = entry.Sequence
+		s.LastHash = entry.Hash
+	}
+}
+
+// logIndex lists archived segments oldest first, plus the active segment. Head is the
+// newest audited entry ever written, which survives retention and truncation.
+type logIndex struct {
+	Active   LogSegment   `json:"active"`
+	Segments []LogSegment `json:"segments"`
+	Head     *auditHead   `json:"audit_head,omitempty"`
+}
+
+// auditHead is the position of the newest entry in the audit hash chain.
+type auditHead struct {
+	Sequence uint64 `json:"seq"`
+	Hash     string `json:"hash"`
+}
+
+// head returns the end of the audit chain, falling back to the segments when the index
+// was rebuilt.
+func (i logIndex) head() auditHead {
+	if i.Head != nil {
+		return *i.Head
+	}
+	if i.Active.LastHash != "" {
+		return auditHead{Sequence: i.Active.LastSequence, Hash: i.Active.LastHash}
+	}
+	for position := len(i.Segments) - 1; position >= 0; position-- {
+		if segment := i.Segments[position]; segment.LastHash != "" {
+			return auditHead{Sequence: segment.LastSequence, Hash: segment.LastHash}
+		}
+	}
+	return auditHead{}
+}
+
+// LogLimits bounds command log segments. Zero values disable the limit.
+type LogLimits struct {
+	// MaxSegmentBytes starts a new segment once the active one reaches this size.
+	MaxSegmentBytes int64
+	// MaxTotalBytes deletes the oldest segments once the log exceeds this size.
+	MaxTotalBytes int64
+	// Compress gzips segments once they are rolled over.
+	Compress bool
+}
+
+// LogQuery selects command log entries. Zero fields match everything.
+type LogQuery struct {
+	Since  time.Time
+	Until  time.Time
+	Status string
+	// Limit caps the number of entries returned, newest first.
+	Limit int
+}
+
+func (q LogQuery) matches(entry LogEntry) bool {
+	if !q.Since.IsZero() && entry.StartTime.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && entry.StartTime.After(q.Until) {
+		return false
+	}
+	return q.Status == "" || entry.Status == q.Status
+}
+
+// overlaps reports whether segment may hold entries matching the query.
+func (q LogQuery) overlaps(segment LogSegment) bool {
+	if segment.Entries == 0 {
+		return false
+	}
+	if !q.Since.IsZero() && !segment.Last.IsZero() && segment.Last.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !segment.First.IsZero() && segment.First.After(q.Until) {
+		return false
+	}
+	return q.Status == "" || segment.Statuses[q.Status] > 0
+}
+
+// QueryLogEntries returns entries of the command log at path that match query, newest
+// first. A missing log yields no entries; lines that are not valid entries are skipped.
+func QueryLogEntries(path string, query LogQuery) ([]LogEntry, error) {
+	files := newLogFiles(path)
+	entries := make([]LogEntry, 0)
+	err := files.withLock(false, func() error {
+		index, _, err := files.loadIndex()
+		if err != nil {
+			return err
+		}
+		segments := append(index.Segments, index.Active)
+		for position := len(segments) - 1; position >= 0; position-- {
+			segment := segments[position]
+			if !query.overlaps(segment) {
+				continue
+			}
+			matched := make([]LogEntry, 0, segment.Entries)
+			err := forEachLogEntry(files.segmentPath(segment), segment.Compressed, func(entry LogEntry) {
+				if query.matches(entry) {
+					matched = append(matched, entry)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			for next := len(matched) - 1; next >= 0; next-- {
+				entries = append(entries, matched[next])
+				if query.Limit > 0 && len(entries) >= query.Limit {
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// logFiles names the files that make up the command log at path.
+type logFiles struct {
+	path string
+	dir  string
+	stem string
+	ext  string
+}
+
+func newLogFiles(path string) logFiles {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	return logFiles{path: path, dir: filepath.Dir(path), stem: strings.TrimSuffix(base, ext), ext: ext}
+}
+
+func (f logFiles) indexPath() string {
+	return filepath.Join(f.dir, f.stem+".index.json")
+}
+
+func (f logFiles) sealsPath() string {
+	return filepath.Join(f.dir, f.stem+".seals")
+}
+
+func (f logFiles) lockPath() string {
+	return f.path + ".lock"
+}
+
+func (f logFiles) segmentPath(segment LogSegment) string {
+	if segment.Name == filepath.Base(f.path) {
+		return f.path
+	}
+	return filepath.Join(f.dir, segment.Name)
+}
+
+// withLock runs fn holding the log's lock file. Readers that cannot create the lock file,
+// for example because the log directory does not exist yet, run without it.
+func (f logFiles) withLock(exclusive bool, fn func() error) error {
+	if exclusive {
+		if err := os.MkdirAll(f.dir, 0o755); err != nil {
+			return err
+		}
+	}
+	lock, err := os.OpenFile(f.lockPath(), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		if !exclusive {
+			return fn()
+		}
+		return err
+	}
+	defer func() {
+		_ = lock.Close()
+	}()
+	if err := lockFile(lock, exclusive); err != nil {
+		return err
+	}
+	defer func() {
+		_ = unlockFile(lock)
+	}()
+	return fn()
+}
+
+// listSegments returns archived segment names in chronological order. A plain segment
+// left next to its compressed copy by an interrupted compression is ignored.
+func (f logFiles) listSegments() ([]string, error) {
+	plain, err := filepath.Glob(filepath.Join(f.dir, f.stem+"-*"+f.ext))
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := filepath.Glob(filepath.Join(f.dir, f.stem+"-*"+f.ext+".gz"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(plain)+len(compressed))
+	seen := map[string]bool{}
+	for _, path := range compressed {
+		names = append(names, filepath.Base(path))
+		seen[strings.TrimSuffix(filepath.Base(path), ".gz")] = true
+	}
+	for _, path := range plain {
+		if !seen[filepath.Base(path)] {
+			names = append(names, filepath.Base(path))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// loadIndex reads the index and brings it up to date with the segments on disk, scanning
+// only segments the index does not describe. changed reports whether anything was rescanned.
+func (f logFiles) loadIndex() (logIndex, bool, error) {
+	var stored logIndex
+	if data, err := os.ReadFile(f.indexPath()); err == nil {
+		// A corrupt index is rebuilt from the segments.
+		_ = json.Unmarshal(data, &stored)
+	}
+	known := make(map[string]LogSegment, len(stored.Segments))
+	for _, segment := range stored.Segments {
+		known[segment.Name] = segment
+	}
+
+	names, err := f.listSegments()
+	if err != nil {
+		return logIndex{}, false, err
+	}
+	changed := len(names) != len(stored.Segments)
+	index := logIndex{Segments: make([]LogSegment, 0, len(names)), Head: stored.Head}
+	for _, name := range names {
+		segment, ok := known[name]
+		if !ok {
+			segment, err = scanLogSegment(filepath.Join(f.dir, name), strings.HasSuffix(name, ".gz"))
+			if err != nil {
+				return logIndex{}, false, err
+			}
+			segment.Name = name
+			changed = true
+		}
+		index.Segments = append(index.Segments, segment)
+	}
+
+	var size int64
+	info, err := os.Stat(f.path)
+	if err == nil {
+		size = info.Size()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return logIndex{}, false, err
+	}
+	active := stored.Active
+	if active.Name != filepath.Base(f.path) || active.Bytes != size {
+		// Written by another tool or an older actui; rescan the active segment.
+		active, err = scanLogSegment(f.path, false)
+		if err != nil {
+			return logIndex{}, false, err
+		}
+		changed = true
+	}
+	active.Name = filepath.Base(f.path)
+	index.Active = active
+	return index, changed, nil
+}
+
+// saveIndex replaces the index file atomically.
+func (f logFiles) saveIndex(index logIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	tempPath := f.indexPath() + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, f.indexPath())
+}
+
+// nextSegmentName returns an unused archive name for a segment starting on day.
+func (f logFiles) nextSegmentName(day time.Time, segments []LogSegment) string {
+	prefix := f.stem + "-" + day.Local().Format("20060102") + "-"
+	sequence := 1
+	for _, segment := range segments {
+		rest, ok := strings.CutPrefix(segment.Name, prefix)
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSuffix(rest, ".gz"), f.ext))
+		if err == nil && number >= sequence {
+			sequence = number + 1
+		}
+	}
+	return fmt.Sprintf("%s%03d%s", prefix, sequence, f.ext)
+}
+
+// scanLogSegment rebuilds the index record of the segment at path.
+func scanLogSegment(path string, compressed bool) (LogSegment, error) {
+	segment := LogSegment{}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return segment, nil
+	}
+	if err != nil {
+		return segment, err
+	}
+	segment.Bytes = info.Size()
+	segment.Compressed = compressed
+	err = forEachLogEntry(path, compressed, segment.add)
+	return segment, err
+}
+
+// forEachLogEntry calls fn for every valid entry of the segment at path, oldest first.
+func forEachLogEntry(path string, compressed bool, fn func(LogEntry)) error {
+	return forEachLogLine(path, compressed, func(line []byte) {
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err != nil || strings.TrimSpace(entry.Command) == "" {
+			return
+		}
+		fn(entry)
+	})
+}
+
+// forEachLogLine calls fn for every line of the segment at path. A missing segment has
+// no lines.
+func forEachLogLine(path string, compressed bool, fn func([]byte)) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var reader io.Reader = file
+	if compressed {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+		}
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+		reader = gzipReader
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineBytes)
+	for scanner.Scan() {
+		fn(scanner.Bytes())
+	}
+	return scanner.Err()
+}
+
+// compressSegment gzips the segment at path, removes the original and returns the size
+// of the compressed file.
+func compressSegment(path string) (int64, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	tempPath := path + ".gz.tmp"
+	out, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	writer := gzip.NewWriter(out)
+	if _, err := io.Copy(writer, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tempPath)
+		return 0, err
+	}
+	if err := writer.Close(); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tempPath)
+		return 0, err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return 0, err
+	}
+	if err := os.Rename(tempPath, path+".gz"); err != nil {
+		return 0, err
+	}
+	if err := os.Remove(path); err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path + ".gz")
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func removeSegment(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -1,11 +1,11 @@
 package services
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"container-tui/src/models"
@@ -14,6 +14,8 @@ import (
 // LogEntry represents a command execution entry.
 type LogEntry struct {
 	Command    string    `json:"command"`
+	Executable string    `json:"executable,omitempty"`
+	Args       []string  `json:"args,omitempty"`
 	DryRun     bool      `json:"dry_run"`
 	ExitCode   int       `json:"exit_code"`
 	Stdout     string    `json:"stdout,omitempty"`
@@ -21,99 +23,217 @@ type LogEntry struct {
 	StartTime  time.Time `json:"start_time"`
 	DurationMs int64     `json:"duration_ms"`
 	Status     string    `json:"status"`
+	Profile    string    `json:"profile,omitempty"`
+	Sequence   uint64    `json:"seq,omitempty"`
+	Host       string    `json:"host,omitempty"`
+	User       string    `json:"user,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	PrevHash   string    `json:"prev_hash,omitempty"`
+	Hash       string    `json:"hash,omitempty"`
 }
 
-// LogWriter appends command log entries to a JSON lines file.
+// LogWriter appends command log entries to a segmented JSON lines log.
 type LogWriter struct {
-	path          string
+	mu            sync.Mutex
+	files         logFiles
 	retentionDays int
+	limits        LogLimits
+	redactor      *Redactor
+	audit         *AuditMetadata
+	now           func() time.Time
+}
+
+// DefaultLogPath returns the path of the active command log segment in the state directory.
+func DefaultLogPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "command.log"), nil
 }
 
 // NewLogWriter creates a log writer for the default log path.
 func NewLogWriter(retentionDays int) (*LogWriter, error) {
-	home, err := os.UserHomeDir()
+	logPath, err := DefaultLogPath()
 	if err != nil {
 		return nil, err
 	}
-	logPath := filepath.Join(home, "Library", "Application Support", "actui", "command.log")
-	return &LogWriter{path: logPath, retentionDays: retentionDays}, nil
+	return NewLogWriterAt(logPath, retentionDays), nil
+}
+
+// NewLogWriterAt creates a log writer whose active segment is the file at path. Segments
+// older than retentionDays are deleted; zero keeps them all.
+func NewLogWriterAt(path string, retentionDays int) *LogWriter {
+	return &LogWriter{files: newLogFiles(path), retentionDays: retentionDays, redactor: DefaultRedactor(), now: time.Now}
+}
+
+// SetRedactor replaces the redactor applied to every entry before it is written.
+func (w *LogWriter) SetRedactor(redactor *Redactor) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.redactor = redactor
+}
+
+// SetLimits sets the segment size, total size and compression limits.
+func (w *LogWriter) SetLimits(limits LogLimits) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.limits = limits
+}
+
+// SetRetention sets how many days segments are kept; zero keeps them all.
+func (w *LogWriter) SetRetention(days int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.retentionDays = days
+}
+
+// EnableAudit chains every written entry to the previous one by hash, stamps it with
+// metadata and seals each segment as it is rolled over.
+func (w *LogWriter) EnableAudit(metadata AuditMetadata) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.audit = &metadata
 }
 
-// Write appends a log entry to the log file.
+// Path returns the path of the active log segment.
+func (w *LogWriter) Path() string {
+	return w.files.path
+}
+
+// Write appends a log entry to the active segment, rolling it over first when it is due.
 func (w *LogWriter) Write(entry LogEntry) error {
 	if w == nil {
 		return errors.New("log writer is nil")
 	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	entry = w.redactor.RedactEntry(entry)
 
-	if err := w.rotateIfNeeded(); err != nil {
-		return err
-	}
-
-	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
-		return err
-	}
-	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
+	return w.files.withLock(true, func() error {
+		index, _, err := w.files.loadIndex()
+		if err != nil {
+			return err
+		}
+		if err := w.maintain(&index); err != nil {
+			return err
+		}
+		if w.audit != nil {
+			entry = w.audit.chain(entry, index.head())
+			index.Head = &auditHead{Sequence: entry.Sequence, Hash: entry.Hash}
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
 
-	encoder := json.NewEncoder(file)
-	return encoder.Encode(entry)
+		file, err := os.OpenFile(w.files.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(line); err != nil {
+			_ = file.Close()
+			return err
+		}
+		info, err := file.Stat()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		index.Active.add(entry)
+		index.Active.Bytes = info.Size()
+		if w.audit != nil {
+			if err := w.files.sealHead(index.Active, w.now()); err != nil {
+				return err
+			}
+		}
+		return w.files.saveIndex(index)
+	})
 }
 
+// rotateIfNeeded rolls over, compresses and expires segments without writing an entry.
 func (w *LogWriter) rotateIfNeeded() error {
-	if w.retentionDays <= 0 {
-		return nil
-	}
-	if _, err := os.Stat(w.path); err != nil {
-		return nil
-	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.files.withLock(true, func() error {
+		index, _, err := w.files.loadIndex()
+		if err != nil {
+			return err
+		}
+		if err := w.maintain(&index); err != nil {
+			return err
+		}
+		return w.files.saveIndex(index)
+	})
+}
 
-	cutoff := time.Now().AddDate(0, 0, -w.retentionDays)
-	file, err := os.Open(w.path)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	kept := make([]LogEntry, 0)
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		var entry LogEntry
-		if err := json.Unmarshal(line, &entry); err != nil {
-			continue
+// maintain rolls the active segment over when it started on an earlier day or reached the
+// segment size, compresses archived segments and applies the age and size retention. The
+// caller holds the exclusive lock.
+func (w *LogWriter) maintain(index *logIndex) error {
+	now := w.now()
+	active := index.Active
+	if active.Entries > 0 {
+		started := active.First
+		if started.IsZero() {
+			started = now
 		}
-		if entry.StartTime.IsZero() || entry.StartTime.After(cutoff) {
-			kept = append(kept, entry)
+		full := w.limits.MaxSegmentBytes > 0 && active.Bytes >= w.limits.MaxSegmentBytes
+		if full || started.Local().Format(time.DateOnly) != now.Local().Format(time.DateOnly) {
+			active.Name = w.files.nextSegmentName(started, index.Segments)
+			if err := os.Rename(w.files.path, filepath.Join(w.files.dir, active.Name)); err != nil {
+				return err
+			}
+			if w.audit != nil {
+				if err := w.files.sealSegment(active.Name, now); err != nil {
+					return err
+				}
+			}
+			index.Segments = append(index.Segments, active)
+			index.Active = LogSegment{Name: filepath.Base(w.files.path)}
 		}
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+
+	if w.limits.Compress {
+		for position, segment := range index.Segments {
+			if segment.Compressed {
+				continue
+			}
+			size, err := compressSegment(filepath.Join(w.files.dir, segment.Name))
+			if err != nil {
+				return err
+			}
+			index.Segments[position].Name = segment.Name + ".gz"
+			index.Segments[position].Compressed = true
+			index.Segments[position].Bytes = size
+		}
 	}
 
-	tempPath := w.path + ".tmp"
-	out, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
-	if err != nil {
-		return err
+	kept := index.Segments[:0]
+	cutoff := now.AddDate(0, 0, -w.retentionDays)
+	total := index.Active.Bytes
+	for _, segment := range index.Segments {
+		if w.retentionDays > 0 && !segment.Last.IsZero() && segment.Last.Before(cutoff) {
+			if err := removeSegment(filepath.Join(w.files.dir, segment.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, segment)
+		total += segment.Bytes
 	}
-	encoder := json.NewEncoder(out)
-	for _, entry := range kept {
-		if err := encoder.Encode(entry); err != nil {
-			_ = out.Close()
+	for w.limits.MaxTotalBytes > 0 && total > w.limits.MaxTotalBytes && len(kept) > 0 {
+		if err := removeSegment(filepath.Join(w.files.dir, kept[0].Name)); err != nil {
 			return err
 		}
+		total -= kept[0].Bytes
+		kept = kept[1:]
 	}
-	if err := out.Close(); err != nil {
-		return err
-	}
-
-	return os.Rename(tempPath, w.path)
+	index.Segments = kept
+	return nil
 }
 
 // BuildLogEntry constructs a log entry from a command result.
@@ -121,6 +241,8 @@ func BuildLogEntry(command models.Command, result models.Result, dryRun bool) Lo
 	startTime := time.Now().Add(-result.Duration)
 	return LogEntry{
 		Command:    command.String(),
+		Executable: command.Executable,
+		Args:       command.Args,
 		DryRun:     dryRun,
 		ExitCode:   result.ExitCode,
 		Stdout:     result.Stdout,
@@ -1,12 +1,17 @@
 package services
 
-import "container-tui/src/models"
+import (
+	"context"
+
+	"container-tui/src/models"
+)
 
 // LoggingExecutor wraps an executor with log writing.
 type LoggingExecutor struct {
 	delegate CommandExecutor
 	writer   *LogWriter
 	dryRun   bool
+	profiles *ProfileSet
 }
 
 // NewLoggingExecutor builds a logging executor.
@@ -14,11 +19,63 @@ func NewLoggingExecutor(delegate CommandExecutor, writer *LogWriter, dryRun bool
 	return &LoggingExecutor{delegate: delegate, writer: writer, dryRun: dryRun}
 }
 
+// SetProfiles records the active profile of profiles in every entry.
+func (l *LoggingExecutor) SetProfiles(profiles *ProfileSet) {
+	l.profiles = profiles
+}
+
+func (l *LoggingExecutor) entry(cmd models.Command, result models.Result) LogEntry {
+	entry := BuildLogEntry(cmd, result, l.dryRun)
+	if l.profiles != nil {
+		entry.Profile = l.profiles.Active().Name
+	}
+	return entry
+}
+
 // Execute runs the command and writes a log entry.
 func (l *LoggingExecutor) Execute(cmd models.Command) (models.Result, error) {
-	result, err := l.delegate.Execute(cmd)
+	return l.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command under ctx and writes a log entry, including canceled runs.
+func (l *LoggingExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	result, err := ExecuteContext(ctx, l.delegate, cmd)
+	if l.writer != nil {
+		_ = l.writer.Write(l.entry(cmd, result))
+	}
+	return result, err
+}
+
+// Stream runs the command under ctx and logs a bounded tail of its output.
+func (l *LoggingExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	stdoutTail := NewLineTail(DefaultStreamTailLines)
+	stderrTail := NewLineTail(DefaultStreamTailLines)
+	result, err := StreamContext(ctx, l.delegate, cmd, func(line StreamLine) {
+		if line.Source == StreamStderr {
+			stderrTail.Add(line.Text)
+		} else {
+			stdoutTail.Add(line.Text)
+		}
+		onLine(line)
+	})
 	if l.writer != nil {
-		_ = l.writer.Write(BuildLogEntry(cmd, result, l.dryRun))
+		logged := result
+		logged.Stdout = stdoutTail.String()
+		logged.Stderr = stderrTail.String()
+		_ = l.writer.Write(l.entry(cmd, logged))
 	}
 	return result, err
 }
+
+// LogPath returns the path of the command log, or "" when logging is disabled.
+func (l *LoggingExecutor) LogPath() string {
+	if l.writer == nil {
+		return ""
+	}
+	return l.writer.Path()
+}
+
+// Unwrap returns the wrapped executor.
+func (l *LoggingExecutor) Unwrap() CommandExecutor {
+	return l.delegate
+}
@@ -19,3 +19,8 @@ func (b MachineDeleteBuilder) Build() (models.Command, error) {
 	machineID, _ := normalizeRequiredToken(b.MachineID, "machine id")
 	return models.Command{Executable: "container", Args: []string{"machine", "delete", machineID}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (MachineDeleteBuilder) GuardedAction() DestructiveAction {
+	return ActionDeleteMachine
+}
@@ -19,3 +19,8 @@ func (b MachineStopBuilder) Build() (models.Command, error) {
 	machineID, _ := normalizeRequiredToken(b.MachineID, "machine id")
 	return models.Command{Executable: "container", Args: []string{"machine", "stop", machineID}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (MachineStopBuilder) GuardedAction() DestructiveAction {
+	return ActionStopMachine
+}
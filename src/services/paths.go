@@ -0,0 +1,85 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appName names actui's config and state directories.
+const appName = "actui"
+
+// ConfigDir returns the directory searched first for the config file:
+// $XDG_CONFIG_HOME/actui, or ~/.config/actui when XDG_CONFIG_HOME is unset.
+func ConfigDir() (string, error) {
+	if dir := xdgDir("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", appName), nil
+}
+
+// StateDir returns the directory holding the command log and other state:
+// $XDG_STATE_HOME/actui, or ~/Library/Application Support/actui when XDG_STATE_HOME is unset.
+func StateDir() (string, error) {
+	if dir := xdgDir("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	return macSupportDir()
+}
+
+func macSupportDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Application Support", appName), nil
+}
+
+// xdgDir returns the XDG base directory in name. The spec says relative paths are invalid
+// and must be ignored.
+func xdgDir(name string) string {
+	dir := os.Getenv(name)
+	if dir == "" || !filepath.IsAbs(dir) {
+		return ""
+	}
+	return dir
+}
+
+// writeFileAtomic replaces path with data so readers see either the old or the new file,
+// never a partial one.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	fail := func(err error) error {
+		_ = temp.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if _, err := temp.Write(data); err != nil {
+		return fail(err)
+	}
+	if err := temp.Chmod(perm); err != nil {
+		return fail(err)
+	}
+	if err := temp.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := temp.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"io"
+
+	"container-tui/src/models"
+)
+
+// PolicyExecutor refuses commands blocked by an ExecutionPolicy before they reach the delegate.
+type PolicyExecutor struct {
+	delegate CommandExecutor
+	policy   ExecutionPolicy
+}
+
+// NewPolicyExecutor builds a policy executor.
+func NewPolicyExecutor(delegate CommandExecutor, policy ExecutionPolicy) *PolicyExecutor {
+	return &PolicyExecutor{delegate: delegate, policy: policy}
+}
+
+// Execute runs the command if the policy allows it.
+func (p *PolicyExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return p.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command if the policy allows it.
+func (p *PolicyExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	if err := p.policy.Check(cmd); err != nil {
+		return blockedResult(err)
+	}
+	return ExecuteContext(ctx, p.delegate, cmd)
+}
+
+// Stream streams the command if the policy allows it.
+func (p *PolicyExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	if err := p.policy.Check(cmd); err != nil {
+		return blockedResult(err)
+	}
+	return StreamContext(ctx, p.delegate, cmd, onLine)
+}
+
+// Interactive prepares an interactive session if the policy allows it.
+func (p *PolicyExecutor) Interactive(cmd models.Command) InteractiveProcess {
+	if err := p.policy.Check(cmd); err != nil {
+		return blockedProcess{err: err}
+	}
+	return InteractiveCommand(p.delegate, cmd)
+}
+
+// Policy returns the enforced policy.
+func (p *PolicyExecutor) Policy() ExecutionPolicy {
+	return p.policy
+}
+
+// Unwrap returns the wrapped executor.
+func (p *PolicyExecutor) Unwrap() CommandExecutor {
+	return p.delegate
+}
+
+// CheckPolicy reports whether cmd would be blocked by any policy executor in the chain.
+func CheckPolicy(executor CommandExecutor, cmd models.Command) error {
+	for executor != nil {
+		if policyExecutor, ok := executor.(*PolicyExecutor); ok {
+			if err := policyExecutor.policy.Check(cmd); err != nil {
+				return err
+			}
+		}
+		wrapper, ok := executor.(WrappingExecutor)
+		if !ok {
+			return nil
+		}
+		executor = wrapper.Unwrap()
+	}
+	return nil
+}
+
+func blockedResult(err error) (models.Result, error) {
+	return models.Result{ExitCode: -1, Stderr: err.Error() + "\n", Status: models.ResultError}, err
+}
+
+// blockedProcess fails immediately with the policy error.
+type blockedProcess struct {
+	err error
+}
+
+func (p blockedProcess) Run() error        { return p.err }
+func (blockedProcess) SetStdin(io.Reader)  {}
+func (blockedProcess) SetStdout(io.Writer) {}
+func (blockedProcess) SetStderr(io.Writer) {}
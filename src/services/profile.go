@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"container-tui/src/models"
+)
+
+// LocalProfileName names the built-in profile that runs `container` from PATH. A
+// [profiles.local] table replaces it.
+const LocalProfileName = "local"
+
+// Profile is a connection profile with its name.
+type Profile struct {
+	Name string
+	models.ConnectionProfile
+}
+
+// binary returns the CLI to run, defaulting to `container`.
+func (p Profile) binary() string {
+	if p.Binary == "" {
+		return "container"
+	}
+	return p.Binary
+}
+
+// Apply rewrites a `container` command for the profile: the profile's binary and global
+// arguments replace the executable and its environment is added. With a Host and OpenSSH
+// the command is wrapped in the system ssh client; other hosts are reached by the
+// ProfileExecutor's SSH connection. Commands for other executables are returned unchanged.
+func (p Profile) Apply(cmd models.Command) models.Command {
+	return p.apply(cmd, false)
+}
+
+func (p Profile) apply(cmd models.Command, interactive bool) models.Command {
+	if cmd.Executable != "container" {
+		return cmd
+	}
+	args := append(append([]string{}, p.Args...), cmd.Args...)
+	applied := models.Command{Executable: p.binary(), Args: args, Env: append(append([]string{}, cmd.Env...), p.Env...)}
+	if p.Host == "" || !p.OpenSSH {
+		return applied
+	}
+	sshArgs := []string{}
+	if interactive {
+		sshArgs = append(sshArgs, "-t")
+	}
+	sshArgs = append(sshArgs, p.Host, "--", shellCommand(applied))
+	return models.Command{Executable: "ssh", Args: sshArgs}
+}
+
+// remote reports whether the profile's commands run over the built-in SSH client.
+func (p Profile) remote() bool {
+	return p.Host != "" && !p.OpenSSH
+}
+
+// Describe summarizes where the profile runs commands.
+func (p Profile) Describe() string {
+	description := strings.Join(append([]string{p.binary()}, p.Args...), " ")
+	if p.Host != "" {
+		description += " on " + p.Host
+	}
+	return description
+}
+
+// shellCommand joins cmd into one line for a remote shell, quoting each word and setting
+// its environment with env.
+func shellCommand(cmd models.Command) string {
+	words := []string{}
+	if len(cmd.Env) > 0 {
+		words = append(append(words, "env"), cmd.Env...)
+	}
+	words = append(append(words, cmd.Executable), cmd.Args...)
+	for index, word := range words {
+		words[index] = shellQuote(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func shellQuote(word string) string {
+	if word != "" && strings.IndexFunc(word, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@,+%", r))
+	}) < 0 {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+// ProfileSet holds the configured profiles and the active one. It is shared by the
+// ProfileExecutor that applies the active profile, the LoggingExecutor that records it and
+// the UI that switches it.
+type ProfileSet struct {
+	mu       sync.RWMutex
+	profiles []Profile
+	active   int
+}
+
+// NewProfileSet builds the profiles in config, plus the built-in local profile, and makes
+// name active. An empty name selects config.Profile, then the local profile.
+func NewProfileSet(config models.UserConfig, name string) (*ProfileSet, error) {
+	set := &ProfileSet{}
+	if _, ok := config.Profiles[LocalProfileName]; !ok {
+		set.profiles = append(set.profiles, Profile{Name: LocalProfileName})
+	}
+	names := make([]string, 0, len(config.Profiles))
+	for profileName := range config.Profiles {
+		names = append(names, profileName)
+	}
+	sort.Strings(names)
+	for _, profileName := range names {
+		profile := Profile{Name: profileName, ConnectionProfile: config.Profiles[profileName]}
+		for _, variable := range profile.Env {
+			if key, _, ok := strings.Cut(variable, "="); !ok || key == "" {
+				return nil, fmt.Errorf("profile %q: env entry %q is not KEY=VALUE", profileName, variable)
+			}
+		}
+		set.profiles = append(set.profiles, profile)
+	}
+
+	if name == "" {
+		name = config.Profile
+	}
+	if name == "" {
+		name = LocalProfileName
+	}
+	if _, err := set.Use(name); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Profiles returns every profile, the local profile first unless it was replaced.
+func (s *ProfileSet) Profiles() []Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Profile(nil), s.profiles...)
+}
+
+// Active returns the active profile.
+func (s *ProfileSet) Active() Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profiles[s.active]
+}
+
+// Use makes the profile called name active for commands started from now on.
+func (s *ProfileSet) Use(name string) (Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for index, profile := range s.profiles {
+		if profile.Name == name {
+			s.active = index
+			return profile, nil
+		}
+	}
+	names := make([]string, len(s.profiles))
+	for index, profile := range s.profiles {
+		names[index] = profile.Name
+	}
+	return Profile{}, fmt.Errorf("unknown profile %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// ProfileExecutor runs `container` commands through the active profile of a ProfileSet.
+// Profiles with a remote host share one SSHExecutor, and so one connection, per profile.
+type ProfileExecutor struct {
+	delegate CommandExecutor
+	profiles *ProfileSet
+	mu       sync.Mutex
+	remotes  map[string]*SSHExecutor
+}
+
+// NewProfileExecutor builds a profile executor.
+func NewProfileExecutor(delegate CommandExecutor, profiles *ProfileSet) *ProfileExecutor {
+	return &ProfileExecutor{delegate: delegate, profiles: profiles, remotes: map[string]*SSHExecutor{}}
+}
+
+// Execute runs the command through the active profile.
+func (p *ProfileExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return p.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command through the active profile under ctx.
+func (p *ProfileExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	executor, applied, err := p.route(cmd, false)
+	if err != nil {
+		return models.Result{ExitCode: -1, Stderr: err.Error() + "\n", Status: models.ResultError}, err
+	}
+	return ExecuteContext(ctx, executor, applied)
+}
+
+// Stream runs the command through the active profile and reports its output lines.
+func (p *ProfileExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	executor, applied, err := p.route(cmd, false)
+	if err != nil {
+		return models.Result{ExitCode: -1, Stderr: err.Error() + "\n", Status: models.ResultError}, err
+	}
+	return StreamContext(ctx, executor, applied, onLine)
+}
+
+// Interactive prepares an interactive session through the active profile; remote
+// sessions get a terminal.
+func (p *ProfileExecutor) Interactive(cmd models.Command) InteractiveProcess {
+	executor, applied, err := p.route(cmd, true)
+	if err != nil {
+		return failedProcess{err: err}
+	}
+	return InteractiveCommand(executor, applied)
+}
+
+// CheckCLI verifies the CLI of the active profile, connecting to its host when it has one.
+func (p *ProfileExecutor) CheckCLI(ctx context.Context) error {
+	profile := p.profiles.Active()
+	if !profile.remote() {
+		return CheckCLI(ctx, profile)
+	}
+	result, err := p.ExecuteContext(ctx, models.Command{Executable: "container", Args: []string{"system", "version"}})
+	if err != nil {
+		message := strings.TrimSpace(result.Stderr)
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("failed to verify Apple Container CLI on %s: %s", profile.Host, message)
+	}
+	return nil
+}
+
+// Close closes the connections to remote hosts.
+func (p *ProfileExecutor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for name, remote := range p.remotes {
+		errs = append(errs, remote.Close())
+		delete(p.remotes, name)
+	}
+	return errors.Join(errs...)
+}
+
+// route returns the executor for cmd under the active profile and the command to give it.
+func (p *ProfileExecutor) route(cmd models.Command, interactive bool) (CommandExecutor, models.Command, error) {
+	profile := p.profiles.Active()
+	applied := profile.apply(cmd, interactive)
+	if cmd.Executable != "container" || !profile.remote() {
+		return p.delegate, applied, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if remote, ok := p.remotes[profile.Name]; ok {
+		return remote, applied, nil
+	}
+	config, err := NewSSHConfig(profile)
+	if err != nil {
+		return nil, applied, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
+	remote := NewSSHExecutor(config)
+	p.remotes[profile.Name] = remote
+	return remote, applied, nil
+}
+
+// Unwrap returns the wrapped executor.
+func (p *ProfileExecutor) Unwrap() CommandExecutor {
+	return p.delegate
+}
+
+// failedProcess is an interactive session that could not be started.
+type failedProcess struct {
+	err error
+}
+
+func (p failedProcess) Run() error          { return p.err }
+func (p failedProcess) SetStdin(io.Reader)  {}
+func (p failedProcess) SetStdout(io.Writer) {}
+func (p failedProcess) SetStderr(io.Writer) {}
+
+// commandEnv returns the environment for cmd, or nil to inherit the current one.
+func commandEnv(cmd models.Command) []string {
+	if len(cmd.Env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), cmd.Env...)
+}
+
+// newProcess builds the exec.Cmd for cmd under ctx.
+func newProcess(ctx context.Context, cmd models.Command) *exec.Cmd {
+	process := exec.CommandContext(ctx, cmd.Executable, cmd.Args...)
+	process.Env = commandEnv(cmd)
+	return process
+}
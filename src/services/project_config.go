@@ -0,0 +1,269 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"container-tui/src/models"
+)
+
+// ProjectConfigName is the per-project config file searched for in the working directory
+// and its parents.
+const ProjectConfigName = ".actui.toml"
+
+// projectConfigKeys are the keys a project config may set. Everything else, such as the
+// confirmation and policy settings, stays under the user's control.
+var projectConfigKeys = map[string]bool{
+	"default_build_file":  true,
+	"default_tag_pattern": true,
+	"build_context":       true,
+	"pull_latest":         true,
+	"build_presets":       true,
+	"run_presets":         true,
+}
+
+// FindProjectConfig returns the ProjectConfigName file in dir or the nearest parent that
+// has one, or "" when there is none.
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// UseProject merges the project config found from dir over the user config in Load. It
+// returns the project config path, or "" when there is none.
+func (m *ConfigManager) UseProject(dir string) string {
+	m.ProjectPath = FindProjectConfig(dir)
+	return m.ProjectPath
+}
+
+// readProjectConfig decodes the project config with its relative paths resolved against
+// the directory it is in.
+func readProjectConfig(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	table := map[string]any{}
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for key := range table {
+		if !projectConfigKeys[key] {
+			return nil, fmt.Errorf("%s: %s cannot be set in a project config", path, key)
+		}
+	}
+	dir := filepath.Dir(path)
+	resolvePath(table, "default_build_file", dir)
+	resolvePath(table, "build_context", dir)
+	if presets, ok := table["build_presets"].(map[string]any); ok {
+		for _, preset := range presets {
+			if preset, ok := preset.(map[string]any); ok {
+				resolvePath(preset, "file", dir)
+				resolvePath(preset, "context", dir)
+			}
+		}
+	}
+	if presets, ok := table["run_presets"].(map[string]any); ok {
+		for _, preset := range presets {
+			if preset, ok := preset.(map[string]any); ok {
+				if files, ok := preset["env_files"].([]any); ok {
+					for index, file := range files {
+						if file, ok := file.(string); ok && file != "" && !filepath.IsAbs(file) {
+							files[index] = filepath.Join(dir, file)
+						}
+					}
+				}
+			}
+		}
+	}
+	return table, nil
+}
+
+func resolvePath(table map[string]any, key string, dir string) {
+	if path, ok := table[key].(string); ok && path != "" && !filepath.IsAbs(path) {
+		table[key] = filepath.Join(dir, path)
+	}
+}
+
+var (
+	// tagPlaceholder matches the placeholders of default_tag_pattern.
+	tagPlaceholder = regexp.MustCompile(`\{([a-z]*)\}`)
+	// invalidNameChars matches runs of characters not allowed in a repository name.
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+)
+
+// TagPatternValues fill the placeholders of a tag pattern.
+type TagPatternValues struct {
+	// Project is the directory holding the project config, or the working directory.
+	Project string
+	// Context is the build context directory.
+	Context string
+	Now     time.Time
+}
+
+// ExpandTagPattern replaces {project} and {dir} with the lower-cased base names of the
+// project and build context directories, {date} with YYYYMMDD and {time} with HHMMSS.
+func ExpandTagPattern(pattern string, values TagPatternValues) string {
+	return tagPlaceholder.ReplaceAllStringFunc(pattern, func(match string) string {
+		switch match {
+		case "{project}":
+			return imageNameFromDir(values.Project)
+		case "{dir}":
+			return imageNameFromDir(values.Context)
+		case "{date}":
+			return values.Now.Format("20060102")
+		case "{time}":
+			return values.Now.Format("150405")
+		}
+		return match
+	})
+}
+
+func checkTagPattern(pattern string) string {
+	for _, match := range tagPlaceholder.FindAllStringSubmatch(pattern, -1) {
+		switch match[1] {
+		case "project", "dir", "date", "time":
+		default:
+			return fmt.Sprintf("unknown placeholder %s (use {project}, {dir}, {date} or {time})", match[0])
+		}
+	}
+	return ""
+}
+
+// imageNameFromDir turns a directory name into a valid repository name component.
+func imageNameFromDir(dir string) string {
+	name := strings.ToLower(filepath.Base(filepath.Clean(dir)))
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-._")
+	if name == "" {
+		return "image"
+	}
+	return name
+}
+
+// Config value sources, from lowest to highest precedence.
+const (
+	SourceDefault = "default"
+	SourceUser    = "user"
+	SourceProject = "project"
+	SourceEnv     = "env"
+)
+
+// ConfigSetting is one effective config value and where it came from.
+type ConfigSetting struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// EffectiveConfig is the merged config with the files it came from and the source of
+// every value.
+type EffectiveConfig struct {
+	Config      models.UserConfig
+	UserPath    string
+	ProjectPath string
+	Settings    []ConfigSetting
+}
+
+// ProjectDir returns the directory holding the project config, or "" when there is none.
+func (e EffectiveConfig) ProjectDir() string {
+	if e.ProjectPath == "" {
+		return ""
+	}
+	return filepath.Dir(e.ProjectPath)
+}
+
+// Effective loads the config like Load and records the source of every value: the
+// defaults, the user config, the project config or an ACTUI_* variable.
+func (m *ConfigManager) Effective() (EffectiveConfig, error) {
+	config, used, err := m.Load()
+	if err != nil {
+		return EffectiveConfig{}, err
+	}
+	effective := EffectiveConfig{Config: config, UserPath: used, ProjectPath: m.ProjectPath}
+
+	userKeys := map[string]bool{}
+	if used != "" {
+		table := map[string]any{}
+		if data, err := os.ReadFile(used); err == nil && toml.Unmarshal(data, &table) == nil {
+			flattenConfigTable(table, "", func(key string, _ any) { userKeys[strings.ToLower(key)] = true })
+		}
+	}
+	projectKeys := map[string]bool{}
+	if m.ProjectPath != "" {
+		if table, err := readProjectConfig(m.ProjectPath); err == nil {
+			flattenConfigTable(table, "", func(key string, _ any) { projectKeys[strings.ToLower(key)] = true })
+		}
+	}
+
+	defaultKeys := map[string]bool{}
+	defaults, _ := ConfigValue(models.DefaultUserConfig(), "")
+	flattenConfigTable(defaults.(map[string]any), "", func(key string, _ any) { defaultKeys[key] = true })
+
+	values, _ := ConfigValue(config, "")
+	flattenConfigTable(values.(map[string]any), "", func(key string, value any) {
+		source := SourceDefault
+		_, overridden := os.LookupEnv(EnvVarName(key))
+		switch {
+		case overridden:
+			source = SourceEnv
+		case projectKeys[key]:
+			source = SourceProject
+		case userKeys[key]:
+			source = SourceUser
+		case !defaultKeys[key]:
+			// A preset field no file sets.
+			return
+		}
+		effective.Settings = append(effective.Settings, ConfigSetting{Key: key, Value: formatConfigValue(value), Source: source})
+	})
+	sort.Slice(effective.Settings, func(i, j int) bool {
+		return effective.Settings[i].Key < effective.Settings[j].Key
+	})
+	return effective, nil
+}
+
+// flattenConfigTable calls fn with the dotted key of every value in a decoded table.
+func flattenConfigTable(table map[string]any, prefix string, fn func(key string, value any)) {
+	for key, value := range table {
+		if nested, ok := value.(map[string]any); ok {
+			flattenConfigTable(nested, prefix+key+".", fn)
+			continue
+		}
+		fn(prefix+key, value)
+	}
+}
+
+func formatConfigValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return fmt.Sprintf("%q", typed)
+	case []string:
+		quoted := make([]string, len(typed))
+		for index, item := range typed {
+			quoted[index] = fmt.Sprintf("%q", item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(typed)
+	}
+}
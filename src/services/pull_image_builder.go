@@ -7,9 +7,9 @@ type PullImageBuilder struct {
 	Reference string
 }
 
-// Validate ensures reference is provided.
+// Validate ensures reference is a valid image reference.
 func (b PullImageBuilder) Validate() error {
-	_, err := normalizeRequiredToken(b.Reference, "reference")
+	_, err := normalizeImageReference(b.Reference, "reference")
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,6 @@ func (b PullImageBuilder) Build() (models.Command, error) {
 	if err := b.Validate(); err != nil {
 		return models.Command{}, err
 	}
-	reference, _ := normalizeRequiredToken(b.Reference, "reference")
+	reference, _ := normalizeImageReference(b.Reference, "reference")
 	return models.Command{Executable: "container", Args: []string{"image", "pull", reference}}, nil
 }
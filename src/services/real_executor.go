@@ -2,7 +2,9 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"os/exec"
+	"sync"
 	"time"
 
 	"container-tui/src/models"
@@ -12,9 +14,14 @@ import (
 type RealExecutor struct{}
 
 // Execute runs the command and captures output.
-func (RealExecutor) Execute(cmd models.Command) (models.Result, error) {
+func (e RealExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return e.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command and kills it when ctx is canceled or its deadline passes.
+func (RealExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
 	start := time.Now()
-	command := exec.Command(cmd.Executable, cmd.Args...)
+	command := newProcess(ctx, cmd)
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	command.Stdout = &stdout
@@ -27,6 +34,13 @@ func (RealExecutor) Execute(cmd models.Command) (models.Result, error) {
 		Duration: time.Since(start),
 	}
 
+	if ctx.Err() != nil {
+		interrupted, interruptErr := interruptedResult(ctx, result.Duration)
+		interrupted.Stdout = result.Stdout
+		interrupted.Stderr = result.Stderr
+		return interrupted, interruptErr
+	}
+
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitErr.ExitCode()
@@ -41,3 +55,47 @@ func (RealExecutor) Execute(cmd models.Command) (models.Result, error) {
 	result.Status = models.ResultSuccess
 	return result, nil
 }
+
+// Stream runs the command and reports stdout and stderr lines as they are written.
+func (RealExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	start := time.Now()
+	command := newProcess(ctx, cmd)
+	command.WaitDelay = streamWaitDelay
+
+	var emitMu sync.Mutex
+	emit := func(line StreamLine) {
+		emitMu.Lock()
+		defer emitMu.Unlock()
+		onLine(line)
+	}
+	stdout := newLineWriter(StreamStdout, DefaultStreamTailLines, emit)
+	stderr := newLineWriter(StreamStderr, DefaultStreamTailLines, emit)
+	command.Stdout = stdout
+	command.Stderr = stderr
+
+	err := command.Run()
+	stdout.Flush()
+	stderr.Flush()
+	result := models.Result{
+		Stdout:   stdout.tail.String(),
+		Stderr:   stderr.tail.String(),
+		Duration: time.Since(start),
+	}
+	if ctx.Err() != nil {
+		interrupted, interruptErr := interruptedResult(ctx, result.Duration)
+		interrupted.Stdout = result.Stdout
+		interrupted.Stderr = result.Stderr
+		return interrupted, interruptErr
+	}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+		}
+		result.Status = models.ResultError
+		return result, err
+	}
+	result.Status = models.ResultSuccess
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"container-tui/src/models"
+)
+
+// RecordingExecutor appends every command and its result to a session fixture file.
+// Canceled commands are not recorded because their output is incomplete.
+type RecordingExecutor struct {
+	delegate CommandExecutor
+	path     string
+	redactor *Redactor
+	mu       sync.Mutex
+}
+
+// NewRecordingExecutor builds a recording executor writing to path.
+func NewRecordingExecutor(delegate CommandExecutor, path string) *RecordingExecutor {
+	return &RecordingExecutor{delegate: delegate, path: path, redactor: DefaultRedactor()}
+}
+
+// SetRedactor replaces the redactor applied to recorded entries, so fixtures can be shared.
+func (r *RecordingExecutor) SetRedactor(redactor *Redactor) {
+	r.redactor = redactor
+}
+
+// Execute runs and records the command.
+func (r *RecordingExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return r.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs and records the command with context support.
+func (r *RecordingExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	result, err := ExecuteContext(ctx, r.delegate, cmd)
+	r.record(cmd, result, err)
+	return result, err
+}
+
+// Stream runs the command and records the streamed tail as its output.
+func (r *RecordingExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	result, err := StreamContext(ctx, r.delegate, cmd, onLine)
+	r.record(cmd, result, err)
+	return result, err
+}
+
+// Unwrap returns the wrapped executor.
+func (r *RecordingExecutor) Unwrap() CommandExecutor {
+	return r.delegate
+}
+
+func (r *RecordingExecutor) record(cmd models.Command, result models.Result, err error) {
+	if IsCanceled(err) {
+		return
+	}
+	_ = r.append(r.redactor.RedactFixture(NewFixtureEntry(cmd, result, err)))
+}
+
+func (r *RecordingExecutor) append(entry FixtureEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return json.NewEncoder(file).Encode(entry)
+}
@@ -0,0 +1,24 @@
+package services
+
+import "container-tui/src/models"
+
+// RegistryLoginBuilder builds a registry login command. The CLI prompts for the username
+// and password, so the command runs interactively.
+type RegistryLoginBuilder struct {
+	Server string
+}
+
+// Validate ensures server is provided.
+func (b RegistryLoginBuilder) Validate() error {
+	_, err := normalizeRequiredToken(b.Server, "registry server")
+	return err
+}
+
+// Build returns the registry login command.
+func (b RegistryLoginBuilder) Build() (models.Command, error) {
+	server, err := normalizeRequiredToken(b.Server, "registry server")
+	if err != nil {
+		return models.Command{}, err
+	}
+	return models.Command{Executable: "container", Args: []string{"registry", "login", server}}, nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"container-tui/src/models"
+)
+
+// ReplayMissError reports a command that is not in the recording.
+type ReplayMissError struct {
+	Command models.Command
+}
+
+func (e *ReplayMissError) Error() string {
+	return fmt.Sprintf("command not in recording: %s", e.Command.String())
+}
+
+// ReplayExecutor serves recorded results by matching commands exactly. Recordings mask
+// secrets, so commands are matched after the same redaction. Repeated commands are answered
+// in recorded order, and the last recorded result is reused once exhausted. Commands missing
+// from the recording are reported as drift and, when a fallback is set, delegated to it.
+type ReplayExecutor struct {
+	fallback CommandExecutor
+	mu       sync.Mutex
+	recorded []FixtureEntry
+	entries  map[string][]FixtureEntry
+	served   map[string]int
+	drift    []models.Command
+	redactor *Redactor
+}
+
+// NewReplayExecutor builds a replay executor over recorded entries. fallback may be nil.
+func NewReplayExecutor(entries []FixtureEntry, fallback CommandExecutor) *ReplayExecutor {
+	replay := &ReplayExecutor{fallback: fallback, recorded: entries, served: map[string]int{}, redactor: DefaultRedactor()}
+	replay.index()
+	return replay
+}
+
+// Execute returns the recorded result for the command.
+func (r *ReplayExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return r.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext returns the recorded result unless ctx is already done.
+func (r *ReplayExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	if ctx.Err() != nil {
+		return interruptedResult(ctx, 0)
+	}
+	entry, ok := r.next(cmd)
+	if ok {
+		return entry.Result()
+	}
+	if r.fallback != nil {
+		return ExecuteContext(ctx, r.fallback, cmd)
+	}
+	return models.Result{ExitCode: -1, Status: models.ResultError}, &ReplayMissError{Command: cmd}
+}
+
+// Unwrap returns the fallback executor.
+func (r *ReplayExecutor) Unwrap() CommandExecutor {
+	return r.fallback
+}
+
+// Has reports whether the recording answers cmd, without serving or counting it as drift.
+func (r *ReplayExecutor) Has(cmd models.Command) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries[r.key(cmd)]) > 0
+}
+
+// Drift returns the commands that were requested but not found in the recording.
+func (r *ReplayExecutor) Drift() []models.Command {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]models.Command{}, r.drift...)
+}
+
+// SetRedactor sets the redactor applied before matching commands and to commands listed in
+// the drift report. It should be the redactor the recording was made with.
+func (r *ReplayExecutor) SetRedactor(redactor *Redactor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.redactor = redactor
+	r.index()
+}
+
+// index groups the recorded entries by key. The caller holds mu, or owns r.
+func (r *ReplayExecutor) index() {
+	r.entries = make(map[string][]FixtureEntry, len(r.recorded))
+	for _, entry := range r.recorded {
+		key := r.key(entry.Command())
+		r.entries[key] = append(r.entries[key], entry)
+	}
+}
+
+// key identifies cmd the way the recorder wrote it, with its secrets masked.
+func (r *ReplayExecutor) key(cmd models.Command) string {
+	return fixtureKey(r.redactor.RedactCommand(cmd))
+}
+
+// DriftReport summarizes unmatched commands, or returns an empty string when none drifted.
+func (r *ReplayExecutor) DriftReport() string {
+	drift := r.Drift()
+	if len(drift) == 0 {
+		return ""
+	}
+	counts := map[string]int{}
+	order := make([]string, 0, len(drift))
+	for _, cmd := range drift {
+		key := r.redactor.RedactCommand(cmd).String()
+		if counts[key] == 0 {
+			order = append(order, key)
+		}
+		counts[key]++
+	}
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "replay drift: %d command(s) not in recording\n", len(order))
+	for _, key := range order {
+		fmt.Fprintf(&builder, "  %s (x%d)\n", key, counts[key])
+	}
+	return builder.String()
+}
+
+func (r *ReplayExecutor) next(cmd models.Command) (FixtureEntry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := r.key(cmd)
+	recorded := r.entries[key]
+	if len(recorded) == 0 {
+		r.drift = append(r.drift, cmd)
+		return FixtureEntry{}, false
+	}
+	index := r.served[key]
+	if index >= len(recorded) {
+		index = len(recorded) - 1
+	} else {
+		r.served[key] = index + 1
+	}
+	return recorded[index], true
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// RetryableKinds lists the error kinds a retry policy may name. Canceled and blocked
+// commands, and failures that need the user to act, are never retried.
+func RetryableKinds() []ErrorCategory {
+	return []ErrorCategory{ErrorNetwork, ErrorTimeout, ErrorDaemon, ErrorUnknown}
+}
+
+// RetryPolicy decides how often and how soon a failed read-only command runs again.
+type RetryPolicy struct {
+	// Attempts counts the first run, so 1 disables retries.
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Kinds      []ErrorCategory
+	// Warmup also retries daemon errors for this long after a system or machine start.
+	Warmup time.Duration
+}
+
+// NewRetryPolicy converts the `retry` config table into a policy.
+func NewRetryPolicy(config models.RetryConfig) RetryPolicy {
+	policy := RetryPolicy{Attempts: config.Attempts, Backoff: config.Backoff, MaxBackoff: config.MaxBackoff, Warmup: config.Warmup}
+	for _, kind := range config.Kinds {
+		policy.Kinds = append(policy.Kinds, ErrorCategory(kind))
+	}
+	return policy
+}
+
+// DefaultRetryPolicy returns the policy of the default `retry` table.
+func DefaultRetryPolicy() RetryPolicy {
+	return NewRetryPolicy(models.DefaultUserConfig().Retry)
+}
+
+// Retries reports whether the policy treats failures of kind as transient. Daemon errors
+// are transient while warming, shortly after a system or machine start.
+func (p RetryPolicy) Retries(kind ErrorCategory, warming bool) bool {
+	return slices.Contains(p.Kinds, kind) || (warming && kind == ErrorDaemon && p.Warmup > 0)
+}
+
+// Delay returns the wait before attempt, which is 2 for the first retry. The delay
+// doubles with each retry up to MaxBackoff.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for retry := 2; retry < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); retry++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// RetryState describes a command that failed and is being run again.
+type RetryState struct {
+	Command  models.Command
+	Attempt  int
+	Attempts int
+	Err      *RuntimeError
+}
+
+func (s RetryState) String() string {
+	return fmt.Sprintf("retrying (%d/%d)", s.Attempt, s.Attempts)
+}
+
+// RetryingExecutor runs idempotent read-only commands, such as lists, inspects and status
+// checks, again when they fail with a transient error. Commands that change runtime state
+// and log commands are never retried.
+type RetryingExecutor struct {
+	delegate CommandExecutor
+	now      func() time.Time
+
+	mu      sync.Mutex
+	policy  RetryPolicy
+	started time.Time
+	notify  func(RetryState)
+	retries map[int]RetryState
+	nextID  int
+}
+
+// NewRetryingExecutor builds a retrying executor.
+func NewRetryingExecutor(delegate CommandExecutor, policy RetryPolicy) *RetryingExecutor {
+	return &RetryingExecutor{delegate: delegate, now: time.Now, policy: policy, retries: map[int]RetryState{}}
+}
+
+// SetPolicy replaces the policy used for commands started from now on.
+func (r *RetryingExecutor) SetPolicy(policy RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = policy
+}
+
+// SetNotify calls notify before each retry.
+func (r *RetryingExecutor) SetNotify(notify func(RetryState)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notify = notify
+}
+
+// Retrying returns the most recent retry still in progress.
+func (r *RetryingExecutor) Retrying() (RetryState, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	latest := -1
+	for id := range r.retries {
+		latest = max(latest, id)
+	}
+	state, ok := r.retries[latest]
+	return state, ok
+}
+
+// Execute runs the command, retrying transient failures.
+func (r *RetryingExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return r.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command under ctx, retrying transient failures.
+func (r *RetryingExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	return r.run(ctx, cmd, func() (models.Result, error) {
+		return ExecuteContext(ctx, r.delegate, cmd)
+	})
+}
+
+// Stream streams the command, retrying transient failures. Lines from failed attempts
+// have already been reported when the command runs again.
+func (r *RetryingExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	return r.run(ctx, cmd, func() (models.Result, error) {
+		return StreamContext(ctx, r.delegate, cmd, onLine)
+	})
+}
+
+// Unwrap returns the wrapped executor.
+func (r *RetryingExecutor) Unwrap() CommandExecutor {
+	return r.delegate
+}
+
+// Failures are returned as a *RuntimeError whose Retryable says whether the policy
+// treats them as transient, so callers see the same decision the retries followed.
+func (r *RetryingExecutor) run(ctx context.Context, cmd models.Command, attempt func() (models.Result, error)) (models.Result, error) {
+	start := r.now()
+	r.mu.Lock()
+	policy := r.policy
+	r.mu.Unlock()
+	result, err := attempt()
+	if err == nil || !retryableCommand(cmd) {
+		r.noteStart(cmd, err)
+		return result, r.classify(policy, cmd, result, err)
+	}
+
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.retries, id)
+		r.mu.Unlock()
+	}()
+
+	for next := 2; next <= policy.Attempts && err != nil && ctx.Err() == nil; next++ {
+		failure := r.classify(policy, cmd, result, err).(*RuntimeError)
+		if !failure.Retryable {
+			break
+		}
+		state := RetryState{Command: cmd, Attempt: next, Attempts: policy.Attempts, Err: failure}
+		r.mu.Lock()
+		r.retries[id] = state
+		notify := r.notify
+		r.mu.Unlock()
+		if notify != nil {
+			notify(state)
+		}
+
+		timer := time.NewTimer(policy.Delay(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return interruptedResult(ctx, r.now().Sub(start))
+		case <-timer.C:
+		}
+		result, err = attempt()
+	}
+	return result, r.classify(policy, cmd, result, err)
+}
+
+// classify converts err to a *RuntimeError marked retryable by policy, keeping a nil
+// error nil.
+func (r *RetryingExecutor) classify(policy RetryPolicy, cmd models.Command, result models.Result, err error) error {
+	failure := NewRuntimeError(cmd, result, err)
+	if failure == nil {
+		return nil
+	}
+	classified := *failure
+	classified.Retryable = policy.Retries(classified.Kind, r.warming(policy))
+	return &classified
+}
+
+// warming reports whether the daemon or a machine is still within policy's warmup after
+// starting.
+func (r *RetryingExecutor) warming(policy RetryPolicy) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.started.IsZero() && r.now().Sub(r.started) < policy.Warmup
+}
+
+// noteStart remembers when a system or machine start succeeded.
+func (r *RetryingExecutor) noteStart(cmd models.Command, err error) {
+	if err != nil || len(cmd.Args) < 2 || cmd.Args[1] != "start" || (cmd.Args[0] != "system" && cmd.Args[0] != "machine") {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = r.now()
+}
+
+// retryableCommand reports whether cmd is read-only and idempotent. Logs are read-only
+// but may follow output, so they are not.
+func retryableCommand(cmd models.Command) bool {
+	if _, ok := matchPolicyRule(readOnlySubcommands, policySubcommand(cmd)); !ok {
+		return false
+	}
+	return ClassifyCommand(cmd) != CommandKindLogs
+}
+
+// RetryStatus returns the retry in progress in executor or any executor it wraps.
+func RetryStatus(executor CommandExecutor) (RetryState, bool) {
+	for executor != nil {
+		if retrying, ok := executor.(*RetryingExecutor); ok {
+			return retrying.Retrying()
+		}
+		wrapper, ok := executor.(WrappingExecutor)
+		if !ok {
+			return RetryState{}, false
+		}
+		executor = wrapper.Unwrap()
+	}
+	return RetryState{}, false
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// RunField names a field of RunContainerBuilder, so problems can be shown next to it.
+type RunField string
+
+const (
+	RunFieldImage      RunField = "image"
+	RunFieldName       RunField = "name"
+	RunFieldPorts      RunField = "ports"
+	RunFieldEnv        RunField = "env"
+	RunFieldEnvFiles   RunField = "env files"
+	RunFieldVolumes    RunField = "volumes"
+	RunFieldCPUs       RunField = "cpus"
+	RunFieldMemory     RunField = "memory"
+	RunFieldWorkdir    RunField = "workdir"
+	RunFieldEntrypoint RunField = "entrypoint"
+	RunFieldCommand    RunField = "command"
+	RunFieldLabels     RunField = "labels"
+)
+
+// RunFields lists the fields of RunContainerBuilder in the order they are validated.
+func RunFields() []RunField {
+	return []RunField{RunFieldImage, RunFieldName, RunFieldPorts, RunFieldEnv, RunFieldEnvFiles, RunFieldVolumes, RunFieldCPUs, RunFieldMemory, RunFieldWorkdir, RunFieldEntrypoint, RunFieldCommand, RunFieldLabels}
+}
+
+var (
+	containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	envNamePattern       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	memoryPattern        = regexp.MustCompile(`^[1-9][0-9]*(?:[KkMmGgTt][Bb]?|[Bb])?$`)
+)
+
+// RunContainerBuilder builds `container run [flags] <image> [command...]`.
+type RunContainerBuilder struct {
+	Image  string
+	Name   string
+	Detach bool
+	Ports  []models.PortMapping
+	// Env holds KEY=VALUE entries; a bare KEY passes the variable through from the CLI's
+	// environment.
+	Env      []string
+	EnvFiles []string
+	Volumes  []models.Mount
+	// CPUs and Memory are left to the runtime when zero and empty. Memory takes a size
+	// such as 512M or 2G.
+	CPUs       int
+	Memory     string
+	Workdir    string
+	Entrypoint string
+	Command    []string
+	// Labels holds key=value entries.
+	Labels []string
+}
+
+// FieldErrors validates every field and returns the first problem of each, by field.
+func (b RunContainerBuilder) FieldErrors() map[RunField]error {
+	problems := map[RunField]error{}
+	if _, err := normalizeImageReference(b.Image, "image"); err != nil {
+		problems[RunFieldImage] = err
+	}
+	if name := strings.TrimSpace(b.Name); name != "" && !containerNamePattern.MatchString(name) {
+		problems[RunFieldName] = fmt.Errorf("invalid name %q: use letters, digits, \"_\", \".\" or \"-\", starting with a letter or digit", name)
+	}
+	if err := validatePorts(b.Ports); err != nil {
+		problems[RunFieldPorts] = err
+	}
+	for _, entry := range b.Env {
+		name, _, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if !envNamePattern.MatchString(name) {
+			problems[RunFieldEnv] = fmt.Errorf("invalid variable %q: use NAME=VALUE with letters, digits and \"_\" in the name", entry)
+			break
+		}
+	}
+	for _, path := range b.EnvFiles {
+		if strings.TrimSpace(path) == "" {
+			problems[RunFieldEnvFiles] = errors.New("env file path is empty")
+			break
+		}
+	}
+	for _, mount := range b.Volumes {
+		if err := mount.Validate(); err != nil {
+			problems[RunFieldVolumes] = fmt.Errorf("invalid volume %q: %w", mount.String(), err)
+			break
+		}
+	}
+	if b.CPUs < 0 {
+		problems[RunFieldCPUs] = errors.New("cpus must be a positive integer")
+	}
+	if memory := strings.TrimSpace(b.Memory); memory != "" && !memoryPattern.MatchString(memory) {
+		problems[RunFieldMemory] = fmt.Errorf("invalid memory %q: use a size such as 512M or 2G", memory)
+	}
+	if workdir := strings.TrimSpace(b.Workdir); workdir != "" && !strings.HasPrefix(workdir, "/") {
+		problems[RunFieldWorkdir] = errors.New("working directory must be an absolute path")
+	}
+	if entrypoint := strings.TrimSpace(b.Entrypoint); strings.ContainsAny(entrypoint, " \t\n") {
+		problems[RunFieldEntrypoint] = errors.New("entrypoint must be a single executable; put its arguments in the command")
+	}
+	for _, label := range b.Labels {
+		key, _, ok := strings.Cut(strings.TrimSpace(label), "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t\n") {
+			problems[RunFieldLabels] = fmt.Errorf("invalid label %q: use key=value", label)
+			break
+		}
+	}
+	return problems
+}
+
+// ParseRunCommand splits a command line into words. Words are separated by spaces; a word
+// containing spaces or quotes is written in double quotes with Go escapes.
+func ParseRunCommand(line string) ([]string, error) {
+	words, err := splitCommandLine(line)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command: unterminated or malformed quote")
+	}
+	return words, nil
+}
+
+// validatePorts checks each mapping and that no host port is published twice.
+func validatePorts(ports []models.PortMapping) error {
+	published := map[string]bool{}
+	for _, port := range ports {
+		if err := port.Validate(); err != nil {
+			return fmt.Errorf("invalid port mapping %q: %w", port.String(), err)
+		}
+		key := strconv.Itoa(port.HostPort) + "/" + port.Protocol
+		if published[key] {
+			return fmt.Errorf("host port %s is published twice", key)
+		}
+		published[key] = true
+	}
+	return nil
+}
+
+// Validate returns the first problem in the order of RunFields.
+func (b RunContainerBuilder) Validate() error {
+	problems := b.FieldErrors()
+	for _, field := range RunFields() {
+		if err := problems[field]; err != nil {
+			if field == RunFieldImage {
+				return err
+			}
+			return fmt.Errorf("%s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// Build returns the run command.
+func (b RunContainerBuilder) Build() (models.Command, error) {
+	if err := b.Validate(); err != nil {
+		return models.Command{}, err
+	}
+	args := []string{"run"}
+	if name := strings.TrimSpace(b.Name); name != "" {
+		args = append(args, "--name", name)
+	}
+	if b.Detach {
+		args = append(args, "--detach")
+	}
+	if b.CPUs > 0 {
+		args = append(args, "--cpus", strconv.Itoa(b.CPUs))
+	}
+	if memory := strings.TrimSpace(b.Memory); memory != "" {
+		args = append(args, "--memory", memory)
+	}
+	if workdir := strings.TrimSpace(b.Workdir); workdir != "" {
+		args = append(args, "--workdir", workdir)
+	}
+	if entrypoint := strings.TrimSpace(b.Entrypoint); entrypoint != "" {
+		args = append(args, "--entrypoint", entrypoint)
+	}
+	for _, entry := range b.Env {
+		args = append(args, "--env", strings.TrimSpace(entry))
+	}
+	for _, path := range b.EnvFiles {
+		args = append(args, "--env-file", strings.TrimSpace(path))
+	}
+	for _, port := range b.Ports {
+		args = append(args, "--publish", port.String())
+	}
+	for _, mount := range b.Volumes {
+		args = append(args, "--volume", mount.String())
+	}
+	for _, label := range b.Labels {
+		args = append(args, "--label", strings.TrimSpace(label))
+	}
+	image, _ := normalizeImageReference(b.Image, "image")
+	args = append(args, image)
+	args = append(args, b.Command...)
+	return models.Command{Executable: "container", Args: args}, nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// Remedy names the action that fixes a failed command.
+type Remedy string
+
+const (
+	// RemedyNone marks failures without a known fix.
+	RemedyNone Remedy = ""
+	// RemedyStartDaemon starts the container system service.
+	RemedyStartDaemon Remedy = "start-daemon"
+	// RemedyRegistryLogin logs in to the registry that rejected the credentials.
+	RemedyRegistryLogin Remedy = "registry-login"
+	// RemedyStartBuilder starts the image builder.
+	RemedyStartBuilder Remedy = "start-builder"
+)
+
+// Label returns the name of the remedy's action as a button would show it.
+func (r Remedy) Label() string {
+	switch r {
+	case RemedyStartDaemon:
+		return "Start daemon"
+	case RemedyRegistryLogin:
+		return "Log in to registry"
+	case RemedyStartBuilder:
+		return "Start builder"
+	default:
+		return ""
+	}
+}
+
+// ResourceType names what a command acts on.
+type ResourceType string
+
+const (
+	ResourceDaemon    ResourceType = "daemon"
+	ResourceBuilder   ResourceType = "builder"
+	ResourceRegistry  ResourceType = "registry"
+	ResourceContainer ResourceType = "container"
+	ResourceImage     ResourceType = "image"
+	ResourceMachine   ResourceType = "machine"
+)
+
+// DefaultRegistry is the registry of references without a registry host.
+const DefaultRegistry = models.DefaultImageRegistry
+
+// Resource identifies what a failed command acted on. Name is empty for the daemon and
+// the builder, of which there is one.
+type Resource struct {
+	Type ResourceType
+	Name string
+}
+
+func (r Resource) String() string {
+	if r.Name == "" {
+		return string(r.Type)
+	}
+	return string(r.Type) + " " + r.Name
+}
+
+// RuntimeError is a failed command classified by its cause, so callers can branch on the
+// kind instead of matching message text.
+type RuntimeError struct {
+	Kind     ErrorCategory
+	Resource Resource
+	// Retryable reports whether the retry policy treats the failure as transient: the
+	// executor's policy for commands it ran, or DefaultRetryPolicy otherwise.
+	Retryable bool
+	Remedy    Remedy
+	Command   models.Command
+	Stderr    string
+	Err       error
+}
+
+// NewRuntimeError classifies a failed command, or returns nil when err is nil. Errors that
+// are already classified are returned unchanged.
+func NewRuntimeError(cmd models.Command, result models.Result, err error) *RuntimeError {
+	if err == nil {
+		return nil
+	}
+	var runtimeErr *RuntimeError
+	if errors.As(err, &runtimeErr) {
+		return runtimeErr
+	}
+	kind := ClassifyError(err, result.Stderr)
+	resource := commandResource(cmd)
+	switch kind {
+	case ErrorDaemon:
+		resource = Resource{Type: ResourceDaemon}
+	case ErrorBuilder:
+		resource = Resource{Type: ResourceBuilder}
+	case ErrorAuth:
+		registry, _ := commandRegistry(cmd)
+		resource = Resource{Type: ResourceRegistry, Name: registry}
+	}
+	return &RuntimeError{
+		Kind:      kind,
+		Resource:  resource,
+		Retryable: DefaultRetryPolicy().Retries(kind, false),
+		Remedy:    kind.Remedy(),
+		Command:   cmd,
+		Stderr:    result.Stderr,
+		Err:       err,
+	}
+}
+
+func (e *RuntimeError) Error() string {
+	if e.Kind == ErrorAuth && e.Resource.Name != "" {
+		return "authentication required for " + e.Resource.Name + "; check registry credentials"
+	}
+	return FormatError(e.Err, e.Stderr)
+}
+
+func (e *RuntimeError) Unwrap() error {
+	return e.Err
+}
+
+// RemedyCommand builds the command that applies the remedy, and reports whether it must
+// run interactively because it prompts for input.
+func (e *RuntimeError) RemedyCommand() (models.Command, bool, error) {
+	switch e.Remedy {
+	case RemedyStartDaemon:
+		cmd, err := StartDaemonBuilder{}.Build()
+		return cmd, false, err
+	case RemedyStartBuilder:
+		cmd, err := StartBuilderBuilder{}.Build()
+		return cmd, false, err
+	case RemedyRegistryLogin:
+		cmd, err := RegistryLoginBuilder{Server: e.Resource.Name}.Build()
+		return cmd, true, err
+	default:
+		return models.Command{}, false, errors.New("no remedy for " + string(e.Kind) + " errors")
+	}
+}
+
+// ResolvedBy reports whether a successful run of cmd shows the remedy is no longer needed:
+// any command that reached the daemon, a build or builder start, or a login or pull for
+// the same registry.
+func (e *RuntimeError) ResolvedBy(cmd models.Command) bool {
+	args := cmd.Args
+	if len(args) == 0 || isHelpRequest(args) {
+		return false
+	}
+	switch e.Remedy {
+	case RemedyStartDaemon:
+		return args[0] != "system" || (len(args) > 1 && args[1] == "start")
+	case RemedyStartBuilder:
+		return commandResource(cmd).Type == ResourceBuilder
+	case RemedyRegistryLogin:
+		registry, ok := commandRegistry(cmd)
+		return ok && registry == e.Resource.Name
+	default:
+		return false
+	}
+}
+
+// commandResource returns what a `container` command acts on.
+func commandResource(cmd models.Command) Resource {
+	args := cmd.Args
+	if len(args) == 0 {
+		return Resource{}
+	}
+	target := ""
+	if len(args) > 1 && !strings.HasPrefix(lastArg(args), "-") {
+		target = lastArg(args)
+	}
+	switch args[0] {
+	case "system":
+		return Resource{Type: ResourceDaemon}
+	case "build", "builder":
+		return Resource{Type: ResourceBuilder}
+	case "registry":
+		registry, _ := commandRegistry(cmd)
+		return Resource{Type: ResourceRegistry, Name: registry}
+	case "image":
+		if len(args) > 2 {
+			return Resource{Type: ResourceImage, Name: target}
+		}
+		return Resource{Type: ResourceImage}
+	case "machine":
+		if len(args) > 2 {
+			return Resource{Type: ResourceMachine, Name: target}
+		}
+		return Resource{Type: ResourceMachine}
+	case "list", "ls":
+		return Resource{Type: ResourceContainer}
+	default:
+		return Resource{Type: ResourceContainer, Name: target}
+	}
+}
+
+// commandRegistry returns the registry a command talks to: the server of a registry
+// login or logout, or the registry of the reference an image pull or push names. Other
+// commands, builds included, report the default registry and false.
+func commandRegistry(cmd models.Command) (string, bool) {
+	args := cmd.Args
+	switch {
+	case len(args) > 2 && args[0] == "registry" && (args[1] == "login" || args[1] == "logout"):
+		return lastArg(args), true
+	case len(args) > 2 && args[0] == "image" && (args[1] == "pull" || args[1] == "push"):
+		return referenceRegistry(lastArg(args)), true
+	default:
+		return DefaultRegistry, false
+	}
+}
+
+// referenceRegistry returns the registry host of an image reference, or the default
+// registry when the reference names none or does not parse.
+func referenceRegistry(reference string) string {
+	parsed, err := models.ParseImageReference(reference)
+	if err != nil {
+		return DefaultRegistry
+	}
+	return parsed.Registry
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// FixtureEntry is one recorded command and its result in a session fixture file.
+type FixtureEntry struct {
+	Executable string   `json:"executable"`
+	Args       []string `json:"args"`
+	ExitCode   int      `json:"exit_code"`
+	Stdout     string   `json:"stdout,omitempty"`
+	Stderr     string   `json:"stderr,omitempty"`
+	DurationMs int64    `json:"duration_ms"`
+	Status     string   `json:"status"`
+	Error      string   `json:"error,omitempty"`
+	ErrorKind  string   `json:"error_kind,omitempty"`
+	TimeoutMs  int64    `json:"timeout_ms,omitempty"`
+}
+
+// NewFixtureEntry captures a command and its outcome.
+func NewFixtureEntry(cmd models.Command, result models.Result, err error) FixtureEntry {
+	entry := FixtureEntry{
+		Executable: cmd.Executable,
+		Args:       append([]string{}, cmd.Args...),
+		ExitCode:   result.ExitCode,
+		Stdout:     result.Stdout,
+		Stderr:     result.Stderr,
+		DurationMs: result.Duration.Milliseconds(),
+		Status:     string(result.Status),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		switch kind := ClassifyError(err, ""); kind {
+		case ErrorCanceled, ErrorTimeout, ErrorPolicy:
+			entry.ErrorKind = string(kind)
+		}
+		var timeoutErr *TimeoutError
+		if errors.As(err, &timeoutErr) {
+			entry.TimeoutMs = timeoutErr.Timeout.Milliseconds()
+		}
+	}
+	return entry
+}
+
+// Command returns the recorded command.
+func (e FixtureEntry) Command() models.Command {
+	return models.Command{Executable: e.Executable, Args: append([]string{}, e.Args...)}
+}
+
+// Result returns the recorded result and error. Cancellations, timeouts and policy blocks
+// come back with the types the executor gave them, so callers classify them the same way.
+func (e FixtureEntry) Result() (models.Result, error) {
+	result := models.Result{
+		ExitCode: e.ExitCode,
+		Stdout:   e.Stdout,
+		Stderr:   e.Stderr,
+		Duration: time.Duration(e.DurationMs) * time.Millisecond,
+		Status:   models.ResultStatus(e.Status),
+	}
+	if e.Error == "" {
+		return result, nil
+	}
+	switch ErrorCategory(e.ErrorKind) {
+	case ErrorCanceled:
+		return result, &fixtureError{message: e.Error, cause: context.Canceled}
+	case ErrorTimeout:
+		return result, &TimeoutError{
+			Timeout: time.Duration(e.TimeoutMs) * time.Millisecond,
+			Err:     &fixtureError{message: e.Error, cause: context.DeadlineExceeded},
+		}
+	case ErrorPolicy:
+		return result, &PolicyError{Command: e.Command(), Reason: strings.TrimPrefix(e.Error, "blocked by policy: ")}
+	}
+	return result, errors.New(e.Error)
+}
+
+// fixtureError is a replayed error that keeps its recorded message and the sentinel it wrapped.
+type fixtureError struct {
+	message string
+	cause   error
+}
+
+func (e *fixtureError) Error() string {
+	return e.message
+}
+
+func (e *fixtureError) Unwrap() error {
+	return e.cause
+}
+
+// ReadFixture loads a session fixture written by RecordingExecutor.
+func ReadFixture(path string) ([]FixtureEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	entries := make([]FixtureEntry, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry FixtureEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// fixtureKey identifies a command for replay matching.
+func fixtureKey(cmd models.Command) string {
+	return cmd.String()
+}
@@ -0,0 +1,394 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/term"
+
+	"container-tui/src/models"
+)
+
+// sshDialTimeout bounds connecting and authenticating to a remote host.
+const sshDialTimeout = 10 * time.Second
+
+// defaultIdentityFiles are tried, in order, when a profile sets no identity_file.
+var defaultIdentityFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// SSHConfig describes how to reach and authenticate to a remote host.
+type SSHConfig struct {
+	// Address is host:port.
+	Address string
+	User    string
+	// AgentSocket is the SSH agent to ask for keys, or "" for none.
+	AgentSocket string
+	Signers     []ssh.Signer
+	HostKey     ssh.HostKeyCallback
+}
+
+// NewSSHConfig resolves the host of profile. Keys come from the agent at $SSH_AUTH_SOCK and
+// from identity_file, or the default ~/.ssh/id_* keys when it is unset. Host keys are checked
+// against known_hosts, by default ~/.ssh/known_hosts.
+func NewSSHConfig(profile Profile) (SSHConfig, error) {
+	config := SSHConfig{AgentSocket: os.Getenv("SSH_AUTH_SOCK")}
+	host := profile.Host
+	if name, rest, ok := strings.Cut(host, "@"); ok {
+		config.User, host = name, rest
+	}
+	if config.User == "" {
+		current, err := user.Current()
+		if err != nil {
+			return SSHConfig{}, fmt.Errorf("ssh user for %s: %w", profile.Host, err)
+		}
+		config.User = current.Username
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+	config.Address = host
+
+	home, _ := os.UserHomeDir()
+	if profile.IdentityFile != "" {
+		signer, err := readIdentityFile(expandHome(profile.IdentityFile, home))
+		if err != nil {
+			return SSHConfig{}, err
+		}
+		config.Signers = append(config.Signers, signer)
+	} else {
+		for _, name := range defaultIdentityFiles {
+			if signer, err := readIdentityFile(filepath.Join(home, ".ssh", name)); err == nil {
+				config.Signers = append(config.Signers, signer)
+			}
+		}
+	}
+	if config.AgentSocket == "" && len(config.Signers) == 0 {
+		return SSHConfig{}, fmt.Errorf("no SSH agent or key for %s: start ssh-agent or set identity_file", profile.Host)
+	}
+
+	knownHostsPath := profile.KnownHosts
+	if knownHostsPath == "" {
+		knownHostsPath = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKey, err := knownhosts.New(expandHome(knownHostsPath, home))
+	if err != nil {
+		return SSHConfig{}, fmt.Errorf("known_hosts: %w (connect once with ssh to add the host key)", err)
+	}
+	config.HostKey = hostKey
+	return config, nil
+}
+
+func readIdentityFile(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("identity_file: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf("identity_file %s is passphrase protected; add it to the SSH agent instead", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("identity_file %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+func expandHome(path string, home string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(home, rest)
+	}
+	return path
+}
+
+// SSHExecutor runs commands on a remote host. Every command, log stream and interactive
+// session is a channel on one SSH connection, opened on first use and reopened if it drops.
+type SSHExecutor struct {
+	config  SSHConfig
+	mu      sync.Mutex
+	client  *ssh.Client
+	dialing *sshDial
+}
+
+// sshDial is a connection being opened. Commands that need the connection meanwhile wait
+// for done instead of holding the lock, and share its outcome.
+type sshDial struct {
+	done   chan struct{}
+	client *ssh.Client
+	err    error
+	// abandoned is set when the dial failed because its command's ctx ended.
+	abandoned bool
+}
+
+// NewSSHExecutor builds an executor for the host in config. It does not connect yet.
+func NewSSHExecutor(config SSHConfig) *SSHExecutor {
+	return &SSHExecutor{config: config}
+}
+
+// Execute runs the command on the remote host and captures output.
+func (e *SSHExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return e.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command on the remote host and kills it when ctx is done.
+func (e *SSHExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	start := time.Now()
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	err := e.run(ctx, cmd, &stdout, &stderr)
+	return sshResult(ctx, start, stdout.String(), stderr.String(), err)
+}
+
+// Stream runs the command on the remote host and reports output lines as they arrive.
+func (e *SSHExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	start := time.Now()
+	var emitMu sync.Mutex
+	emit := func(line StreamLine) {
+		emitMu.Lock()
+		defer emitMu.Unlock()
+		onLine(line)
+	}
+	stdout := newLineWriter(StreamStdout, DefaultStreamTailLines, emit)
+	stderr := newLineWriter(StreamStderr, DefaultStreamTailLines, emit)
+	err := e.run(ctx, cmd, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+	return sshResult(ctx, start, stdout.tail.String(), stderr.tail.String(), err)
+}
+
+// Interactive prepares a remote session. When stdin is a terminal it is put in raw mode and
+// the remote side gets a terminal of the same size, as `container exec -it` needs.
+func (e *SSHExecutor) Interactive(cmd models.Command) InteractiveProcess {
+	return &sshProcess{executor: e, cmd: cmd}
+}
+
+// Close closes the connection, ending any remote sessions.
+func (e *SSHExecutor) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dialing = nil
+	if e.client == nil {
+		return nil
+	}
+	err := e.client.Close()
+	e.client = nil
+	return err
+}
+
+func (e *SSHExecutor) run(ctx context.Context, cmd models.Command, stdout io.Writer, stderr io.Writer) error {
+	session, err := e.session(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+	session.Stdout = stdout
+	session.Stderr = stderr
+	stop := context.AfterFunc(ctx, func() {
+		_ = session.Signal(ssh.SIGKILL)
+		_ = session.Close()
+	})
+	defer stop()
+	return session.Run(shellCommand(cmd))
+}
+
+// session opens a channel on the connection, reconnecting once if the connection is gone.
+func (e *SSHExecutor) session(ctx context.Context) (*ssh.Session, error) {
+	client, err := e.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := client.NewSession()
+	if err == nil {
+		return session, nil
+	}
+	e.drop(client)
+	if client, err = e.connect(ctx); err != nil {
+		return nil, err
+	}
+	return client.NewSession()
+}
+
+// connect returns the connection, opening it if needed. Only one command dials at a time;
+// the others wait for it, or until their ctx is done. A dial abandoned because the dialing
+// command was canceled or timed out is retried by the next waiter.
+func (e *SSHExecutor) connect(ctx context.Context) (*ssh.Client, error) {
+	for {
+		e.mu.Lock()
+		if e.client != nil {
+			client := e.client
+			e.mu.Unlock()
+			return client, nil
+		}
+		pending := e.dialing
+		if pending == nil {
+			pending = &sshDial{done: make(chan struct{})}
+			e.dialing = pending
+			e.mu.Unlock()
+			pending.client, pending.err = e.dial(ctx)
+			pending.abandoned = pending.err != nil && ctx.Err() != nil
+			e.mu.Lock()
+			if e.dialing == pending {
+				e.dialing = nil
+				e.client = pending.client
+			} else if pending.client != nil {
+				// Close ran while dialing.
+				_ = pending.client.Close()
+				pending.client, pending.err = nil, fmt.Errorf("ssh %s: connection closed", e.config.Address)
+			}
+			e.mu.Unlock()
+			close(pending.done)
+			return pending.client, pending.err
+		}
+		e.mu.Unlock()
+
+		select {
+		case <-pending.done:
+			if !pending.abandoned {
+				return pending.client, pending.err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// dial opens a connection and authenticates, giving up when ctx is done.
+func (e *SSHExecutor) dial(ctx context.Context) (*ssh.Client, error) {
+	signers := e.config.Signers
+	if e.config.AgentSocket != "" {
+		// The agent is only needed while authenticating.
+		if conn, err := net.Dial("unix", e.config.AgentSocket); err == nil {
+			defer func() {
+				_ = conn.Close()
+			}()
+			if agentSigners, err := agent.NewClient(conn).Signers(); err == nil {
+				signers = append(agentSigners, signers...)
+			}
+		}
+	}
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("ssh %s: the SSH agent has no keys", e.config.Address)
+	}
+	clientConfig := &ssh.ClientConfig{
+		User:            e.config.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback: e.config.HostKey,
+		Timeout:         sshDialTimeout,
+	}
+
+	dialer := net.Dialer{Timeout: sshDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("ssh %s: %w", e.config.Address, err)
+	}
+	// The handshake takes no context, so closing the connection ends it.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	clientConn, channels, requests, err := ssh.NewClientConn(conn, e.config.Address, clientConfig)
+	if !stop() {
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = conn.Close()
+		if clientConn != nil {
+			_ = clientConn.Close()
+		}
+		return nil, fmt.Errorf("ssh %s: %w", e.config.Address, err)
+	}
+	return ssh.NewClient(clientConn, channels, requests), nil
+}
+
+// drop forgets client if it is still the current connection.
+func (e *SSHExecutor) drop(client *ssh.Client) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.client == client {
+		_ = e.client.Close()
+		e.client = nil
+	}
+}
+
+func sshResult(ctx context.Context, start time.Time, stdout string, stderr string, err error) (models.Result, error) {
+	result := models.Result{Stdout: stdout, Stderr: stderr, Duration: time.Since(start)}
+	if ctx.Err() != nil {
+		interrupted, interruptErr := interruptedResult(ctx, result.Duration)
+		interrupted.Stdout = result.Stdout
+		interrupted.Stderr = result.Stderr
+		return interrupted, interruptErr
+	}
+	if err != nil {
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitStatus()
+		} else {
+			result.ExitCode = -1
+		}
+		result.Status = models.ResultError
+		return result, err
+	}
+	result.Status = models.ResultSuccess
+	return result, nil
+}
+
+// sshProcess is an interactive session on an SSHExecutor's connection.
+type sshProcess struct {
+	executor *SSHExecutor
+	cmd      models.Command
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func (p *sshProcess) SetStdin(r io.Reader)  { p.stdin = r }
+func (p *sshProcess) SetStdout(w io.Writer) { p.stdout = w }
+func (p *sshProcess) SetStderr(w io.Writer) { p.stderr = w }
+
+// Run runs the session until the remote command exits.
+func (p *sshProcess) Run() error {
+	session, err := p.executor.session(context.Background())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+	session.Stdin = p.stdin
+	session.Stdout = p.stdout
+	session.Stderr = p.stderr
+
+	if file, ok := p.stdin.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		fd := int(file.Fd())
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+		if state, err := term.MakeRaw(fd); err == nil {
+			defer func() {
+				_ = term.Restore(fd, state)
+			}()
+		}
+		terminal := os.Getenv("TERM")
+		if terminal == "" {
+			terminal = "xterm-256color"
+		}
+		if err := session.RequestPty(terminal, height, width, ssh.TerminalModes{ssh.ECHO: 1}); err != nil {
+			return fmt.Errorf("ssh %s: %w", p.executor.config.Address, err)
+		}
+	}
+	return session.Run(shellCommand(p.cmd))
+}
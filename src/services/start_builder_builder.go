@@ -0,0 +1,16 @@
+package services
+
+import "container-tui/src/models"
+
+// StartBuilderBuilder builds a command that starts the image builder.
+type StartBuilderBuilder struct{}
+
+// Validate returns nil because there are no inputs.
+func (StartBuilderBuilder) Validate() error {
+	return nil
+}
+
+// Build returns the builder start command.
+func (StartBuilderBuilder) Build() (models.Command, error) {
+	return models.Command{Executable: "container", Args: []string{"builder", "start"}}, nil
+}
@@ -24,3 +24,8 @@ func (b StopContainerBuilder) Build() (models.Command, error) {
 	containerID, _ := normalizeRequiredToken(b.ContainerID, "container id")
 	return models.Command{Executable: "container", Args: []string{"stop", containerID}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (StopContainerBuilder) GuardedAction() DestructiveAction {
+	return ActionStopContainer
+}
@@ -14,3 +14,8 @@ func (StopDaemonBuilder) Validate() error {
 func (StopDaemonBuilder) Build() (models.Command, error) {
 	return models.Command{Executable: "container", Args: []string{"system", "stop"}}, nil
 }
+
+// GuardedAction declares the confirmation guard for this command.
+func (StopDaemonBuilder) GuardedAction() DestructiveAction {
+	return ActionStopDaemon
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// StreamSource identifies which output pipe produced a line.
+type StreamSource string
+
+const (
+	// StreamStdout marks a line read from stdout.
+	StreamStdout StreamSource = "stdout"
+	// StreamStderr marks a line read from stderr.
+	StreamStderr StreamSource = "stderr"
+)
+
+// StreamLine is one line of output from a running command.
+type StreamLine struct {
+	Source StreamSource
+	Text   string
+}
+
+// StreamingExecutor runs a command and delivers its output line by line while it runs.
+// The returned result carries only a bounded tail of each stream.
+type StreamingExecutor interface {
+	Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error)
+}
+
+// DefaultStreamTailLines bounds how many lines of each stream are kept in the final result.
+const DefaultStreamTailLines = 200
+
+// StreamContext streams cmd through executor. Executors without streaming support run to
+// completion and have their output replayed line by line.
+func StreamContext(ctx context.Context, executor CommandExecutor, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if onLine == nil {
+		onLine = func(StreamLine) {}
+	}
+	if streaming, ok := executor.(StreamingExecutor); ok {
+		return streaming.Stream(ctx, cmd, onLine)
+	}
+	result, err := ExecuteContext(ctx, executor, cmd)
+	replayLines(result.Stdout, StreamStdout, onLine)
+	replayLines(result.Stderr, StreamStderr, onLine)
+	return result, err
+}
+
+func replayLines(output string, source StreamSource, onLine func(StreamLine)) {
+	trimmed := strings.TrimRight(output, "\n")
+	if trimmed == "" {
+		return
+	}
+	for _, line := range strings.Split(trimmed, "\n") {
+		onLine(StreamLine{Source: source, Text: line})
+	}
+}
+
+// streamWaitDelay bounds how long a killed command may hold its output pipes open.
+const streamWaitDelay = 2 * time.Second
+
+// lineWriter splits written bytes into lines, emitting each one and keeping a bounded tail.
+type lineWriter struct {
+	source  StreamSource
+	emit    func(StreamLine)
+	tail    *LineTail
+	pending []byte
+}
+
+func newLineWriter(source StreamSource, tailLines int, emit func(StreamLine)) *lineWriter {
+	return &lineWriter{source: source, emit: emit, tail: NewLineTail(tailLines)}
+}
+
+// Write emits every complete line in data and buffers the remainder.
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.pending = append(w.pending, data...)
+	for {
+		index := bytes.IndexByte(w.pending, '\n')
+		if index < 0 {
+			break
+		}
+		w.emitLine(string(bytes.TrimSuffix(w.pending[:index], []byte("\r"))))
+		w.pending = w.pending[index+1:]
+	}
+	return len(data), nil
+}
+
+// Flush emits any buffered partial line.
+func (w *lineWriter) Flush() {
+	if len(w.pending) == 0 {
+		return
+	}
+	w.emitLine(string(w.pending))
+	w.pending = nil
+}
+
+func (w *lineWriter) emitLine(text string) {
+	w.tail.Add(text)
+	w.emit(StreamLine{Source: w.source, Text: text})
+}
+
+// LineTail keeps the most recent lines written to it.
+type LineTail struct {
+	mu    sync.Mutex
+	limit int
+	lines []string
+}
+
+// NewLineTail creates a tail that keeps at most limit lines.
+func NewLineTail(limit int) *LineTail {
+	if limit <= 0 {
+		limit = DefaultStreamTailLines
+	}
+	return &LineTail{limit: limit}
+}
+
+// Add appends a line, dropping the oldest one when the limit is reached.
+func (t *LineTail) Add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.lines) == t.limit {
+		copy(t.lines, t.lines[1:])
+		t.lines = t.lines[:t.limit-1]
+	}
+	t.lines = append(t.lines, line)
+}
+
+// String returns the kept lines joined by newlines.
+func (t *LineTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.lines) == 0 {
+		return ""
+	}
+	return strings.Join(t.lines, "\n") + "\n"
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"container-tui/src/models"
+)
+
+// CommandTimeouts maps command kinds to their maximum run time. Zero disables the timeout.
+type CommandTimeouts map[CommandKind]time.Duration
+
+// NewCommandTimeouts converts the `command_timeouts` config table into typed timeouts.
+func NewCommandTimeouts(config map[string]time.Duration) CommandTimeouts {
+	timeouts := CommandTimeouts{}
+	for kind, timeout := range config {
+		timeouts[CommandKind(kind)] = timeout
+	}
+	return timeouts
+}
+
+// For returns the timeout for cmd, falling back to the default kind.
+func (t CommandTimeouts) For(cmd models.Command) time.Duration {
+	if timeout, ok := t[ClassifyCommand(cmd)]; ok {
+		return timeout
+	}
+	return t[CommandKindDefault]
+}
+
+// TimeoutExecutor bounds each command by the timeout configured for its kind.
+type TimeoutExecutor struct {
+	delegate CommandExecutor
+	mu       sync.RWMutex
+	timeouts CommandTimeouts
+}
+
+// NewTimeoutExecutor builds a timeout executor.
+func NewTimeoutExecutor(delegate CommandExecutor, timeouts CommandTimeouts) *TimeoutExecutor {
+	return &TimeoutExecutor{delegate: delegate, timeouts: timeouts}
+}
+
+// SetTimeouts replaces the timeouts used for commands started from now on.
+func (t *TimeoutExecutor) SetTimeouts(timeouts CommandTimeouts) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timeouts = timeouts
+}
+
+func (t *TimeoutExecutor) timeoutFor(cmd models.Command) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.timeouts.For(cmd)
+}
+
+// Execute runs the command with its configured timeout.
+func (t *TimeoutExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return t.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command under ctx narrowed by its configured timeout.
+func (t *TimeoutExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	timeout := t.timeoutFor(cmd)
+	if timeout <= 0 {
+		return ExecuteContext(ctx, t.delegate, cmd)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := ExecuteContext(timeoutCtx, t.delegate, cmd)
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout == 0 {
+		timeoutErr.Timeout = timeout
+	}
+	return result, err
+}
+
+// Stream runs the command with its configured timeout.
+func (t *TimeoutExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(StreamLine)) (models.Result, error) {
+	timeout := t.timeoutFor(cmd)
+	if timeout <= 0 {
+		return StreamContext(ctx, t.delegate, cmd, onLine)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := StreamContext(timeoutCtx, t.delegate, cmd, onLine)
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout == 0 {
+		timeoutErr.Timeout = timeout
+	}
+	return result, err
+}
+
+// Unwrap returns the wrapped executor.
+func (t *TimeoutExecutor) Unwrap() CommandExecutor {
+	return t.delegate
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,6 +24,10 @@ type AppModel struct {
 	selectedImage     *models.Image
 	selectedMachine   *models.ContainerMachine
 	navDebugEnabled   bool
+	runner            *services.CancellableExecutor
+	remedies          *remedyTracker
+	notice            string
+	noticeID          int
 
 	containerList   ContainerListScreen
 	containerSub    ContainerSubmenuScreen
@@ -42,18 +47,26 @@ type AppModel struct {
 	filePicker      FilePickerScreen
 	buildScreen     BuildScreen
 	containerExport ContainerExportScreen
+	containerRun    ContainerRunScreen
 	daemonControl   DaemonControlScreen
+	history         HistoryScreen
+	profiles        ProfilesScreen
 	help            HelpScreen
 	spinner         SpinnerModel
 }
 
 // NewAppModel creates the initial app model.
-func NewAppModel(executor services.CommandExecutor, version string) AppModel {
+func NewAppModel(baseExecutor services.CommandExecutor, version string) AppModel {
+	remedies := &remedyTracker{}
+	runner := services.NewCancellableExecutor(&remedyExecutor{delegate: baseExecutor, tracker: remedies})
+	var executor services.CommandExecutor = runner
 	return AppModel{
 		keys:            DefaultKeyMap(),
 		active:          ScreenContainerList,
 		stack:           []ActiveScreen{},
 		navDebugEnabled: os.Getenv("ACTUI_DEBUG_NAV") == "1",
+		runner:          runner,
+		remedies:        remedies,
 		containerList:   NewContainerListScreen(executor),
 		containerSub:    NewContainerSubmenuScreen(executor),
 		containerLogs:   NewContainerLogsScreen(executor),
@@ -72,7 +85,10 @@ func NewAppModel(executor services.CommandExecutor, version string) AppModel {
 		filePicker:      NewFilePickerScreen(executor),
 		buildScreen:     NewBuildScreen(executor, ""),
 		containerExport: NewContainerExportScreen(executor),
+		containerRun:    NewContainerRunScreen(executor),
 		daemonControl:   NewDaemonControlScreen(executor),
+		history:         NewHistoryScreen(executor),
+		profiles:        NewProfilesScreen(),
 		help:            HelpScreen{Version: version},
 		spinner:         NewSpinnerModel(),
 	}
@@ -110,12 +126,26 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.filePicker, _ = m.filePicker.Update(message)
 		m.buildScreen, _ = m.buildScreen.Update(message)
 		m.containerExport, _ = m.containerExport.Update(message)
+		m.containerRun, _ = m.containerRun.Update(message)
 		m.daemonControl, _ = m.daemonControl.Update(message)
+		m.history, _ = m.history.Update(message)
+		m.profiles, _ = m.profiles.Update(message)
 		m.help, _ = m.help.Update(message)
 	case tea.KeyMsg:
-		if keyMatches(message, m.keys.Quit) && !m.machineScreenUsesQForBack() {
+		if keyMatches(message, m.keys.Quit) && !m.machineScreenUsesQForBack() && !m.historySearchActive() && !m.runFormActive() && !m.listInputActive() {
 			return m, tea.Quit
 		}
+		if keyMatches(message, m.keys.Cancel) {
+			if m.isLoading() && m.runner != nil {
+				m.runner.CancelAll()
+			}
+			skipScreenUpdate = true
+		}
+		if keyMatches(message, m.keys.Fix) && !m.isLoading() {
+			if failure := m.offeredRemedy(); failure != nil {
+				return m, m.runRemedy(failure)
+			}
+		}
 	case screenChangeMsg:
 		origin := m.active
 		if message.push {
@@ -198,8 +228,17 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd = m.buildScreen.Init()
 		case ScreenContainerExport:
 			cmd = m.containerExport.Init()
+		case ScreenContainerRun:
+			m.containerRun = m.containerRun.Reset(message.image)
+			cmd = m.containerRun.Init()
 		case ScreenDaemonControl:
 			cmd = m.daemonControl.Init()
+		case ScreenHistory:
+			m.history.loading = true
+			cmd = m.history.Init()
+		case ScreenProfiles:
+			m.profiles = m.profiles.Reset()
+			cmd = m.profiles.Init()
 		case ScreenHelp:
 			cmd = m.help.Init()
 		}
@@ -213,6 +252,49 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.active = ScreenBuild
 		cmd = m.buildScreen.Init()
 		skipScreenUpdate = true
+	case ConfigChangedMsg:
+		m, cmd = m.applyConfigChange(message)
+		skipScreenUpdate = true
+	case profileSwitchedMsg:
+		m, cmd = m.switchProfile(message)
+		skipScreenUpdate = true
+	case profileSavedMsg:
+		m = m.profileSaved(message)
+		skipScreenUpdate = true
+	case streamLinesMsg:
+		m, cmd = m.routeStream(message)
+		skipScreenUpdate = true
+	case buildResultMsg, imagePullResultMsg, containerRunResultMsg:
+		if m.active != streamResultOwner(msg) {
+			m = m.deliverStreamResult(msg)
+			skipScreenUpdate = true
+		}
+	case bulkFinishedMsg:
+		if m.active != message.screen {
+			m = m.deliverBulkResult(message)
+			skipScreenUpdate = true
+		}
+	case capabilitiesDetectedMsg:
+		m, cmd = m.capabilitiesDetected(message)
+		skipScreenUpdate = true
+	case remedyFinishedMsg:
+		m.noticeID++
+		if message.err != nil {
+			m.notice = RenderError(message.failure.Remedy.Label() + " failed: " + services.FormatError(message.err, message.result.Stderr))
+		} else {
+			m.remedies.resolve(message.failure)
+			m.notice = RenderSuccess(remedyDoneNotice(message.failure))
+			id := m.noticeID
+			cmd = tea.Batch(m.initForActive(), tea.Tick(configNoticeDuration, func(time.Time) tea.Msg {
+				return configNoticeExpiredMsg{id: id}
+			}))
+		}
+		skipScreenUpdate = true
+	case configNoticeExpiredMsg:
+		if message.id == m.noticeID {
+			m.notice = ""
+		}
+		skipScreenUpdate = true
 	case BackToListMsg:
 		origin := m.active
 		m.active = m.popView(ScreenContainerList)
@@ -308,10 +390,22 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			updated, updateCmd := m.containerExport.Update(msg)
 			m.containerExport = updated
 			cmd = tea.Batch(cmd, updateCmd)
+		case ScreenContainerRun:
+			updated, updateCmd := m.containerRun.Update(msg)
+			m.containerRun = updated
+			cmd = tea.Batch(cmd, updateCmd)
 		case ScreenDaemonControl:
 			updated, updateCmd := m.daemonControl.Update(msg)
 			m.daemonControl = updated
 			cmd = tea.Batch(cmd, updateCmd)
+		case ScreenHistory:
+			updated, updateCmd := m.history.Update(msg)
+			m.history = updated
+			cmd = tea.Batch(cmd, updateCmd)
+		case ScreenProfiles:
+			updated, updateCmd := m.profiles.Update(msg)
+			m.profiles = updated
+			cmd = tea.Batch(cmd, updateCmd)
 		case ScreenHelp:
 			updated, updateCmd := m.help.Update(msg)
 			m.help = updated
@@ -331,52 +425,117 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// routeStream delivers streamed lines to the screens that run streams, whichever screen is
+// active. Only the screen that owns the stream takes them and asks for more, so a build or
+// pull keeps draining its output while Help or another screen is shown.
+func (m AppModel) routeStream(msg streamLinesMsg) (AppModel, tea.Cmd) {
+	var build, pull, run, containerLogs, machineLogs tea.Cmd
+	m.buildScreen, build = m.buildScreen.Update(msg)
+	m.imagePull, pull = m.imagePull.Update(msg)
+	m.containerRun, run = m.containerRun.Update(msg)
+	m.containerLogs, containerLogs = m.containerLogs.Update(msg)
+	m.machineLogs, machineLogs = m.machineLogs.Update(msg)
+	return m, tea.Batch(build, pull, run, containerLogs, machineLogs)
+}
+
+// streamResultOwner returns the screen that started the stream a result message ends.
+func streamResultOwner(msg tea.Msg) ActiveScreen {
+	switch msg.(type) {
+	case buildResultMsg:
+		return ScreenBuild
+	case imagePullResultMsg:
+		return ScreenImagePull
+	default:
+		return ScreenContainerRun
+	}
+}
+
+// deliverStreamResult hands a finished stream to its screen while another screen is shown.
+// The screen's follow-up navigation is dropped so the user is not moved away from where
+// they are; the result is there when they return.
+func (m AppModel) deliverStreamResult(msg tea.Msg) AppModel {
+	switch streamResultOwner(msg) {
+	case ScreenBuild:
+		m.buildScreen, _ = m.buildScreen.Update(msg)
+	case ScreenImagePull:
+		m.imagePull, _ = m.imagePull.Update(msg)
+	default:
+		m.containerRun, _ = m.containerRun.Update(msg)
+	}
+	return m
+}
+
+// deliverBulkResult updates the list a bulk action was started from while another screen
+// is shown. The list reloads when it is next opened, so the refresh it asks for is dropped.
+func (m AppModel) deliverBulkResult(message bulkFinishedMsg) AppModel {
+	switch message.screen {
+	case ScreenImageList:
+		m.imageList, _ = m.imageList.Update(message)
+	case ScreenMachineList:
+		m.machineList, _ = m.machineList.Update(message)
+	default:
+		m.containerList, _ = m.containerList.Update(message)
+		m.containerList.hasLoaded = false
+	}
+	return m
+}
+
 // View renders the UI.
 func (m AppModel) View() string {
 	left, right := m.statusBarInfo()
 	status := RenderStatusBar(m.width, left, right)
+	return m.screenView() + "\n" + m.remedyView() + status
+}
 
+// screenView renders the active screen.
+func (m AppModel) screenView() string {
 	switch m.active {
 	case ScreenContainerSubmenu:
-		return m.containerSub.View() + "\n" + status
+		return m.containerSub.View()
 	case ScreenContainerLogs:
-		return m.containerLogs.View() + "\n" + status
+		return m.containerLogs.View()
 	case ScreenContainerShell:
-		return m.containerShell.View() + "\n" + status
+		return m.containerShell.View()
 	case ScreenImageList:
-		return m.imageList.View() + "\n" + status
+		return m.imageList.View()
 	case ScreenImageSubmenu:
-		return m.imageSub.View() + "\n" + status
+		return m.imageSub.View()
 	case ScreenImageInspect:
-		return m.imageInspect.View() + "\n" + status
+		return m.imageInspect.View()
 	case ScreenImagePull:
-		return m.imagePull.View() + "\n" + status
+		return m.imagePull.View()
 	case ScreenRegistries:
-		return m.registries.View() + "\n" + status
+		return m.registries.View()
 	case ScreenMachineList:
-		return m.machineList.View() + "\n" + status
+		return m.machineList.View()
 	case ScreenMachineSubmenu:
-		return m.machineSub.View() + "\n" + status
+		return m.machineSub.View()
 	case ScreenMachineInspect:
-		return m.machineInspect.View() + "\n" + status
+		return m.machineInspect.View()
 	case ScreenMachineLogs:
-		return m.machineLogs.View() + "\n" + status
+		return m.machineLogs.View()
 	case ScreenMachineEditResources:
-		return m.machineEditRes.View() + "\n" + status
+		return m.machineEditRes.View()
 	case ScreenMachineCreate:
-		return m.machineCreate.View() + "\n" + status
+		return m.machineCreate.View()
 	case ScreenFilePicker:
-		return m.filePicker.View() + "\n" + status
+		return m.filePicker.View()
 	case ScreenBuild:
-		return m.buildScreen.View() + "\n" + status
+		return m.buildScreen.View()
 	case ScreenContainerExport:
-		return m.containerExport.View() + "\n" + status
+		return m.containerExport.View()
+	case ScreenContainerRun:
+		return m.containerRun.View()
 	case ScreenDaemonControl:
-		return m.daemonControl.View() + "\n" + status
+		return m.daemonControl.View()
+	case ScreenHistory:
+		return m.history.View()
+	case ScreenProfiles:
+		return m.profiles.View()
 	case ScreenHelp:
-		return m.help.View() + "\n" + status
+		return m.help.View()
 	default:
-		return m.containerList.View() + "\n" + status
+		return m.containerList.View()
 	}
 }
 
@@ -389,7 +548,7 @@ func (m AppModel) statusBarInfo() (string, string) {
 	case ScreenContainerSubmenu:
 		label = "Container Actions"
 		if m.containerSub.preview != nil {
-			preview = m.containerSub.preview.Command.String()
+			preview = displayCommand(m.containerSub.preview.Command)
 		}
 	case ScreenContainerLogs:
 		label = "Container Logs"
@@ -397,6 +556,9 @@ func (m AppModel) statusBarInfo() (string, string) {
 		label = "Container Shell"
 	case ScreenImageList:
 		label = "Images"
+		if m.imageList.bulk != nil {
+			preview = m.imageList.bulk.summary()
+		}
 	case ScreenImageSubmenu:
 		label = "Image Actions"
 	case ScreenImageInspect:
@@ -404,18 +566,21 @@ func (m AppModel) statusBarInfo() (string, string) {
 	case ScreenImagePull:
 		label = "Pull Image"
 		if m.imagePull.preview != nil {
-			preview = m.imagePull.preview.Command.String()
+			preview = displayCommand(m.imagePull.preview.Command)
 		}
 	case ScreenRegistries:
 		label = "Registries"
 	case ScreenMachineList:
 		label = "Machines"
+		if m.machineList.bulk != nil {
+			preview = m.machineList.bulk.summary()
+		}
 	case ScreenMachineSubmenu:
 		label = "Machine Actions"
 		if m.machineSub.preview != nil {
-			preview = m.machineSub.preview.Command.String()
+			preview = displayCommand(m.machineSub.preview.Command)
 		} else if m.machineSub.confirm != nil {
-			preview = m.machineSub.confirm.Command.String()
+			preview = displayCommand(m.machineSub.confirm.Command)
 		}
 	case ScreenMachineInspect:
 		label = "Machine Inspect"
@@ -424,57 +589,89 @@ func (m AppModel) statusBarInfo() (string, string) {
 	case ScreenMachineEditResources:
 		label = "Machine Resources"
 		if m.machineEditRes.preview != nil {
-			preview = m.machineEditRes.preview.Command.String()
+			preview = displayCommand(m.machineEditRes.preview.Command)
 		}
 	case ScreenMachineCreate:
 		label = "Create Machine"
 		if m.machineCreate.preview != nil {
-			preview = m.machineCreate.preview.Command.String()
+			preview = displayCommand(m.machineCreate.preview.Command)
 		}
 	case ScreenFilePicker:
 		label = "File Picker"
 	case ScreenBuild:
 		label = "Build"
 		if m.buildScreen.preview != nil {
-			preview = m.buildScreen.preview.Command.String()
+			preview = displayCommand(m.buildScreen.preview.Command)
 		}
 	case ScreenContainerExport:
 		label = "Export Container"
 		if m.containerExport.preview != nil {
 			if len(m.containerExport.preview.Commands) > 0 {
-				preview = m.containerExport.preview.Commands[0].String()
+				preview = displayCommand(m.containerExport.preview.Commands[0])
 			} else {
-				preview = m.containerExport.preview.Command.String()
+				preview = displayCommand(m.containerExport.preview.Command)
 			}
 		}
+	case ScreenContainerRun:
+		label = "Run Container"
+		if m.containerRun.preview != nil {
+			preview = displayCommand(m.containerRun.preview.Command)
+		}
 	case ScreenDaemonControl:
 		label = "Daemon"
 		if m.daemonControl.confirm != nil {
-			preview = m.daemonControl.confirm.Command.String()
+			preview = displayCommand(m.daemonControl.confirm.Command)
 		}
+	case ScreenHistory:
+		label = "History"
+		if m.history.preview != nil {
+			preview = displayCommand(m.history.preview.Command)
+		} else if m.history.confirm != nil {
+			preview = displayCommand(m.history.confirm.Command)
+		}
+	case ScreenProfiles:
+		label = "Profiles"
 	case ScreenHelp:
 		label = "Help"
 	default:
 		label = "Containers"
-		if m.containerList.preview != nil {
-			preview = m.containerList.preview.Command.String()
+		if m.containerList.bulk != nil {
+			preview = m.containerList.bulk.summary()
+		} else if m.containerList.preview != nil {
+			preview = displayCommand(m.containerList.preview.Command)
 		} else if m.containerList.confirm != nil {
-			preview = m.containerList.confirm.Command.String()
+			preview = displayCommand(m.containerList.confirm.Command)
 		}
 	}
 
+	if name := activeProfileName(); name != "" {
+		label = "[" + name + "] " + label
+	}
 	left := label
 	if spinner != "" {
-		left = spinner + " " + label
+		if retry, ok := m.retryStatus(); ok {
+			label += " " + retry.String()
+		}
+		left = spinner + " " + label + " (" + m.keys.Cancel.Help().Key + " to cancel)"
 	}
 	left = RenderMuted(left)
 	if preview != "" {
 		preview = RenderMuted("Preview: " + preview)
+	} else if m.notice != "" {
+		preview = m.notice
 	}
 
 	return left, preview
 }
 
+// retryStatus returns the retry of a failed read-only command that is in progress.
+func (m AppModel) retryStatus() (services.RetryState, bool) {
+	if m.runner == nil {
+		return services.RetryState{}, false
+	}
+	return services.RetryStatus(m.runner)
+}
+
 func (m AppModel) isLoading() bool {
 	switch m.active {
 	case ScreenContainerLogs:
@@ -503,8 +700,12 @@ func (m AppModel) isLoading() bool {
 		return m.buildScreen.loading
 	case ScreenContainerExport:
 		return m.containerExport.loading
+	case ScreenContainerRun:
+		return m.containerRun.loading
 	case ScreenDaemonControl:
 		return m.daemonControl.loading
+	case ScreenHistory:
+		return m.history.loading
 	default:
 		return m.containerList.loading
 	}
@@ -569,8 +770,14 @@ func (m AppModel) initForActive() tea.Cmd {
 		return m.buildScreen.Init()
 	case ScreenContainerExport:
 		return m.containerExport.Init()
+	case ScreenContainerRun:
+		return m.containerRun.Init()
 	case ScreenDaemonControl:
 		return m.daemonControl.Init()
+	case ScreenHistory:
+		return m.history.Init()
+	case ScreenProfiles:
+		return m.profiles.Init()
 	case ScreenHelp:
 		return m.help.Init()
 	default:
@@ -595,3 +802,29 @@ func (m AppModel) machineScreenUsesQForBack() bool {
 		return false
 	}
 }
+
+// historySearchActive reports whether the history screen is capturing typed text, for a
+// search or a type-to-confirm prompt.
+func (m AppModel) historySearchActive() bool {
+	return m.active == ScreenHistory && (m.history.searching || m.history.confirm != nil)
+}
+
+// runFormActive reports whether the run form is capturing typed text.
+func (m AppModel) runFormActive() bool {
+	return m.active == ScreenContainerRun && m.containerRun.preview == nil && !m.containerRun.loading
+}
+
+// listInputActive reports whether a list is capturing typed text, for a selection filter
+// or an export destination.
+func (m AppModel) listInputActive() bool {
+	switch m.active {
+	case ScreenContainerList:
+		return m.containerList.selection.filtering || m.containerList.exporting
+	case ScreenImageList:
+		return m.imageList.selection.filtering || m.imageList.exporting
+	case ScreenMachineList:
+		return m.machineList.selection.filtering
+	default:
+		return false
+	}
+}
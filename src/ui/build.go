@@ -1,8 +1,8 @@
 package ui
 
 import (
-	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -25,12 +25,16 @@ type BuildScreen struct {
 	context      string
 	input        textinput.Model
 	pullLatest   bool
+	presets      []string
+	preset       int
 	preview      *CommandPreviewModal
 	loading      bool
 	errorMsg     string
 	result       *models.Result
 	viewport     viewport.Model
 	progress     ProgressModel
+	stream       *commandStream
+	lines        []string
 	width        int
 	height       int
 }
@@ -42,19 +46,56 @@ func NewBuildScreen(executor services.CommandExecutor, filePath string) BuildScr
 	input.Prompt = "Tag: "
 	input.Focus()
 
+	config := effectiveConfig.Config
+	context := buildContextFor(filePath)
+	if config.DefaultTagPattern != "" {
+		input.SetValue(expandTag(config.DefaultTagPattern, context))
+	}
+
 	viewportModel := viewport.New(0, 0)
 	return BuildScreen{
 		executor:     executor,
 		returnTarget: ScreenContainerList,
 		filePath:     filePath,
-		context:      filepath.Dir(filePath),
+		context:      context,
 		input:        input,
-		pullLatest:   true,
+		pullLatest:   config.PullLatest,
+		presets:      buildPresetNames(),
+		preset:       -1,
 		viewport:     viewportModel,
 		progress:     NewProgressModel(),
 	}
 }
 
+func expandTag(pattern string, context string) string {
+	return services.ExpandTagPattern(pattern, services.TagPatternValues{Project: projectDir(), Context: context, Now: time.Now()})
+}
+
+// nextPreset applies the next build preset. Fields the preset leaves empty keep their
+// current value; a new build file without a context uses the default context for it.
+func (m BuildScreen) nextPreset() BuildScreen {
+	if len(m.presets) == 0 {
+		return m
+	}
+	m.preset = (m.preset + 1) % len(m.presets)
+	preset := effectiveConfig.Config.BuildPresets[m.presets[m.preset]]
+	if preset.File != "" {
+		m.filePath = preset.File
+		m.context = buildContextFor(preset.File)
+	}
+	if preset.Context != "" {
+		m.context = preset.Context
+	}
+	if preset.Tag != "" {
+		m.input.SetValue(expandTag(preset.Tag, m.context))
+	}
+	if preset.PullLatest != nil {
+		m.pullLatest = *preset.PullLatest
+	}
+	m.errorMsg = ""
+	return m
+}
+
 // SetReturnTarget sets the screen to return to for Esc and post-success flow.
 func (m BuildScreen) SetReturnTarget(target ActiveScreen) BuildScreen {
 	m.returnTarget = target
@@ -74,17 +115,25 @@ func (m BuildScreen) Update(msg tea.Msg) (BuildScreen, tea.Cmd) {
 		m.height = message.Height
 		m.viewport.Width = message.Width - 4
 		m.viewport.Height = max(3, message.Height-12)
+	case streamLinesMsg:
+		if !m.stream.owns(message) {
+			return m, nil
+		}
+		m.lines = appendStreamLines(m.lines, message.lines)
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		m.viewport.GotoBottom()
+		return m, m.stream.next()
 	case buildResultMsg:
 		m.loading = false
 		if message.err != nil {
 			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
 			m.result = &message.result
-			m.viewport.SetContent(RenderResult(message.result))
+			m.viewport.SetContent(renderStreamResult(m.lines, message.result))
 			m.progress.SetPercent(1)
 			return m, nil
 		}
 		m.result = &message.result
-		m.viewport.SetContent(RenderResult(message.result))
+		m.viewport.SetContent(renderStreamResult(m.lines, message.result))
 		m.progress.SetPercent(1)
 		if m.returnTarget != ScreenContainerList {
 			target := m.returnTarget
@@ -98,6 +147,11 @@ func (m BuildScreen) Update(msg tea.Msg) (BuildScreen, tea.Cmd) {
 				previewed := m.preview.Command
 				m.preview = nil
 				m.loading = true
+				m.errorMsg = ""
+				m.result = nil
+				m.lines = nil
+				m.viewport.SetContent("")
+				m.stream = newCommandStream()
 				m.progress.SetPercent(0)
 				return m, m.executeCommandCmd(previewed)
 			case "n", "esc":
@@ -107,14 +161,20 @@ func (m BuildScreen) Update(msg tea.Msg) (BuildScreen, tea.Cmd) {
 			return m, nil
 		}
 
+		if key := message.String(); m.loading && key != "esc" && key != "?" {
+			return m, nil
+		}
 		switch message.String() {
 		case "esc":
+			m.stream.stop()
 			return m, func() tea.Msg { return BackToListMsg{} }
 		case "?":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
 		case "p":
 			m.pullLatest = !m.pullLatest
 			return m, nil
+		case "tab":
+			return m.nextPreset(), nil
 		case "enter":
 			if strings.TrimSpace(m.filePath) == "" {
 				m.errorMsg = "no build file selected"
@@ -145,10 +205,17 @@ func (m BuildScreen) Update(msg tea.Msg) (BuildScreen, tea.Cmd) {
 func (m BuildScreen) View() string {
 	builder := strings.Builder{}
 	builder.WriteString(RenderTitle("Build Image") + "\n\n")
+	if m.preset >= 0 {
+		builder.WriteString(RenderAccent("Preset: "+m.presets[m.preset]) + "\n")
+	}
 	if m.filePath != "" {
-		builder.WriteString(RenderMuted("File: "+m.filePath) + "\n\n")
+		builder.WriteString(RenderMuted("File: "+m.filePath) + "\n")
+		builder.WriteString(RenderMuted("Context: "+m.context) + "\n\n")
 	}
 	builder.WriteString(m.input.View() + "\n")
+	if hint := referenceHint(m.input.Value()); hint != "" {
+		builder.WriteString(hint + "\n")
+	}
 	checkbox := "[ ] Pull latest base images"
 	if m.pullLatest {
 		checkbox = "[x] Pull latest base images"
@@ -167,17 +234,23 @@ func (m BuildScreen) View() string {
 		builder.WriteString("\n")
 		builder.WriteString(m.preview.View())
 	}
-	if m.result != nil {
+	if m.result != nil || len(m.lines) > 0 {
 		builder.WriteString("\n\n")
 		builder.WriteString(m.viewport.View())
 	}
-	builder.WriteString("\n" + RenderMuted("Keys: enter=preview, p=toggle pull, ?=help, esc=back") + "\n")
+	keys := "Keys: enter=preview, p=toggle pull, ?=help, esc=back"
+	if len(m.presets) > 0 {
+		keys = "Keys: enter=preview, p=toggle pull, tab=next preset, ?=help, esc=back"
+	}
+	if m.loading {
+		keys = "Keys: esc=cancel build and back"
+	}
+	builder.WriteString("\n" + RenderMuted(keys) + "\n")
 	return builder.String()
 }
 
 func (m BuildScreen) executeCommandCmd(command models.Command) tea.Cmd {
-	return func() tea.Msg {
-		result, err := m.executor.Execute(command)
+	return m.stream.start(m.executor, command, func(result models.Result, err error) tea.Msg {
 		return buildResultMsg{result: result, err: err}
-	}
+	})
 }
@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// listSelection tracks the items of a list marked for a bulk action. Items are keyed by id,
+// so marks survive a refresh that reorders the list. While filtering, the typed text picks
+// the items to mark by name.
+type listSelection struct {
+	marked    map[string]bool
+	filtering bool
+	query     string
+}
+
+// isMarked reports whether the item with id is marked.
+func (s listSelection) isMarked(id string) bool {
+	return s.marked[id]
+}
+
+// count returns how many items are marked.
+func (s listSelection) count() int {
+	return len(s.marked)
+}
+
+// toggle marks or unmarks the item with id.
+func (s listSelection) toggle(id string) listSelection {
+	marked := s.copyMarks()
+	if marked[id] {
+		delete(marked, id)
+	} else {
+		marked[id] = true
+	}
+	s.marked = marked
+	return s
+}
+
+// toggleAll marks every item, or clears the marks when every item is already marked.
+func (s listSelection) toggleAll(ids []string) listSelection {
+	all := len(ids) > 0
+	for _, id := range ids {
+		all = all && s.marked[id]
+	}
+	if all {
+		return s.clear()
+	}
+	marked := s.copyMarks()
+	for _, id := range ids {
+		marked[id] = true
+	}
+	s.marked = marked
+	return s
+}
+
+// clear removes every mark.
+func (s listSelection) clear() listSelection {
+	s.marked = nil
+	return s
+}
+
+// retain drops the marks of items no longer listed.
+func (s listSelection) retain(ids []string) listSelection {
+	listed := map[string]bool{}
+	for _, id := range ids {
+		listed[id] = true
+	}
+	marked := map[string]bool{}
+	for id := range s.marked {
+		if listed[id] {
+			marked[id] = true
+		}
+	}
+	s.marked = marked
+	return s
+}
+
+// keepFailed marks only the items whose bulk action failed, so it can be retried.
+func (s listSelection) keepFailed(outcomes []services.BulkOutcome) listSelection {
+	marked := map[string]bool{}
+	for _, outcome := range outcomes {
+		if !outcome.Succeeded() {
+			marked[outcome.Name] = true
+		}
+	}
+	s.marked = marked
+	return s
+}
+
+// matching returns the ids of the items whose name contains the query, ignoring case.
+func (s listSelection) matching(ids, names []string) []string {
+	query := strings.ToLower(strings.TrimSpace(s.query))
+	matches := []string{}
+	for i, id := range ids {
+		if query != "" && strings.Contains(strings.ToLower(names[i]), query) {
+			matches = append(matches, id)
+		}
+	}
+	return matches
+}
+
+// handleFilterKey edits the filter. Enter marks the matching items in addition to those
+// already marked; esc leaves the marks as they were.
+func (s listSelection) handleFilterKey(message tea.KeyMsg, ids, names []string) listSelection {
+	switch message.Type {
+	case tea.KeyEnter:
+		marked := s.copyMarks()
+		for _, id := range s.matching(ids, names) {
+			marked[id] = true
+		}
+		s.marked = marked
+		s.filtering = false
+		s.query = ""
+	case tea.KeyEsc:
+		s.filtering = false
+		s.query = ""
+	case tea.KeyBackspace:
+		if s.query != "" {
+			runes := []rune(s.query)
+			s.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		s.query += string(message.Runes)
+	}
+	return s
+}
+
+// label prefixes a name with its mark while any item is marked.
+func (s listSelection) label(id, name string) string {
+	if s.count() == 0 {
+		return name
+	}
+	if s.marked[id] {
+		return "* " + name
+	}
+	return "  " + name
+}
+
+// View renders the filter being typed, or how many items are marked.
+func (s listSelection) View(ids, names []string) string {
+	if s.filtering {
+		return RenderAccent(fmt.Sprintf("Select matching: %s_  (%d items)", s.query, len(s.matching(ids, names)))) + "\n" +
+			RenderMuted("Type part of a name, enter=mark matches, esc=cancel") + "\n"
+	}
+	if s.count() > 0 {
+		return RenderAccent(fmt.Sprintf("%d selected", s.count())) + "\n"
+	}
+	return ""
+}
+
+func (s listSelection) copyMarks() map[string]bool {
+	marked := make(map[string]bool, len(s.marked)+1)
+	for id := range s.marked {
+		marked[id] = true
+	}
+	return marked
+}
+
+// newExportInput returns the destination prompt of an export from a list.
+func newExportInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "."
+	input.Prompt = "Export to directory: "
+	return input
+}
+
+// bulkPlan collects the commands of a bulk action. Items that cannot take the action, or
+// whose commands the execution policy blocks, are skipped with the reason.
+type bulkPlan struct {
+	verb    string
+	noun    string
+	action  services.DestructiveAction
+	items   []services.BulkItem
+	skipped []string
+	// expectedOne is what a type-to-confirm prompt for a single item asks for instead of
+	// its name.
+	expectedOne string
+}
+
+// add plans the commands for the item with id, or skips it when err is set.
+func (p *bulkPlan) add(executor services.CommandExecutor, id string, commands []models.Command, err error) {
+	if err != nil {
+		p.skip(id, err.Error())
+		return
+	}
+	for _, command := range commands {
+		if reason := policyBlockReason(executor, command, nil); reason != "" {
+			p.skip(id, reason)
+			return
+		}
+	}
+	p.items = append(p.items, services.BulkItem{Name: id, Commands: commands})
+}
+
+// skip records an item left out of the action.
+func (p *bulkPlan) skip(id, reason string) {
+	p.skipped = append(p.skipped, id+": "+reason)
+}
+
+// prompt returns one preview of every command, or the type-to-confirm prompt the policy
+// requires for a guarded action. Both are nil when the policy runs the action unprompted.
+func (p bulkPlan) prompt() (*CommandPreviewModal, *TypeToConfirmModal) {
+	commands := services.BulkCommands(p.items)
+	if p.action != "" {
+		switch confirmationModeFor(p.action) {
+		case services.ConfirmNone:
+			return nil, nil
+		case services.ConfirmTypeToConfirm:
+			expected := fmt.Sprintf("%s %d", strings.ToLower(p.verb), len(p.items))
+			if len(p.items) == 1 {
+				expected = p.items[0].Name
+				if p.expectedOne != "" {
+					expected = p.expectedOne
+				}
+			}
+			confirm := NewTypeToConfirmModal(p.title(), expected, commands[0])
+			confirm.Commands = commands
+			return nil, &confirm
+		}
+	}
+	return &CommandPreviewModal{Title: p.title(), Commands: commands}, nil
+}
+
+// title names the action by the items it runs on, such as "Delete 3 Containers".
+func (p bulkPlan) title() string {
+	if len(p.items) == 1 {
+		return fmt.Sprintf("%s 1 %s", p.verb, p.noun)
+	}
+	return fmt.Sprintf("%s %d %ss", p.verb, len(p.items), p.noun)
+}
+
+// summary names the action and how many commands it runs, for the status bar.
+func (p bulkPlan) summary() string {
+	return fmt.Sprintf("%s (%d commands)", p.title(), len(services.BulkCommands(p.items)))
+}
+
+// View lists the skipped items under the prompt.
+func (p bulkPlan) View() string {
+	if len(p.skipped) == 0 {
+		return ""
+	}
+	return RenderWarning("Skipped:") + "\n  " + strings.Join(p.skipped, "\n  ") + "\n"
+}
+
+// busyKey reports whether key is one of a list's actionKeys while the list is loading. Those
+// keys are ignored until the running command or bulk action finishes, so a second one cannot
+// start on top of it.
+func busyKey(loading bool, key string, actionKeys ...string) bool {
+	return loading && slices.Contains(actionKeys, key)
+}
+
+// bulkFinishedMsg reports a bulk action started from screen. The app delivers it to that
+// screen even when another one is shown.
+type bulkFinishedMsg struct {
+	screen ActiveScreen
+	report bulkReport
+}
+
+// bulkReport is the per-item summary of a bulk action.
+type bulkReport struct {
+	title    string
+	outcomes []services.BulkOutcome
+	skipped  []string
+}
+
+// runBulkCmd runs the plan with bounded concurrency and reports back to screen.
+func runBulkCmd(executor services.CommandExecutor, screen ActiveScreen, plan bulkPlan) tea.Cmd {
+	return func() tea.Msg {
+		outcomes := services.RunBulk(context.Background(), executor, plan.items, services.DefaultBulkConcurrency)
+		return bulkFinishedMsg{screen: screen, report: bulkReport{title: plan.title(), outcomes: outcomes, skipped: plan.skipped}}
+	}
+}
+
+// View renders the counts, then one line per item.
+func (r bulkReport) View() string {
+	failed := 0
+	for _, outcome := range r.outcomes {
+		if !outcome.Succeeded() {
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("%s: %d succeeded, %d failed", r.title, len(r.outcomes)-failed, failed)
+	if len(r.skipped) > 0 {
+		summary += fmt.Sprintf(", %d skipped", len(r.skipped))
+	}
+	builder := strings.Builder{}
+	if failed > 0 {
+		builder.WriteString(RenderError(summary) + "\n")
+	} else {
+		builder.WriteString(RenderSuccess(summary) + "\n")
+	}
+	for _, outcome := range r.outcomes {
+		switch {
+		case outcome.Succeeded():
+			builder.WriteString(RenderSuccess("  ok       ") + outcome.Name + "\n")
+		case services.IsCanceled(outcome.Err):
+			builder.WriteString(RenderWarning("  canceled ") + outcome.Name + "\n")
+		default:
+			builder.WriteString(RenderError("  failed   ") + outcome.Name + ": " + services.FormatError(outcome.Err, outcome.Result.Stderr) + "\n")
+		}
+	}
+	for _, skipped := range r.skipped {
+		builder.WriteString(RenderMuted("  skipped  "+skipped) + "\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/services"
+)
+
+// capabilities is what the container CLI supports. The zero value shows every action. It is
+// only read and written on the update loop; commands copy what they need when built.
+var capabilities services.Capabilities
+
+// capabilityService probes the CLI again after a profile switch, or is nil when the backend
+// cannot be probed.
+var capabilityService *services.CapabilityService
+
+// ApplyCapabilities sets the CLI capabilities that decide which actions screens offer, and
+// the service that refreshes them when the profile changes.
+func ApplyCapabilities(detected services.Capabilities, service *services.CapabilityService) {
+	capabilities = detected
+	capabilityService = service
+}
+
+// supports reports whether the CLI has capability; unprobed capabilities count as present.
+func supports(capability services.Capability) bool {
+	return capabilities.Has(capability)
+}
+
+// unsupportedMessage explains why an action is unavailable.
+func unsupportedMessage(capability services.Capability) string {
+	version := "this container CLI"
+	if !capabilities.Version.IsZero() {
+		version = "container CLI " + capabilities.Version.String()
+	}
+	return version + " has no `" + string(capability) + "` command"
+}
+
+type capabilitiesDetectedMsg struct {
+	capabilities services.Capabilities
+	err          error
+}
+
+// detectCapabilitiesCmd probes the CLI of the active profile.
+func detectCapabilitiesCmd() tea.Cmd {
+	if capabilityService == nil {
+		return nil
+	}
+	service := capabilityService
+	return func() tea.Msg {
+		detected, err := service.Detect(context.Background())
+		return capabilitiesDetectedMsg{capabilities: detected, err: err}
+	}
+}
+
+// capabilitiesDetected applies the capabilities probed after a profile switch, or unknown
+// capabilities when the probe failed, and then loads the container list with them. A list
+// that is not shown reloads when it is next opened.
+func (m AppModel) capabilitiesDetected(message capabilitiesDetectedMsg) (AppModel, tea.Cmd) {
+	capabilities = services.Capabilities{}
+	if message.err == nil {
+		capabilities = message.capabilities
+	}
+	if m.active != ScreenContainerList {
+		m.containerList.hasLoaded = false
+		return m, nil
+	}
+	m.containerList.loading = true
+	return m, m.containerList.fetchContainersCmd(true)
+}
@@ -24,7 +24,7 @@ func (m CommandPreviewModal) View() string {
 	}
 	lines := make([]string, 0, len(commands))
 	for _, command := range commands {
-		lines = append(lines, command.String())
+		lines = append(lines, displayCommand(command))
 	}
 	return RenderTitle(title) + "\n\n" + strings.Join(lines, "\n") + "\n\n" + RenderMuted("Confirm (y/n)")
 }
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/services"
+)
+
+// configNoticeDuration is how long a successful reload stays in the status bar. Problems
+// stay until the file is fixed.
+const configNoticeDuration = 5 * time.Second
+
+// ConfigChangedMsg delivers a reloaded config file to the app. Restart lists changed keys
+// that only take effect when actui starts again.
+type ConfigChangedMsg struct {
+	Change  services.ConfigChange
+	Restart []string
+}
+
+type configNoticeExpiredMsg struct {
+	id int
+}
+
+// applyConfigChange re-applies the theme, redaction and confirmation policy from a valid
+// config, or keeps the current settings and explains why.
+func (m AppModel) applyConfigChange(message ConfigChangedMsg) (AppModel, tea.Cmd) {
+	m.noticeID++
+	change := message.Change
+	if !change.OK() {
+		m.notice = RenderWarning(configProblemNotice(change))
+		return m, nil
+	}
+
+	ApplyEffectiveConfig(change.Effective)
+	config := change.Effective.Config
+	ApplyTheme(config.ThemeMode)
+	redactor, _ := services.NewRedactor(config.RedactPatterns)
+	ApplyRedactor(redactor)
+	confirmations, _ := services.NewConfirmationPolicy(config.ConfirmDestructiveActions, config.Confirmations)
+	ApplyConfirmationPolicy(confirmations)
+
+	notice := "Config reloaded"
+	if len(message.Restart) > 0 {
+		notice += "; restart to apply " + strings.Join(message.Restart, ", ")
+	}
+	m.notice = RenderSuccess(notice)
+	id := m.noticeID
+	return m, tea.Tick(configNoticeDuration, func(time.Time) tea.Msg {
+		return configNoticeExpiredMsg{id: id}
+	})
+}
+
+func configProblemNotice(change services.ConfigChange) string {
+	detail := ""
+	switch {
+	case change.Err != nil:
+		detail = change.Err.Error()
+	case len(change.Problems) == 1:
+		detail = change.Problems[0].String()
+	default:
+		detail = fmt.Sprintf("%s (and %d more)", change.Problems[0].String(), len(change.Problems)-1)
+	}
+	return "Config not reloaded, keeping previous settings: " + detail
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+var confirmationPolicy = services.DefaultConfirmationPolicy()
+
+// ApplyConfirmationPolicy sets how guarded actions are confirmed.
+func ApplyConfirmationPolicy(policy services.ConfirmationPolicy) {
+	confirmationPolicy = policy
+}
+
+func confirmationModeFor(action services.DestructiveAction) services.ConfirmationMode {
+	return confirmationPolicy.ModeFor(action)
+}
+
+// guardedPrompt picks the prompt the policy requires for a guarded command. A yes/no
+// confirmation uses the command preview; both results are nil when the command runs unprompted.
+func guardedPrompt(builder services.GuardedBuilder, title, expected string, command models.Command) (*CommandPreviewModal, *TypeToConfirmModal) {
+	return promptForMode(confirmationModeFor(builder.GuardedAction()), title, expected, command)
+}
+
+// promptForMode is guardedPrompt with the confirmation mode already chosen.
+func promptForMode(mode services.ConfirmationMode, title, expected string, command models.Command) (*CommandPreviewModal, *TypeToConfirmModal) {
+	switch mode {
+	case services.ConfirmNone:
+		return nil, nil
+	case services.ConfirmTypeToConfirm:
+		confirm := NewTypeToConfirmModal(title, expected, command)
+		return nil, &confirm
+	default:
+		return &CommandPreviewModal{Title: title, Command: command}, nil
+	}
+}
+
+// guardedYesNo returns the y/n modal for a guarded command, upgraded to type-to-confirm or
+// dropped according to the policy.
+func guardedYesNo(action services.DestructiveAction, modal YesNoConfirmModal, expected string) (*YesNoConfirmModal, *TypeToConfirmModal) {
+	switch confirmationModeFor(action) {
+	case services.ConfirmNone:
+		return nil, nil
+	case services.ConfirmTypeToConfirm:
+		confirm := NewTypeToConfirmModal(modal.Title, expected, modal.Command)
+		return nil, &confirm
+	default:
+		return &modal, nil
+	}
+}
@@ -29,6 +29,7 @@ type ContainerExportScreen struct {
 	plan      *services.ContainerExportPlan
 	preview   *CommandPreviewModal
 	confirm   *YesNoConfirmModal
+	typed     *TypeToConfirmModal
 	loading   bool
 	errorMsg  string
 	result    *models.Result
@@ -50,6 +51,7 @@ func (m ContainerExportScreen) SetContainer(container models.Container) Containe
 	m.result = nil
 	m.preview = nil
 	m.confirm = nil
+	m.typed = nil
 	m.plan = nil
 	m.loading = false
 	m.progress.SetPercent(0)
@@ -76,11 +78,15 @@ func (m ContainerExportScreen) Update(msg tea.Msg) (ContainerExportScreen, tea.C
 		}
 		m.errorMsg = ""
 		if m.plan != nil && m.plan.CleanupCommand.Executable != "" {
-			m.confirm = &YesNoConfirmModal{
+			m.confirm, m.typed = guardedYesNo(m.plan.CleanupAction(), YesNoConfirmModal{
 				Title:   "Delete Temporary Export Image",
 				Body:    fmt.Sprintf("Archive saved to %s\n\nDelete the temporary image %s now?", message.result.ArchivePath, m.plan.GeneratedImageRef),
 				Command: m.plan.CleanupCommand,
 				Warning: true,
+			}, m.plan.CleanupConfirmation())
+			if m.confirm == nil && m.typed == nil {
+				m.loading = true
+				return m, m.executeCleanupCmd(m.plan.CleanupCommand)
 			}
 		}
 		return m, nil
@@ -105,6 +111,21 @@ func (m ContainerExportScreen) Update(msg tea.Msg) (ContainerExportScreen, tea.C
 		m.result.Stdout = strings.TrimSpace(strings.Join([]string{m.result.Stdout, cleanupMessage}, "\n\n"))
 		return m, nil
 	case tea.KeyMsg:
+		if m.typed != nil {
+			updatedTyped, confirmed, canceled := m.typed.Handle(message)
+			m.typed = &updatedTyped
+			if confirmed {
+				command := updatedTyped.Command
+				m.typed = nil
+				m.loading = true
+				return m, m.executeCleanupCmd(command)
+			}
+			if canceled {
+				m.typed = nil
+				m.retainExportImage()
+			}
+			return m, nil
+		}
 		if m.confirm != nil {
 			confirmed, canceled := m.confirm.Handle(message)
 			if confirmed {
@@ -114,10 +135,7 @@ func (m ContainerExportScreen) Update(msg tea.Msg) (ContainerExportScreen, tea.C
 				return m, m.executeCleanupCmd(command)
 			}
 			if canceled {
-				if m.result != nil && m.plan != nil {
-					retained := fmt.Sprintf("Temporary export image retained: %s", m.plan.GeneratedImageRef)
-					m.result.Stdout = strings.TrimSpace(strings.Join([]string{m.result.Stdout, retained}, "\n\n"))
-				}
+				m.retainExportImage()
 				m.confirm = nil
 				return m, nil
 			}
@@ -172,6 +190,7 @@ func (m ContainerExportScreen) View() string {
 	builder.WriteString(RenderMuted("Container: "+m.container.Name+" ("+m.container.ID+")") + "\n\n")
 	builder.WriteString(m.input.View() + "\n")
 	if m.plan != nil {
+		builder.WriteString(RenderMuted("Image: "+m.plan.GeneratedImageRef) + "\n")
 		builder.WriteString(RenderMuted("Archive: "+m.plan.ArchivePath) + "\n")
 	}
 	if m.loading {
@@ -186,6 +205,9 @@ func (m ContainerExportScreen) View() string {
 	if m.preview != nil {
 		builder.WriteString("\n" + m.preview.View())
 	}
+	if m.typed != nil {
+		builder.WriteString("\n" + m.typed.View())
+	}
 	if m.confirm != nil {
 		builder.WriteString("\n" + m.confirm.View())
 	}
@@ -210,3 +232,11 @@ func (m ContainerExportScreen) executeCleanupCmd(command models.Command) tea.Cmd
 		return containerExportCleanupMsg{result: result, err: err}
 	}
 }
+
+// retainExportImage notes in the result that the temporary image was kept.
+func (m ContainerExportScreen) retainExportImage() {
+	if m.result != nil && m.plan != nil {
+		retained := fmt.Sprintf("Temporary export image retained: %s", m.plan.GeneratedImageRef)
+		m.result.Stdout = strings.TrimSpace(strings.Join([]string{m.result.Stdout, retained}, "\n\n"))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"container-tui/src/models"
@@ -33,11 +34,16 @@ type ContainerListScreen struct {
 	confirm    *TypeToConfirmModal
 	pendingCmd *models.Command
 	hasLoaded  bool
+	selection  listSelection
+	exporting  bool
+	exportTo   textinput.Model
+	bulk       *bulkPlan
+	report     *bulkReport
 }
 
 // NewContainerListScreen creates the container list screen.
 func NewContainerListScreen(executor services.CommandExecutor) ContainerListScreen {
-	return ContainerListScreen{executor: executor}
+	return ContainerListScreen{executor: executor, exportTo: newExportInput()}
 }
 
 // Init fetches the initial container list.
@@ -60,6 +66,7 @@ func (m ContainerListScreen) Update(msg tea.Msg) (ContainerListScreen, tea.Cmd)
 		m.errorMsg = ""
 		m.containers = message.containers
 		m.hasLoaded = true
+		m.selection = m.selection.retain(m.containerIDs())
 		if m.cursor >= len(m.containers) {
 			m.cursor = max(0, len(m.containers)-1)
 		}
@@ -71,6 +78,16 @@ func (m ContainerListScreen) Update(msg tea.Msg) (ContainerListScreen, tea.Cmd)
 			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
 		}
 		m.result = &message.result
+		m.report = nil
+		return m, m.fetchContainersCmd(true)
+	case bulkFinishedMsg:
+		if message.screen != ScreenContainerList {
+			return m, nil
+		}
+		m.loading = false
+		m.result = nil
+		m.report = &message.report
+		m.selection = m.selection.keepFailed(message.report.outcomes)
 		return m, m.fetchContainersCmd(true)
 	case tea.KeyMsg:
 		if m.confirm != nil {
@@ -80,10 +97,14 @@ func (m ContainerListScreen) Update(msg tea.Msg) (ContainerListScreen, tea.Cmd)
 				command := updatedConfirm.Command
 				m.confirm = nil
 				m.loading = true
+				if m.bulk != nil {
+					return m.runBulk()
+				}
 				return m, m.executeCommandCmd(command)
 			}
 			if canceled {
 				m.confirm = nil
+				m.bulk = nil
 				return m, nil
 			}
 			return m, nil
@@ -93,17 +114,43 @@ func (m ContainerListScreen) Update(msg tea.Msg) (ContainerListScreen, tea.Cmd)
 			case "y", "enter":
 				previewed := m.preview.Command
 				m.preview = nil
-				m.pendingCmd = &previewed
 				m.loading = true
+				if m.bulk != nil {
+					return m.runBulk()
+				}
+				m.pendingCmd = &previewed
 				return m, m.executeCommandCmd(previewed)
 			case "n", "esc":
 				m.preview = nil
 				m.pendingCmd = nil
+				m.bulk = nil
 				return m, nil
 			}
 			return m, nil
 		}
+		if m.selection.filtering {
+			m.selection = m.selection.handleFilterKey(message, m.containerIDs(), m.containerNames())
+			return m, nil
+		}
+		if m.exporting {
+			switch message.String() {
+			case "esc":
+				m.exporting = false
+				m.exportTo.Blur()
+				return m, nil
+			case "enter":
+				m.exporting = false
+				m.exportTo.Blur()
+				return m.planBulkExport(m.exportTo.Value())
+			}
+			var cmd tea.Cmd
+			m.exportTo, cmd = m.exportTo.Update(message)
+			return m, cmd
+		}
 
+		if busyKey(m.loading, message.String(), "d", "s", "t", "e") {
+			return m, nil
+		}
 		switch message.String() {
 		case "up", "k":
 			m.cursor = max(0, m.cursor-1)
@@ -117,16 +164,49 @@ func (m ContainerListScreen) Update(msg tea.Msg) (ContainerListScreen, tea.Cmd)
 		case "m":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenDaemonControl} }
 		case "M":
+			if !supports(services.CapabilityMachine) {
+				m.errorMsg = unsupportedMessage(services.CapabilityMachine)
+				return m, nil
+			}
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenMachineList, push: true} }
+		case "H":
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHistory, push: true} }
+		case "P":
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenProfiles, push: true} }
 		case "?":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
+		case "n":
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerRun, push: true} }
+		case " ":
+			if selected, ok := m.selectedContainer(); ok {
+				m.selection = m.selection.toggle(selected.ID)
+				m.cursor = min(len(m.containers)-1, m.cursor+1)
+			}
+		case "a":
+			m.selection = m.selection.toggleAll(m.containerIDs())
+		case "/":
+			m.selection.filtering = true
+		case "esc":
+			m.selection = m.selection.clear()
+		case "e":
+			m.exporting = true
+			return m, m.exportTo.Focus()
 		case "d":
+			if m.selection.count() > 0 {
+				return m.planBulk("Delete", services.ActionDeleteContainer)
+			}
 			updated, cmd := m.buildAndConfirmDelete()
 			return updated, cmd
 		case "s":
+			if m.selection.count() > 0 {
+				return m.planBulk("Start", "")
+			}
 			updated, cmd := m.buildAndPreviewStart()
 			return updated, cmd
 		case "t":
+			if m.selection.count() > 0 {
+				return m.planBulk("Stop", services.ActionStopContainer)
+			}
 			updated, cmd := m.buildAndPreviewStop()
 			return updated, cmd
 		case "enter":
@@ -166,7 +246,7 @@ func (m ContainerListScreen) View() string {
 		rows := make([]TableRow, len(m.containers))
 		for i, container := range m.containers {
 			rows[i] = TableRow{
-				Cells:    []string{container.Name, string(container.Status), container.Image},
+				Cells:    []string{m.selection.label(container.ID, container.Name), string(container.Status), container.Image},
 				Selected: i == m.cursor,
 				Data:     &container,
 			}
@@ -181,8 +261,16 @@ func (m ContainerListScreen) View() string {
 	}
 	builder.WriteString(table.Render(tableWidth, m.cursor))
 	builder.WriteString(strings.Repeat("─", tableWidth) + "\n")
+	builder.WriteString(m.selection.View(m.containerIDs(), m.containerNames()))
+	if m.exporting {
+		builder.WriteString(m.exportTo.View() + "\n" + RenderMuted("enter=preview export, esc=cancel") + "\n")
+	}
 
-	builder.WriteString("\n" + RenderMuted("Keys: up/down, enter=submenu, s=start, t=stop, d=delete(!), i=images, M=machines, r=refresh, m=manage, ?=help, q=quit") + "\n")
+	machines := "M=machines, "
+	if !supports(services.CapabilityMachine) {
+		machines = ""
+	}
+	builder.WriteString("\n" + RenderMuted("Keys: up/down, enter=submenu, n=run, s=start, t=stop, d=delete(!), e=export, space=mark, a=mark all, /=mark matching, i=images, "+machines+"H=history, P=profiles, r=refresh, m=manage, ?=help, q=quit") + "\n")
 
 	if m.preview != nil {
 		builder.WriteString("\n")
@@ -194,11 +282,20 @@ func (m ContainerListScreen) View() string {
 		builder.WriteString(m.confirm.View())
 	}
 
+	if m.bulk != nil {
+		builder.WriteString("\n" + m.bulk.View())
+	}
+
 	if m.result != nil {
 		builder.WriteString("\n\n")
 		builder.WriteString(RenderResult(*m.result))
 	}
 
+	if m.report != nil {
+		builder.WriteString("\n\n")
+		builder.WriteString(m.report.View())
+	}
+
 	return builder.String()
 }
 
@@ -228,8 +325,7 @@ func (m ContainerListScreen) buildAndPreviewStop() (ContainerListScreen, tea.Cmd
 		m.errorMsg = err.Error()
 		return m, nil
 	}
-	m.preview = &CommandPreviewModal{Title: "Stop Container", Command: cmd}
-	return m, nil
+	return m.promptGuarded(builder, "Stop Container", containerConfirmName(selected), cmd)
 }
 
 func (m ContainerListScreen) buildAndPreviewToggle() (ContainerListScreen, tea.Cmd) {
@@ -260,22 +356,150 @@ func (m ContainerListScreen) buildAndConfirmDelete() (ContainerListScreen, tea.C
 		return m, nil
 	}
 
-	expected := selected.Name
-	if strings.TrimSpace(expected) == "" {
-		expected = selected.ID
+	return m.promptGuarded(builder, "Delete Container", containerConfirmName(selected), cmd)
+}
+
+// promptGuarded shows the confirmation the policy requires, or runs the command right away.
+func (m ContainerListScreen) promptGuarded(builder services.GuardedBuilder, title, expected string, cmd models.Command) (ContainerListScreen, tea.Cmd) {
+	m.preview, m.confirm = guardedPrompt(builder, title, expected, cmd)
+	if m.preview == nil && m.confirm == nil {
+		m.pendingCmd = &cmd
+		m.loading = true
+		return m, m.executeCommandCmd(cmd)
 	}
+	return m, nil
+}
 
-	confirm := NewTypeToConfirmModal("Delete Container", expected, cmd)
-	m.confirm = &confirm
+// targets returns the marked containers, or the one under the cursor when none is marked.
+func (m *ContainerListScreen) targets() []models.Container {
+	if m.selection.count() == 0 {
+		if selected, ok := m.selectedContainer(); ok {
+			return []models.Container{selected}
+		}
+		return nil
+	}
+	targets := []models.Container{}
+	for _, container := range m.containers {
+		if m.selection.isMarked(container.ID) {
+			targets = append(targets, container)
+		}
+	}
+	return targets
+}
+
+// planBulk prompts for starting, stopping or deleting every marked container. Containers
+// already in the wanted state, or running when deleted, are skipped.
+func (m ContainerListScreen) planBulk(verb string, action services.DestructiveAction) (ContainerListScreen, tea.Cmd) {
+	targets := m.targets()
+	plan := bulkPlan{verb: verb, noun: "Container", action: action}
+	for _, container := range targets {
+		var cmd models.Command
+		var err error
+		switch verb {
+		case "Start":
+			if container.Status == models.ContainerStatusRunning {
+				plan.skip(container.ID, "already running")
+				continue
+			}
+			cmd, err = (services.StartContainerBuilder{ContainerID: container.ID}).Build()
+		case "Stop":
+			if container.Status != models.ContainerStatusRunning {
+				plan.skip(container.ID, "not running")
+				continue
+			}
+			cmd, err = (services.StopContainerBuilder{ContainerID: container.ID}).Build()
+		case "Delete":
+			if container.Status != models.ContainerStatusStopped {
+				plan.skip(container.ID, "container must be stopped to delete")
+				continue
+			}
+			cmd, err = (services.DeleteContainerBuilder{ContainerID: container.ID}).Build()
+		}
+		plan.add(m.executor, container.ID, []models.Command{cmd}, err)
+	}
+	return m.promptBulk(plan)
+}
+
+// planBulkExport prompts for exporting every marked container to an OCI archive in
+// destination. Each export also deletes its intermediate image once the archive is saved.
+func (m ContainerListScreen) planBulkExport(destination string) (ContainerListScreen, tea.Cmd) {
+	targets := m.targets()
+	workflow := services.NewExportWorkflowService(m.executor)
+	plan := bulkPlan{verb: "Export", noun: "Container"}
+	// The export is previewed like a single one. Its cleanup deletes the temporary images,
+	// so a policy that wants typed confirmation for that gets it before anything runs.
+	if confirmationModeFor(services.ActionExportCleanup) == services.ConfirmTypeToConfirm {
+		plan.action = services.ActionExportCleanup
+	}
+	images := map[string]string{}
+	for _, container := range targets {
+		exportPlan, err := workflow.Plan(container, destination)
+		commands := append(exportPlan.Commands, exportPlan.CleanupCommand)
+		plan.add(m.executor, container.ID, commands, err)
+		images[container.ID] = exportPlan.CleanupConfirmation()
+	}
+	if len(plan.items) == 1 {
+		plan.expectedOne = images[plan.items[0].Name]
+	}
+	return m.promptBulk(plan)
+}
+
+// promptBulk shows the one prompt for the plan, or runs it when the policy needs none.
+func (m ContainerListScreen) promptBulk(plan bulkPlan) (ContainerListScreen, tea.Cmd) {
+	m.report = nil
+	m.result = nil
+	if len(plan.items) == 0 {
+		m.errorMsg = "nothing to " + strings.ToLower(plan.verb)
+		if len(plan.skipped) > 0 {
+			m.report = &bulkReport{title: plan.title(), skipped: plan.skipped}
+		}
+		return m, nil
+	}
+	m.errorMsg = ""
+	m.bulk = &plan
+	m.preview, m.confirm = plan.prompt()
+	if m.preview == nil && m.confirm == nil {
+		m.loading = true
+		return m.runBulk()
+	}
 	return m, nil
 }
 
+func (m ContainerListScreen) runBulk() (ContainerListScreen, tea.Cmd) {
+	plan := *m.bulk
+	m.bulk = nil
+	return m, runBulkCmd(m.executor, ScreenContainerList, plan)
+}
+
+func (m ContainerListScreen) containerIDs() []string {
+	ids := make([]string, len(m.containers))
+	for i, container := range m.containers {
+		ids[i] = container.ID
+	}
+	return ids
+}
+
+func (m ContainerListScreen) containerNames() []string {
+	names := make([]string, len(m.containers))
+	for i, container := range m.containers {
+		names[i] = containerConfirmName(container)
+	}
+	return names
+}
+
+func containerConfirmName(container models.Container) string {
+	if strings.TrimSpace(container.Name) == "" {
+		return container.ID
+	}
+	return container.Name
+}
+
 func (m ContainerListScreen) fetchContainersCmd(force bool) tea.Cmd {
 	if m.hasLoaded && !force {
 		return nil
 	}
+	builder := services.ListContainersBuilder{Capabilities: capabilities}
 	return func() tea.Msg {
-		builder := services.ListContainersBuilder{}
 		cmd, err := builder.Build()
 		if err != nil {
 			return containerListLoadedMsg{err: err}
@@ -284,7 +508,7 @@ func (m ContainerListScreen) fetchContainersCmd(force bool) tea.Cmd {
 		if err != nil {
 			return containerListLoadedMsg{err: errors.New(services.FormatError(err, result.Stderr))}
 		}
-		containers, parseErr := services.ParseContainerList(result.Stdout)
+		containers, parseErr := builder.Parse(result.Stdout)
 		if parseErr != nil {
 			return containerListLoadedMsg{err: parseErr}
 		}
@@ -14,13 +14,15 @@ type containerLogsLoadedMsg struct {
 	err    error
 }
 
-// ContainerLogsScreen shows container log output.
+// ContainerLogsScreen follows container log output as it is written.
 type ContainerLogsScreen struct {
 	executor  services.CommandExecutor
 	container models.Container
 	loading   bool
 	errorMsg  string
 	lines     []string
+	stream    *commandStream
+	following bool
 }
 
 func NewContainerLogsScreen(executor services.CommandExecutor) ContainerLogsScreen {
@@ -28,10 +30,13 @@ func NewContainerLogsScreen(executor services.CommandExecutor) ContainerLogsScre
 }
 
 func (m ContainerLogsScreen) SetContainer(container models.Container) ContainerLogsScreen {
+	m.stream.stop()
 	m.container = container
 	m.errorMsg = ""
 	m.lines = []string{}
 	m.loading = true
+	m.following = false
+	m.stream = newCommandStream()
 	return m
 }
 
@@ -44,22 +49,29 @@ func (m ContainerLogsScreen) Init() tea.Cmd {
 
 func (m ContainerLogsScreen) Update(msg tea.Msg) (ContainerLogsScreen, tea.Cmd) {
 	switch message := msg.(type) {
+	case streamLinesMsg:
+		if !m.stream.owns(message) {
+			return m, nil
+		}
+		m.loading = false
+		m.following = true
+		m.lines = appendStreamLines(m.lines, message.lines)
+		return m, m.stream.next()
 	case containerLogsLoadedMsg:
 		m.loading = false
+		m.following = false
 		if message.err != nil {
 			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
 			return m, nil
 		}
-		content := strings.TrimSpace(message.result.Stdout)
-		if content == "" {
+		if len(m.lines) == 0 {
 			m.lines = []string{"No logs available."}
-		} else {
-			m.lines = strings.Split(content, "\n")
 		}
 		return m, nil
 	case tea.KeyMsg:
 		switch message.String() {
 		case "esc":
+			m.stream.stop()
 			containerCopy := m.container
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerSubmenu, container: &containerCopy} }
 		}
@@ -80,21 +92,20 @@ func (m ContainerLogsScreen) View() string {
 	for _, line := range m.lines {
 		builder.WriteString(line + "\n")
 	}
-	builder.WriteString("\n" + RenderMuted("Keys: esc=back") + "\n")
+	if m.following {
+		builder.WriteString("\n" + RenderMuted("Following... (new lines appear as they are written)") + "\n")
+	}
+	builder.WriteString("\n" + RenderMuted("Keys: esc=stop following and back") + "\n")
 	return builder.String()
 }
 
 func (m ContainerLogsScreen) loadLogsCmd() tea.Cmd {
-	return func() tea.Msg {
-		builder := services.ContainerLogsBuilder{ContainerName: m.container.ID}
-		cmd, err := builder.Build()
-		if err != nil {
-			return containerLogsLoadedMsg{err: err}
-		}
-		result, err := m.executor.Execute(cmd)
-		if err != nil {
-			return containerLogsLoadedMsg{result: result, err: err}
-		}
-		return containerLogsLoadedMsg{result: result}
+	builder := services.ContainerLogsBuilder{ContainerName: m.container.ID}
+	cmd, err := builder.Build()
+	if err != nil {
+		return func() tea.Msg { return containerLogsLoadedMsg{err: err} }
 	}
+	return m.stream.start(m.executor, cmd, func(result models.Result, err error) tea.Msg {
+		return containerLogsLoadedMsg{result: result, err: err}
+	})
 }
@@ -0,0 +1,371 @@
+package ui
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// runOutputLines is how many of the latest lines of an attached run are shown.
+const runOutputLines = 10
+
+type containerRunResultMsg struct {
+	result models.Result
+	err    error
+}
+
+// runFormField is one text field of the run form.
+type runFormField struct {
+	field services.RunField
+	label string
+	input textinput.Model
+}
+
+// runFormFields describes the text fields in the order they are shown. List fields take
+// comma-separated values.
+var runFormFields = []struct {
+	field       services.RunField
+	label       string
+	placeholder string
+}{
+	{services.RunFieldImage, "Image", "nginx:latest"},
+	{services.RunFieldName, "Name (optional)", "web"},
+	{services.RunFieldPorts, "Ports", "8080:80, 5353:53/udp"},
+	{services.RunFieldEnv, "Environment", "KEY=value, DEBUG=1"},
+	{services.RunFieldEnvFiles, "Env files", ".env"},
+	{services.RunFieldVolumes, "Volumes", "/srv/data:/data, cache:/cache:ro"},
+	{services.RunFieldCPUs, "CPUs", "2"},
+	{services.RunFieldMemory, "Memory", "1G"},
+	{services.RunFieldWorkdir, "Working directory", "/app"},
+	{services.RunFieldEntrypoint, "Entrypoint", "/bin/sh"},
+	{services.RunFieldCommand, "Command", `-c "echo hello"`},
+	{services.RunFieldLabels, "Labels", "team=web, tier=frontend"},
+}
+
+// ContainerRunScreen collects the options of `container run`, checking each field as it
+// is typed, and runs the container after the usual preview. The detach checkbox follows
+// the text fields.
+type ContainerRunScreen struct {
+	executor  services.CommandExecutor
+	fields    []runFormField
+	detach    bool
+	focus     int
+	submitted bool
+	presets   []string
+	preset    int
+	preview   *CommandPreviewModal
+	loading   bool
+	errorMsg  string
+	result    *models.Result
+	stream    *commandStream
+	lines     []string
+	width     int
+}
+
+// NewContainerRunScreen creates the run screen.
+func NewContainerRunScreen(executor services.CommandExecutor) ContainerRunScreen {
+	return ContainerRunScreen{executor: executor}.Reset(nil)
+}
+
+// Reset clears the form, prefilling the image when one is given.
+func (m ContainerRunScreen) Reset(image *models.Image) ContainerRunScreen {
+	m.fields = make([]runFormField, len(runFormFields))
+	for i, spec := range runFormFields {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = spec.placeholder
+		m.fields[i] = runFormField{field: spec.field, label: spec.label, input: input}
+	}
+	m.detach = true
+	m.focus = 0
+	if image != nil {
+		m.fields[0].input.SetValue(image.Reference())
+		m.focus = 1
+	}
+	m.submitted = false
+	m.presets = runPresetNames()
+	m.preset = -1
+	m.preview = nil
+	m.loading = false
+	m.errorMsg = ""
+	m.result = nil
+	m.stream = nil
+	m.lines = nil
+	m.updateFocus()
+	return m
+}
+
+// Init starts the text input.
+func (m ContainerRunScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles form input and the run.
+func (m ContainerRunScreen) Update(msg tea.Msg) (ContainerRunScreen, tea.Cmd) {
+	switch message := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = message.Width
+		return m, nil
+	case streamLinesMsg:
+		if !m.stream.owns(message) {
+			return m, nil
+		}
+		m.lines = appendStreamLines(m.lines, message.lines)
+		return m, m.stream.next()
+	case containerRunResultMsg:
+		m.loading = false
+		m.result = &message.result
+		if message.err != nil {
+			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
+			return m, nil
+		}
+		if m.detach {
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerList} }
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.preview != nil {
+			switch strings.ToLower(message.String()) {
+			case "y", "enter":
+				previewed := m.preview.Command
+				m.preview = nil
+				m.loading = true
+				m.errorMsg = ""
+				m.result = nil
+				m.lines = nil
+				m.stream = newCommandStream()
+				return m, m.executeCommandCmd(previewed)
+			case "n", "esc":
+				m.preview = nil
+			}
+			return m, nil
+		}
+
+		switch message.String() {
+		case "esc":
+			m.stream.stop()
+			return m, func() tea.Msg { return BackToListMsg{} }
+		case "tab", "down":
+			m.focus = (m.focus + 1) % (len(m.fields) + 1)
+			m.updateFocus()
+			return m, nil
+		case "shift+tab", "up":
+			m.focus = (m.focus + len(m.fields)) % (len(m.fields) + 1)
+			m.updateFocus()
+			return m, nil
+		case "ctrl+p":
+			return m.nextPreset(), nil
+		case " ", "x":
+			if m.focus == len(m.fields) {
+				m.detach = !m.detach
+				return m, nil
+			}
+		case "enter":
+			if m.loading {
+				return m, nil
+			}
+			m.submitted = true
+			builder, problems := m.form()
+			if len(problems) > 0 {
+				m.errorMsg = "fix the highlighted fields"
+				return m, nil
+			}
+			cmd, err := builder.Build()
+			if err != nil {
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			m.errorMsg = ""
+			m.preview = &CommandPreviewModal{Title: "Run Container", Command: cmd}
+			return m, nil
+		}
+	}
+
+	if m.focus >= len(m.fields) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.fields[m.focus].input, cmd = m.fields[m.focus].input.Update(msg)
+	return m, cmd
+}
+
+// form parses the fields into a builder and returns the problems by field, from parsing
+// and from the builder's validation.
+func (m ContainerRunScreen) form() (services.RunContainerBuilder, map[services.RunField]error) {
+	builder := services.RunContainerBuilder{Detach: m.detach}
+	problems := map[services.RunField]error{}
+	for _, field := range m.fields {
+		value := strings.TrimSpace(field.input.Value())
+		var err error
+		switch field.field {
+		case services.RunFieldImage:
+			builder.Image = value
+		case services.RunFieldName:
+			builder.Name = value
+		case services.RunFieldPorts:
+			for _, spec := range splitList(value) {
+				var port models.PortMapping
+				if port, err = models.ParsePortMapping(spec); err != nil {
+					break
+				}
+				builder.Ports = append(builder.Ports, port)
+			}
+		case services.RunFieldEnv:
+			builder.Env = splitList(value)
+		case services.RunFieldEnvFiles:
+			builder.EnvFiles = splitList(value)
+		case services.RunFieldVolumes:
+			for _, spec := range splitList(value) {
+				var mount models.Mount
+				if mount, err = models.ParseMount(spec); err != nil {
+					break
+				}
+				builder.Volumes = append(builder.Volumes, mount)
+			}
+		case services.RunFieldCPUs:
+			if value != "" {
+				if builder.CPUs, err = strconv.Atoi(value); err != nil || builder.CPUs < 1 {
+					builder.CPUs = 0
+					err = errors.New("cpus must be a positive integer")
+				}
+			}
+		case services.RunFieldMemory:
+			builder.Memory = value
+		case services.RunFieldWorkdir:
+			builder.Workdir = value
+		case services.RunFieldEntrypoint:
+			builder.Entrypoint = value
+		case services.RunFieldCommand:
+			builder.Command, err = services.ParseRunCommand(value)
+		case services.RunFieldLabels:
+			builder.Labels = splitList(value)
+		}
+		if err != nil {
+			problems[field.field] = err
+		}
+	}
+	for field, err := range builder.FieldErrors() {
+		if _, ok := problems[field]; !ok {
+			problems[field] = err
+		}
+	}
+	return builder, problems
+}
+
+// nextPreset applies the next run preset. Fields the preset leaves empty keep their value.
+func (m ContainerRunScreen) nextPreset() ContainerRunScreen {
+	if len(m.presets) == 0 {
+		return m
+	}
+	m.preset = (m.preset + 1) % len(m.presets)
+	preset := effectiveConfig.Config.RunPresets[m.presets[m.preset]]
+	values := map[services.RunField]string{
+		services.RunFieldImage:      preset.Image,
+		services.RunFieldName:       preset.Name,
+		services.RunFieldPorts:      strings.Join(preset.Ports, ", "),
+		services.RunFieldEnv:        strings.Join(preset.Env, ", "),
+		services.RunFieldEnvFiles:   strings.Join(preset.EnvFiles, ", "),
+		services.RunFieldVolumes:    strings.Join(preset.Volumes, ", "),
+		services.RunFieldMemory:     preset.Memory,
+		services.RunFieldWorkdir:    preset.Workdir,
+		services.RunFieldEntrypoint: preset.Entrypoint,
+		services.RunFieldCommand:    models.Command{Args: preset.Command}.String(),
+		services.RunFieldLabels:     strings.Join(preset.Labels, ", "),
+	}
+	if preset.CPUs > 0 {
+		values[services.RunFieldCPUs] = strconv.Itoa(preset.CPUs)
+	}
+	for i := range m.fields {
+		if value := strings.TrimSpace(values[m.fields[i].field]); value != "" {
+			m.fields[i].input.SetValue(value)
+		}
+	}
+	m.detach = preset.Detach
+	m.errorMsg = ""
+	return m
+}
+
+func (m *ContainerRunScreen) updateFocus() {
+	for i := range m.fields {
+		if i == m.focus {
+			m.fields[i].input.Focus()
+		} else {
+			m.fields[i].input.Blur()
+		}
+	}
+}
+
+// View renders the run form.
+func (m ContainerRunScreen) View() string {
+	builder := strings.Builder{}
+	builder.WriteString(RenderTitle("Run Container") + "\n\n")
+	if m.preset >= 0 {
+		builder.WriteString(RenderAccent("Preset: "+m.presets[m.preset]) + "\n\n")
+	}
+	_, problems := m.form()
+	for i, field := range m.fields {
+		label := field.label
+		if i == m.focus {
+			label = RenderAccent(label)
+		}
+		builder.WriteString(label + "\n" + field.input.View() + "\n")
+		if err := problems[field.field]; err != nil && (m.submitted || strings.TrimSpace(field.input.Value()) != "") {
+			builder.WriteString(RenderWarning(err.Error()) + "\n")
+		}
+	}
+	checkbox := "[ ] Detach (run in the background)"
+	if m.detach {
+		checkbox = "[x] Detach (run in the background)"
+	}
+	if m.focus == len(m.fields) {
+		checkbox = RenderAccent(checkbox)
+	}
+	builder.WriteString("\n" + checkbox + "\n")
+	if m.loading {
+		builder.WriteString("\n" + RenderMuted("Running...") + "\n")
+	}
+	if m.errorMsg != "" {
+		builder.WriteString("\n" + RenderError("Error: "+m.errorMsg) + "\n")
+	}
+	if m.preview != nil {
+		builder.WriteString("\n" + m.preview.View())
+	}
+	if m.result != nil {
+		builder.WriteString("\n\n" + renderStreamResult(m.lines, *m.result))
+	} else if len(m.lines) > 0 {
+		start := max(0, len(m.lines)-runOutputLines)
+		builder.WriteString("\n" + strings.Join(m.lines[start:], "\n") + "\n")
+	}
+	keys := "Keys: tab/up/down=field, space=toggle detach, enter=preview, esc=back"
+	if len(m.presets) > 0 {
+		keys = "Keys: tab/up/down=field, space=toggle detach, ctrl+p=next preset, enter=preview, esc=back"
+	}
+	if m.loading {
+		keys = "Keys: esc=cancel run and back"
+	}
+	builder.WriteString("\n" + RenderMuted(keys) + "\n")
+	return builder.String()
+}
+
+func (m ContainerRunScreen) executeCommandCmd(command models.Command) tea.Cmd {
+	return m.stream.start(m.executor, command, func(result models.Result, err error) tea.Msg {
+		return containerRunResultMsg{result: result, err: err}
+	})
+}
+
+// splitList splits a comma-separated field into its trimmed, non-empty values.
+func splitList(value string) []string {
+	values := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"os/exec"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -67,9 +66,9 @@ func (m ContainerShellScreen) Update(msg tea.Msg) (ContainerShellScreen, tea.Cmd
 			return m, nil
 		}
 
-		execCmd := exec.Command(command.Executable, command.Args...)
+		process := services.InteractiveCommand(m.executor, command)
 		m.statusMessage = "Starting interactive shell..."
-		return m, tea.ExecProcess(execCmd, func(err error) tea.Msg {
+		return m, tea.Exec(process, func(err error) tea.Msg {
 			return containerShellFinishedMsg{err: err}
 		})
 	case containerShellFinishedMsg:
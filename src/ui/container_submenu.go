@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,8 +19,9 @@ type containerSubmenuActionMsg struct {
 }
 
 type containerSubmenuOption struct {
-	label  string
-	action string
+	label   string
+	action  string
+	blocked string
 }
 
 // ContainerSubmenuScreen displays actions for the selected container.
@@ -30,6 +34,7 @@ type ContainerSubmenuScreen struct {
 	errorMsg  string
 	result    *models.Result
 	preview   *CommandPreviewModal
+	confirm   *TypeToConfirmModal
 	width     int
 }
 
@@ -43,6 +48,7 @@ func (m ContainerSubmenuScreen) SetContainer(container models.Container) Contain
 	m.errorMsg = ""
 	m.result = nil
 	m.preview = nil
+	m.confirm = nil
 	m.options = m.buildOptions()
 	return m
 }
@@ -64,6 +70,20 @@ func (m ContainerSubmenuScreen) Update(msg tea.Msg) (ContainerSubmenuScreen, tea
 		m.result = &message.result
 		return m, nil
 	case tea.KeyMsg:
+		if m.confirm != nil {
+			updatedConfirm, confirmed, canceled := m.confirm.Handle(message)
+			m.confirm = &updatedConfirm
+			if confirmed {
+				command := updatedConfirm.Command
+				m.confirm = nil
+				m.loading = true
+				return m, m.executeCommandCmd(command)
+			}
+			if canceled {
+				m.confirm = nil
+			}
+			return m, nil
+		}
 		if m.preview != nil {
 			switch strings.ToLower(message.String()) {
 			case "y", "enter":
@@ -94,6 +114,10 @@ func (m ContainerSubmenuScreen) Update(msg tea.Msg) (ContainerSubmenuScreen, tea
 				return m, nil
 			}
 			selected := m.options[m.cursor]
+			if selected.blocked != "" {
+				m.errorMsg = selected.blocked
+				return m, nil
+			}
 			switch selected.action {
 			case "start":
 				cmd, err := (services.StartContainerBuilder{ContainerID: m.container.ID}).Build()
@@ -104,12 +128,17 @@ func (m ContainerSubmenuScreen) Update(msg tea.Msg) (ContainerSubmenuScreen, tea
 				m.preview = &CommandPreviewModal{Title: "Start Container", Command: cmd}
 				return m, nil
 			case "stop":
-				cmd, err := (services.StopContainerBuilder{ContainerID: m.container.ID}).Build()
+				builder := services.StopContainerBuilder{ContainerID: m.container.ID}
+				cmd, err := builder.Build()
 				if err != nil {
 					m.errorMsg = err.Error()
 					return m, nil
 				}
-				m.preview = &CommandPreviewModal{Title: "Stop Container", Command: cmd}
+				m.preview, m.confirm = guardedPrompt(builder, "Stop Container", containerConfirmName(m.container), cmd)
+				if m.preview == nil && m.confirm == nil {
+					m.loading = true
+					return m, m.executeCommandCmd(cmd)
+				}
 				return m, nil
 			case "logs":
 				containerCopy := m.container
@@ -145,6 +174,26 @@ func (m ContainerSubmenuScreen) View() string {
 	builder.WriteString("name: " + m.container.Name + "\n")
 	builder.WriteString("status: " + string(m.container.Status) + "\n")
 	builder.WriteString("image: " + m.container.Image + "\n")
+	if m.container.Platform != "" {
+		builder.WriteString("platform: " + m.container.Platform + "\n")
+	}
+	if !m.container.CreatedAt.IsZero() {
+		builder.WriteString("created: " + m.container.CreatedAt.Local().Format(time.DateTime) + "\n")
+	}
+	for _, network := range m.container.Networks {
+		builder.WriteString("network: " + strings.TrimSpace(network.Name+" "+network.Address) + "\n")
+	}
+	for _, port := range m.container.Ports {
+		builder.WriteString(fmt.Sprintf("port: %d -> %d/%s\n", port.HostPort, port.ContainerPort, port.Protocol))
+	}
+	for _, mount := range m.container.Mounts {
+		mode := ""
+		if mount.ReadOnly {
+			mode = " (ro)"
+		}
+		builder.WriteString("mount: " + mount.Source + " -> " + mount.Destination + mode + "\n")
+	}
+	writeLabels(&builder, m.container.Labels)
 	builder.WriteString("\n")
 
 	// Horizontal separator
@@ -157,15 +206,12 @@ func (m ContainerSubmenuScreen) View() string {
 	// Available Actions section with bold header
 	builder.WriteString(headerStyle.Render("Available Actions") + "\n")
 
-	// Action items with inverse video selection
-	normalStyle := lipgloss.NewStyle()
-	selectedStyle := lipgloss.NewStyle().Reverse(true)
+	// Action items with inverse video selection; blocked actions are greyed out
 	for i, option := range m.options {
-		style := normalStyle
-		if i == m.cursor {
-			style = selectedStyle
-		}
-		builder.WriteString(style.Render(option.label) + "\n")
+		builder.WriteString(renderActionOption(option.label, option.blocked, i == m.cursor) + "\n")
+	}
+	if m.cursor >= 0 && m.cursor < len(m.options) && m.options[m.cursor].blocked != "" {
+		builder.WriteString(RenderWarning(m.options[m.cursor].blocked) + "\n")
 	}
 	builder.WriteString("\n")
 
@@ -184,6 +230,9 @@ func (m ContainerSubmenuScreen) View() string {
 	if m.preview != nil {
 		builder.WriteString("\n" + m.preview.View() + "\n")
 	}
+	if m.confirm != nil {
+		builder.WriteString("\n" + m.confirm.View() + "\n")
+	}
 	builder.WriteString("\n" + RenderMuted("Keys: up/down=navigate, enter=select, esc=back") + "\n")
 	return builder.String()
 }
@@ -202,12 +251,46 @@ func (m ContainerSubmenuScreen) buildOptions() []containerSubmenuOption {
 		options = append(options, containerSubmenuOption{label: "Export container", action: "export"})
 	}
 	options = append(options, containerSubmenuOption{label: "Back", action: "back"})
+	for i := range options {
+		options[i].blocked = m.blockedReason(options[i].action)
+	}
 	return options
 }
 
+// blockedReason checks the command behind action against the execution policy.
+func (m ContainerSubmenuScreen) blockedReason(action string) string {
+	var command models.Command
+	var err error
+	switch action {
+	case "start":
+		command, err = (services.StartContainerBuilder{ContainerID: m.container.ID}).Build()
+	case "stop":
+		command, err = (services.StopContainerBuilder{ContainerID: m.container.ID}).Build()
+	case "shell":
+		command, err = (services.ContainerExecBuilder{ContainerName: m.container.ID, Shell: "sh"}).Build()
+	case "export":
+		command, err = (services.ExportContainerBuilder{ContainerID: m.container.ID, ImageReference: m.container.ID + "-export"}).Build()
+	default:
+		return ""
+	}
+	return policyBlockReason(m.executor, command, err)
+}
+
 func (m ContainerSubmenuScreen) executeCommandCmd(command models.Command) tea.Cmd {
 	return func() tea.Msg {
 		result, err := m.executor.Execute(command)
 		return containerSubmenuActionMsg{result: result, err: err}
 	}
 }
+
+// writeLabels adds one "label:" line per label, sorted by key.
+func writeLabels(builder *strings.Builder, labels map[string]string) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		builder.WriteString("label: " + key + "=" + labels[key] + "\n")
+	}
+}
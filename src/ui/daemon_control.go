@@ -28,6 +28,7 @@ type DaemonControlScreen struct {
 	errorMsg string
 	result   *models.Result
 	confirm  *YesNoConfirmModal
+	typed    *TypeToConfirmModal
 }
 
 // NewDaemonControlScreen creates the daemon control screen.
@@ -60,6 +61,20 @@ func (m DaemonControlScreen) Update(msg tea.Msg) (DaemonControlScreen, tea.Cmd)
 		m.result = &message.result
 		return m, m.fetchStatusCmd()
 	case tea.KeyMsg:
+		if m.typed != nil {
+			updatedTyped, confirmed, canceled := m.typed.Handle(message)
+			m.typed = &updatedTyped
+			if confirmed {
+				command := updatedTyped.Command
+				m.typed = nil
+				m.loading = true
+				return m, m.executeCommandCmd(command)
+			}
+			if canceled {
+				m.typed = nil
+			}
+			return m, nil
+		}
 		if m.confirm != nil {
 			confirmed, canceled := m.confirm.Handle(message)
 			if confirmed {
@@ -126,14 +141,18 @@ func (m DaemonControlScreen) View() string {
 		builder.WriteString("\n" + RenderError("Error: "+m.errorMsg) + "\n")
 	}
 	builder.WriteString("\nActions:\n")
-	builder.WriteString("  s - start daemon\n")
-	builder.WriteString("  t - stop daemon (!)\n")
+	builder.WriteString(renderDaemonAction("  s - start daemon", m.startBlocked()) + "\n")
+	builder.WriteString(renderDaemonAction("  t - stop daemon (!)", m.stopBlocked()) + "\n")
 	builder.WriteString("\n" + RenderMuted("Keys: s=start, t=stop, r=refresh, ?=help, esc=back") + "\n")
 
 	if m.confirm != nil {
 		builder.WriteString("\n")
 		builder.WriteString(m.confirm.View())
 	}
+	if m.typed != nil {
+		builder.WriteString("\n")
+		builder.WriteString(m.typed.View())
+	}
 	if m.result != nil {
 		builder.WriteString("\n\n")
 		builder.WriteString(RenderResult(*m.result))
@@ -148,6 +167,10 @@ func (m DaemonControlScreen) buildAndConfirmStart() (DaemonControlScreen, tea.Cm
 		m.errorMsg = err.Error()
 		return m, nil
 	}
+	if reason := policyBlockReason(m.executor, cmd, nil); reason != "" {
+		m.errorMsg = reason
+		return m, nil
+	}
 	m.confirm = &YesNoConfirmModal{
 		Title:   "Start Daemon",
 		Body:    "Start container services?",
@@ -164,15 +187,40 @@ func (m DaemonControlScreen) buildAndConfirmStop() (DaemonControlScreen, tea.Cmd
 		m.errorMsg = err.Error()
 		return m, nil
 	}
-	m.confirm = &YesNoConfirmModal{
+	if reason := policyBlockReason(m.executor, cmd, nil); reason != "" {
+		m.errorMsg = reason
+		return m, nil
+	}
+	m.confirm, m.typed = guardedYesNo(builder.GuardedAction(), YesNoConfirmModal{
 		Title:   "Stop Daemon",
 		Body:    "Stop container services and running containers?",
 		Command: cmd,
 		Warning: true,
+	}, "stop")
+	if m.confirm == nil && m.typed == nil {
+		m.loading = true
+		return m, m.executeCommandCmd(cmd)
 	}
 	return m, nil
 }
 
+func (m DaemonControlScreen) startBlocked() string {
+	cmd, err := (services.StartDaemonBuilder{}).Build()
+	return policyBlockReason(m.executor, cmd, err)
+}
+
+func (m DaemonControlScreen) stopBlocked() string {
+	cmd, err := (services.StopDaemonBuilder{}).Build()
+	return policyBlockReason(m.executor, cmd, err)
+}
+
+func renderDaemonAction(label string, blocked string) string {
+	if blocked == "" {
+		return label
+	}
+	return RenderMuted(label + " - " + blocked)
+}
+
 func (m DaemonControlScreen) fetchStatusCmd() tea.Cmd {
 	return func() tea.Msg {
 		builder := services.CheckDaemonStatusBuilder{}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/filepicker"
@@ -23,7 +24,13 @@ type FilePickerScreen struct {
 func NewFilePickerScreen(executor services.CommandExecutor) FilePickerScreen {
 	picker := filepicker.New()
 	picker.AllowedTypes = []string{"Containerfile", "Dockerfile"}
+	if name := filepath.Base(effectiveConfig.Config.DefaultBuildFile); name != "Containerfile" && name != "Dockerfile" && name != "." {
+		picker.AllowedTypes = append(picker.AllowedTypes, name)
+	}
 	picker.CurrentDirectory = "."
+	if dir := effectiveConfig.ProjectDir(); dir != "" {
+		picker.CurrentDirectory = dir
+	}
 	return FilePickerScreen{executor: executor, picker: picker, returnTarget: ScreenContainerList}
 }
 
@@ -51,6 +58,14 @@ func (m FilePickerScreen) Update(msg tea.Msg) (FilePickerScreen, tea.Cmd) {
 			return m, func() tea.Msg { return BackToListMsg{} }
 		case "?":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
+		case "d":
+			path, ok := defaultBuildFile()
+			if !ok {
+				m.errorMsg = "default build file not found: " + path
+				return m, nil
+			}
+			returnTarget := m.returnTarget
+			return m, func() tea.Msg { return buildFileSelectedMsg{path: path, returnTarget: returnTarget} }
 		}
 	}
 
@@ -74,6 +89,10 @@ func (m FilePickerScreen) View() string {
 	if m.errorMsg != "" {
 		builder.WriteString("\n" + RenderError("Error: "+m.errorMsg) + "\n")
 	}
-	builder.WriteString("\n" + RenderMuted("Keys: enter=select, ?=help, esc=back") + "\n")
+	keys := "Keys: enter=select, ?=help, esc=back"
+	if path, ok := defaultBuildFile(); ok {
+		keys = "Keys: enter=select, d=use " + path + ", ?=help, esc=back"
+	}
+	builder.WriteString("\n" + RenderMuted(keys) + "\n")
 	return builder.String()
 }
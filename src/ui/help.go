@@ -1,16 +1,21 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"container-tui/src/services"
 )
 
 // HelpScreen shows keyboard shortcuts and paths.
 type HelpScreen struct {
 	Version string
 	width   int
+	height  int
+	offset  int
 }
 
 // Init returns no initial command.
@@ -23,18 +28,47 @@ func (h HelpScreen) Update(msg tea.Msg) (HelpScreen, tea.Cmd) {
 	switch message := msg.(type) {
 	case tea.WindowSizeMsg:
 		h.width = message.Width
+		h.height = message.Height
 		return h, nil
 	case tea.KeyMsg:
 		switch message.String() {
 		case "esc", "?":
+			h.offset = 0
 			return h, func() tea.Msg { return screenChangeMsg{target: ScreenContainerList} }
+		case "down", "j":
+			h.offset++
+		case "up", "k":
+			h.offset--
+		case "pgdown", " ":
+			h.offset += max(1, h.height-2)
+		case "pgup":
+			h.offset -= max(1, h.height-2)
 		}
+		h.offset = max(0, min(h.offset, h.maxOffset()))
 	}
 	return h, nil
 }
 
-// View renders help content.
+// maxOffset is the last scroll position that still fills the screen.
+func (h HelpScreen) maxOffset() int {
+	if h.height <= 0 {
+		return 0
+	}
+	return max(0, strings.Count(h.content(), "\n")-(h.height-1))
+}
+
+// View renders the visible part of the help content; the status bar takes the last line.
 func (h HelpScreen) View() string {
+	content := h.content()
+	if h.height <= 0 {
+		return content
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	end := min(len(lines), h.offset+h.height-1)
+	return strings.Join(lines[min(h.offset, end):end], "\n") + "\n"
+}
+
+func (h HelpScreen) content() string {
 	builder := strings.Builder{}
 	builder.WriteString(RenderTitle("Help") + "\n\n")
 
@@ -53,14 +87,19 @@ func (h HelpScreen) View() string {
 	builder.WriteString("up/down, j/k       Navigate lists\n")
 	builder.WriteString("enter              Open submenu/select\n")
 	builder.WriteString("esc                Back/cancel\n")
+	builder.WriteString("space              Mark item for a bulk action (container, image and machine lists)\n")
+	builder.WriteString("a                  Mark all, or clear the marks\n")
+	builder.WriteString("/                  Mark items whose name matches\n")
 	builder.WriteString("\n")
 	builder.WriteString(strings.Repeat("─", width) + "\n\n")
 
 	// Section 2: Container Actions
 	builder.WriteString(headerStyle.Render("Container Actions") + "\n")
+	builder.WriteString("n                  Run a new container\n")
 	builder.WriteString("s                  Start container\n")
 	builder.WriteString("t                  Stop container\n")
 	builder.WriteString("d                  Delete container\n")
+	builder.WriteString("e                  Export to an OCI archive\n")
 	builder.WriteString("export             Available from stopped container submenu\n")
 	builder.WriteString("enter              Open container submenu\n")
 	builder.WriteString("r                  Refresh list\n")
@@ -71,24 +110,81 @@ func (h HelpScreen) View() string {
 	builder.WriteString(headerStyle.Render("Image Actions") + "\n")
 	builder.WriteString("i                  Switch to images view\n")
 	builder.WriteString("p                  Pull image\n")
-	builder.WriteString("g                  View registries\n")
-	builder.WriteString("b                  Build image\n")
+	if supports(services.CapabilityRegistry) {
+		builder.WriteString("g                  View registries\n")
+	}
+	if supports(services.CapabilityBuilder) {
+		builder.WriteString("b                  Build image\n")
+	}
+	builder.WriteString("d                  Delete image\n")
+	builder.WriteString("e                  Save image to an OCI archive\n")
 	builder.WriteString("n                  Prune images\n")
 	builder.WriteString("enter              Open image submenu\n")
 	builder.WriteString("\n")
 	builder.WriteString(strings.Repeat("─", width) + "\n\n")
 
-	// Section 4: General
+	// Section 4: Guarded Actions
+	builder.WriteString(headerStyle.Render("Guarded Actions") + "\n")
+	metadata := services.DestructiveActionMetadata()
+	for _, action := range services.DestructiveActions() {
+		info := metadata[action]
+		builder.WriteString(fmt.Sprintf("%-19s%-7s %-7s %s\n", info.Label, info.Risk, confirmationModeFor(action), info.Description))
+	}
+	builder.WriteString(RenderMuted("Change per action in the [confirmations] config table") + "\n")
+	builder.WriteString("\n")
+	builder.WriteString(strings.Repeat("─", width) + "\n\n")
+
+	// Section 5: General
 	builder.WriteString(headerStyle.Render("General") + "\n")
 	builder.WriteString("m                  Manage daemon\n")
+	if supports(services.CapabilityMachine) {
+		builder.WriteString("M                  Container machines\n")
+	}
+	builder.WriteString("H                  Command history (enter=details, x=re-run, /=search)\n")
+	builder.WriteString("P                  Switch connection profile\n")
+	builder.WriteString("ctrl+x             Cancel running command\n")
+	builder.WriteString("ctrl+r             Run the fix offered for the last error\n")
 	builder.WriteString("?                  Show this help\n")
 	builder.WriteString("q                  Quit application\n")
 	builder.WriteString("\n")
-	builder.WriteString("Config: ~/.config/actui/config OR ~/Library/Application Support/actui/config\n")
-	builder.WriteString("Logs:   ~/Library/Application Support/actui/command.log\n")
+	builder.WriteString("Config: $XDG_CONFIG_HOME/actui/config, ~/.config/actui/config OR ~/Library/Application Support/actui/config (--config, ACTUI_* overrides)\n")
+	builder.WriteString("Logs:   $XDG_STATE_HOME/actui/command.log OR ~/Library/Application Support/actui/command.log\n")
+	builder.WriteString("\n")
+	builder.WriteString(strings.Repeat("─", width) + "\n\n")
+
+	// Section 6: Container CLI
+	builder.WriteString(headerStyle.Render("Container CLI") + "\n")
+	if capabilities.VersionLine == "" {
+		builder.WriteString(RenderMuted("Not probed; every action is shown") + "\n")
+	} else {
+		builder.WriteString(capabilities.VersionLine + "\n")
+		if report := capabilities.CompatibilityReport(); report != "" {
+			for _, line := range strings.Split(strings.TrimSpace(report), "\n")[1:] {
+				builder.WriteString(RenderWarning(strings.TrimSpace(line)) + "\n")
+			}
+		}
+	}
+	builder.WriteString("\n")
+	builder.WriteString(strings.Repeat("─", width) + "\n\n")
+
+	// Section 7: Effective Config
+	builder.WriteString(headerStyle.Render("Effective Config") + "\n")
+	userPath := effectiveConfig.UserPath
+	if userPath == "" {
+		userPath = "none (defaults)"
+	}
+	projectPath := effectiveConfig.ProjectPath
+	if projectPath == "" {
+		projectPath = "none (" + services.ProjectConfigName + " in this directory or a parent)"
+	}
+	builder.WriteString(RenderMuted("User:    "+userPath) + "\n")
+	builder.WriteString(RenderMuted("Project: "+projectPath) + "\n")
+	for _, setting := range effectiveConfig.Settings {
+		builder.WriteString(fmt.Sprintf("%-34s %-8s %s\n", setting.Key, setting.Source, setting.Value))
+	}
 	builder.WriteString("\n")
 	builder.WriteString(strings.Repeat("─", width) + "\n\n")
 
-	builder.WriteString(RenderMuted("Press any key to return") + "\n")
+	builder.WriteString(RenderMuted("up/down or j/k to scroll, esc or ? to return") + "\n")
 	return builder.String()
 }
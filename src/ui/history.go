@@ -0,0 +1,409 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+type historyLoadedMsg struct {
+	entries []services.LogEntry
+	err     error
+}
+
+type historyRerunMsg struct {
+	result models.Result
+	err    error
+}
+
+// historyEntryLimit caps how many of the newest log entries the history screen loads.
+const historyEntryLimit = 5000
+
+// historyStatusFilters cycles the status filter; "" shows every entry.
+var historyStatusFilters = []string{"", string(models.ResultSuccess), string(models.ResultError), string(models.ResultCanceled)}
+
+// historyDryRunFilter narrows entries by dry-run flag.
+type historyDryRunFilter int
+
+const (
+	historyDryRunAll historyDryRunFilter = iota
+	historyDryRunOnly
+	historyDryRunExclude
+)
+
+func (f historyDryRunFilter) String() string {
+	switch f {
+	case historyDryRunOnly:
+		return "only"
+	case historyDryRunExclude:
+		return "hidden"
+	default:
+		return "shown"
+	}
+}
+
+// HistoryScreen browses the command log and re-runs past commands.
+type HistoryScreen struct {
+	executor     services.CommandExecutor
+	logPath      string
+	entries      []services.LogEntry
+	visible      []int
+	cursor       int
+	width        int
+	height       int
+	loading      bool
+	errorMsg     string
+	statusFilter int
+	dryRunFilter historyDryRunFilter
+	query        string
+	searching    bool
+	detail       bool
+	viewport     viewport.Model
+	preview      *CommandPreviewModal
+	confirm      *TypeToConfirmModal
+	result       *models.Result
+}
+
+// NewHistoryScreen creates the history screen for the command log written by executor.
+func NewHistoryScreen(executor services.CommandExecutor) HistoryScreen {
+	logPath, _ := services.CommandLogPath(executor)
+	return HistoryScreen{executor: executor, logPath: logPath, viewport: viewport.New(76, 15)}
+}
+
+// Init loads the command log.
+func (m HistoryScreen) Init() tea.Cmd {
+	return m.loadHistoryCmd()
+}
+
+// Update handles history screen input.
+func (m HistoryScreen) Update(msg tea.Msg) (HistoryScreen, tea.Cmd) {
+	switch message := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = message.Width
+		m.height = message.Height
+		m.viewport.Width = max(20, message.Width-4)
+		m.viewport.Height = max(5, message.Height-12)
+		return m, nil
+	case historyLoadedMsg:
+		m.loading = false
+		if message.err != nil {
+			m.errorMsg = message.err.Error()
+			return m, nil
+		}
+		m.entries = make([]services.LogEntry, len(message.entries))
+		for index, entry := range message.entries {
+			// Entries written before redaction was configured are masked on display.
+			m.entries[index] = redactor.RedactEntry(entry)
+		}
+		m.applyFilters()
+		return m, nil
+	case historyRerunMsg:
+		m.loading = false
+		m.result = &message.result
+		if message.err != nil {
+			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
+		}
+		return m, m.loadHistoryCmd()
+	case tea.KeyMsg:
+		if m.confirm != nil {
+			updated, confirmed, canceled := m.confirm.Handle(message)
+			m.confirm = &updated
+			if confirmed {
+				command := updated.Command
+				m.confirm = nil
+				m.loading = true
+				m.errorMsg = ""
+				return m, m.rerunCmd(command)
+			}
+			if canceled {
+				m.confirm = nil
+			}
+			return m, nil
+		}
+		if m.preview != nil {
+			switch strings.ToLower(message.String()) {
+			case "y", "enter":
+				command := m.preview.Command
+				m.preview = nil
+				m.loading = true
+				m.errorMsg = ""
+				return m, m.rerunCmd(command)
+			case "n", "esc":
+				m.preview = nil
+			}
+			return m, nil
+		}
+		if m.searching {
+			return m.handleSearchKey(message), nil
+		}
+		if m.detail {
+			switch message.String() {
+			case "esc", "enter":
+				m.detail = false
+			case "x":
+				return m.previewRerun(), nil
+			default:
+				updatedViewport, _ := m.viewport.Update(message)
+				m.viewport = updatedViewport
+			}
+			return m, nil
+		}
+
+		switch message.String() {
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = max(0, min(len(m.visible)-1, m.cursor+1))
+		case "enter":
+			if entry, ok := m.selectedEntry(); ok {
+				m.detail = true
+				m.viewport.SetContent(renderHistoryDetail(entry))
+				m.viewport.GotoTop()
+			}
+		case "x":
+			return m.previewRerun(), nil
+		case "/":
+			m.searching = true
+		case "f":
+			m.statusFilter = (m.statusFilter + 1) % len(historyStatusFilters)
+			m.applyFilters()
+		case "D":
+			m.dryRunFilter = (m.dryRunFilter + 1) % 3
+			m.applyFilters()
+		case "r":
+			m.loading = true
+			return m, m.loadHistoryCmd()
+		case "?":
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
+		case "esc":
+			if m.query != "" {
+				m.query = ""
+				m.applyFilters()
+				return m, nil
+			}
+			return m, func() tea.Msg { return BackToListMsg{} }
+		}
+	}
+	return m, nil
+}
+
+func (m HistoryScreen) handleSearchKey(message tea.KeyMsg) HistoryScreen {
+	switch message.Type {
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		m.query = ""
+	case tea.KeyBackspace:
+		if m.query != "" {
+			runes := []rune(m.query)
+			m.query = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(message.Runes)
+	}
+	m.applyFilters()
+	return m
+}
+
+// applyFilters recomputes the visible entries and keeps the cursor in range.
+func (m *HistoryScreen) applyFilters() {
+	status := historyStatusFilters[m.statusFilter]
+	query := strings.ToLower(strings.TrimSpace(m.query))
+	m.visible = make([]int, 0, len(m.entries))
+	for index, entry := range m.entries {
+		if status != "" && entry.Status != status {
+			continue
+		}
+		if m.dryRunFilter == historyDryRunOnly && !entry.DryRun {
+			continue
+		}
+		if m.dryRunFilter == historyDryRunExclude && entry.DryRun {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(entry.Command), query) {
+			continue
+		}
+		m.visible = append(m.visible, index)
+	}
+	if m.cursor >= len(m.visible) {
+		m.cursor = max(0, len(m.visible)-1)
+	}
+}
+
+func (m HistoryScreen) selectedEntry() (services.LogEntry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return services.LogEntry{}, false
+	}
+	return m.entries[m.visible[m.cursor]], true
+}
+
+// previewRerun opens the command preview for the selected entry. Guarded commands ask for
+// the confirmation the policy requires of them, typed when they act on all targets, and
+// never less than the preview.
+func (m HistoryScreen) previewRerun() HistoryScreen {
+	entry, ok := m.selectedEntry()
+	if !ok {
+		return m
+	}
+	command, err := entry.ParsedCommand()
+	if err != nil {
+		m.errorMsg = err.Error()
+		return m
+	}
+	if services.IsRedacted(entry.Command) {
+		m.errorMsg = "command contains redacted secrets; run it again from its own screen"
+		return m
+	}
+	if reason := policyBlockReason(m.executor, command, nil); reason != "" {
+		m.errorMsg = reason
+		return m
+	}
+	m.result = nil
+	if guard, ok := services.CommandAction(command); ok {
+		mode := guard.Mode(confirmationModeFor(guard.Action))
+		m.preview, m.confirm = promptForMode(mode, "Re-run Command", guard.Expected, command)
+	}
+	if m.preview == nil && m.confirm == nil {
+		m.preview = &CommandPreviewModal{Title: "Re-run Command", Command: command}
+	}
+	return m
+}
+
+// View renders the history list or the selected entry.
+func (m HistoryScreen) View() string {
+	builder := strings.Builder{}
+	builder.WriteString(RenderTitle("Command History") + "\n\n")
+	if m.loading {
+		builder.WriteString(RenderMuted("Loading...") + "\n")
+	}
+	if m.errorMsg != "" {
+		builder.WriteString(RenderError("Error: "+m.errorMsg) + "\n\n")
+	}
+
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+
+	if m.detail {
+		builder.WriteString(m.viewport.View() + "\n")
+		builder.WriteString(strings.Repeat("─", width) + "\n")
+	} else {
+		if m.logPath == "" {
+			builder.WriteString(RenderMuted("Command logging is disabled for this session") + "\n\n")
+		}
+		status := historyStatusFilters[m.statusFilter]
+		if status == "" {
+			status = "all"
+		}
+		search := m.query
+		if m.searching {
+			search += "_"
+		}
+		builder.WriteString(RenderMuted(fmt.Sprintf("Status: %s  Dry-run: %s  Search: %s  (%d of %d)", status, m.dryRunFilter, search, len(m.visible), len(m.entries))) + "\n\n")
+
+		table := NewTable([]TableColumn{
+			{Header: "Time", MinWidth: 19, Priority: 2, Align: "left"},
+			{Header: "Status", MinWidth: 9, Priority: 2, Align: "left"},
+			{Header: "Duration", MinWidth: 8, Priority: 3, Align: "right"},
+			{Header: "Command", MinWidth: 20, Priority: 1, Align: "left"},
+		})
+		rows := make([]TableRow, 0, len(m.visible))
+		for position, index := range m.visible {
+			entry := m.entries[index]
+			rows = append(rows, TableRow{
+				Cells:    []string{entry.StartTime.Local().Format(time.DateTime), historyStatusLabel(entry), formatHistoryDuration(entry.DurationMs), entry.Command},
+				Selected: position == m.cursor,
+			})
+		}
+		table.SetRows(rows)
+		builder.WriteString(table.Render(width, m.cursor))
+		builder.WriteString(strings.Repeat("─", width) + "\n")
+	}
+
+	if m.preview != nil {
+		builder.WriteString("\n" + m.preview.View() + "\n")
+	}
+	if m.confirm != nil {
+		builder.WriteString("\n" + m.confirm.View() + "\n")
+	}
+	if m.result != nil {
+		builder.WriteString("\n" + RenderResult(*m.result) + "\n")
+	}
+
+	switch {
+	case m.searching:
+		builder.WriteString("\n" + RenderMuted("Type to filter commands, enter=apply, esc=clear") + "\n")
+	case m.detail:
+		builder.WriteString("\n" + RenderMuted("Keys: up/down=scroll, x=re-run, esc=back to list") + "\n")
+	default:
+		builder.WriteString("\n" + RenderMuted("Keys: up/down, enter=details, x=re-run, /=search, f=status filter, D=dry-run filter, r=refresh, esc=back") + "\n")
+	}
+	return builder.String()
+}
+
+func (m HistoryScreen) loadHistoryCmd() tea.Cmd {
+	path := m.logPath
+	return func() tea.Msg {
+		if path == "" {
+			return historyLoadedMsg{entries: []services.LogEntry{}}
+		}
+		entries, err := services.QueryLogEntries(path, services.LogQuery{Limit: historyEntryLimit})
+		return historyLoadedMsg{entries: entries, err: err}
+	}
+}
+
+func (m HistoryScreen) rerunCmd(command models.Command) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.executor.Execute(command)
+		return historyRerunMsg{result: result, err: err}
+	}
+}
+
+func historyStatusLabel(entry services.LogEntry) string {
+	if entry.DryRun {
+		return entry.Status + "*"
+	}
+	return entry.Status
+}
+
+func formatHistoryDuration(milliseconds int64) string {
+	duration := time.Duration(milliseconds) * time.Millisecond
+	if duration < time.Second {
+		return fmt.Sprintf("%dms", milliseconds)
+	}
+	return duration.Round(100 * time.Millisecond).String()
+}
+
+// renderHistoryDetail formats one log entry with its captured output.
+func renderHistoryDetail(entry services.LogEntry) string {
+	builder := strings.Builder{}
+	builder.WriteString("Command:  " + entry.Command + "\n")
+	builder.WriteString("Started:  " + entry.StartTime.Local().Format(time.DateTime) + "\n")
+	builder.WriteString("Duration: " + formatHistoryDuration(entry.DurationMs) + "\n")
+	builder.WriteString(fmt.Sprintf("Exit:     %d\n", entry.ExitCode))
+	builder.WriteString("Status:   " + entry.Status + "\n")
+	if entry.Profile != "" {
+		builder.WriteString("Profile:  " + entry.Profile + "\n")
+	}
+	if entry.DryRun {
+		builder.WriteString("Dry run:  yes\n")
+	}
+	if entry.Stdout != "" {
+		builder.WriteString("\nStdout:\n" + entry.Stdout + "\n")
+	}
+	if entry.Stderr != "" {
+		builder.WriteString("\nStderr:\n" + entry.Stderr + "\n")
+	}
+	if entry.Stdout == "" && entry.Stderr == "" {
+		builder.WriteString("\n" + RenderMuted("(no output captured)") + "\n")
+	}
+	return builder.String()
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"container-tui/src/models"
@@ -21,18 +22,24 @@ type imageListActionMsg struct {
 
 // ImageListScreen shows local images and image actions.
 type ImageListScreen struct {
-	executor services.CommandExecutor
-	images   []models.Image
-	cursor   int
-	width    int
-	loading  bool
-	errorMsg string
-	result   *models.Result
-	confirm  *TypeToConfirmModal
+	executor  services.CommandExecutor
+	images    []models.Image
+	cursor    int
+	width     int
+	loading   bool
+	errorMsg  string
+	result    *models.Result
+	confirm   *TypeToConfirmModal
+	preview   *CommandPreviewModal
+	selection listSelection
+	exporting bool
+	exportTo  textinput.Model
+	bulk      *bulkPlan
+	report    *bulkReport
 }
 
 func NewImageListScreen(executor services.CommandExecutor) ImageListScreen {
-	return ImageListScreen{executor: executor, images: []models.Image{}}
+	return ImageListScreen{executor: executor, images: []models.Image{}, exportTo: newExportInput()}
 }
 
 func (m ImageListScreen) Init() tea.Cmd {
@@ -52,6 +59,7 @@ func (m ImageListScreen) Update(msg tea.Msg) (ImageListScreen, tea.Cmd) {
 			return m, nil
 		}
 		m.images = message.images
+		m.selection = m.selection.retain(m.imageIDs())
 		if m.cursor >= len(m.images) {
 			m.cursor = max(0, len(m.images)-1)
 		}
@@ -64,10 +72,20 @@ func (m ImageListScreen) Update(msg tea.Msg) (ImageListScreen, tea.Cmd) {
 	case imageListActionMsg:
 		m.loading = false
 		m.result = &message.result
+		m.report = nil
 		if message.err != nil {
 			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
 		}
 		return m, m.fetchImagesCmd()
+	case bulkFinishedMsg:
+		if message.screen != ScreenImageList {
+			return m, nil
+		}
+		m.loading = false
+		m.result = nil
+		m.report = &message.report
+		m.selection = m.selection.keepFailed(message.report.outcomes)
+		return m, m.fetchImagesCmd()
 	case tea.KeyMsg:
 		if m.confirm != nil {
 			updatedConfirm, confirmed, canceled := m.confirm.Handle(message)
@@ -76,15 +94,57 @@ func (m ImageListScreen) Update(msg tea.Msg) (ImageListScreen, tea.Cmd) {
 				command := updatedConfirm.Command
 				m.confirm = nil
 				m.loading = true
+				if m.bulk != nil {
+					return m.runBulk()
+				}
 				return m, m.executeCommandCmd(command)
 			}
 			if canceled {
 				m.confirm = nil
+				m.bulk = nil
 				return m, nil
 			}
 			return m, nil
 		}
+		if m.preview != nil {
+			switch strings.ToLower(message.String()) {
+			case "y", "enter":
+				previewed := m.preview.Command
+				m.preview = nil
+				m.loading = true
+				if m.bulk != nil {
+					return m.runBulk()
+				}
+				return m, m.executeCommandCmd(previewed)
+			case "n", "esc":
+				m.preview = nil
+				m.bulk = nil
+			}
+			return m, nil
+		}
+		if m.selection.filtering {
+			m.selection = m.selection.handleFilterKey(message, m.imageIDs(), m.imageIDs())
+			return m, nil
+		}
+		if m.exporting {
+			switch message.String() {
+			case "esc":
+				m.exporting = false
+				m.exportTo.Blur()
+				return m, nil
+			case "enter":
+				m.exporting = false
+				m.exportTo.Blur()
+				return m.planBulkExport(m.exportTo.Value())
+			}
+			var cmd tea.Cmd
+			m.exportTo, cmd = m.exportTo.Update(message)
+			return m, cmd
+		}
 
+		if busyKey(m.loading, message.String(), "d", "e", "n") {
+			return m, nil
+		}
 		switch message.String() {
 		case "up", "k":
 			m.cursor = max(0, m.cursor-1)
@@ -96,19 +156,49 @@ func (m ImageListScreen) Update(msg tea.Msg) (ImageListScreen, tea.Cmd) {
 		case "p":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenImagePull, push: true} }
 		case "g":
+			if !supports(services.CapabilityRegistry) {
+				m.errorMsg = unsupportedMessage(services.CapabilityRegistry)
+				return m, nil
+			}
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenRegistries, push: true} }
 		case "b":
+			if !supports(services.CapabilityBuilder) {
+				m.errorMsg = unsupportedMessage(services.CapabilityBuilder)
+				return m, nil
+			}
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenFilePicker, push: true} }
 		case "n":
-			cmd, err := (services.ImagePruneBuilder{}).Build()
+			builder := services.ImagePruneBuilder{}
+			cmd, err := builder.Build()
 			if err != nil {
 				m.errorMsg = err.Error()
 				return m, nil
 			}
-			confirm := NewTypeToConfirmModal("Prune Images", "prune", cmd)
-			m.confirm = &confirm
+			m.preview, m.confirm = guardedPrompt(builder, "Prune Images", "prune", cmd)
+			if m.preview == nil && m.confirm == nil {
+				m.loading = true
+				return m, m.executeCommandCmd(cmd)
+			}
 			return m, nil
+		case " ":
+			if len(m.images) > 0 && m.cursor >= 0 && m.cursor < len(m.images) {
+				m.selection = m.selection.toggle(m.images[m.cursor].Reference())
+				m.cursor = min(len(m.images)-1, m.cursor+1)
+			}
+		case "a":
+			m.selection = m.selection.toggleAll(m.imageIDs())
+		case "/":
+			m.selection.filtering = true
+		case "d":
+			return m.planBulkDelete()
+		case "e":
+			m.exporting = true
+			return m, m.exportTo.Focus()
 		case "esc":
+			if m.selection.count() > 0 {
+				m.selection = m.selection.clear()
+				return m, nil
+			}
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerList} }
 		case "enter":
 			if len(m.images) == 0 || m.cursor < 0 || m.cursor >= len(m.images) {
@@ -133,13 +223,15 @@ func (m ImageListScreen) View() string {
 		{Header: "Name", MinWidth: 10, Priority: 1, Align: "left"},
 		{Header: "Tag", MinWidth: 8, Priority: 2, Align: "left"},
 		{Header: "Digest", MinWidth: 12, Priority: 3, Align: "left"},
+		{Header: "Platform", MinWidth: 11, Priority: 3, Align: "left"},
+		{Header: "Size", MinWidth: 9, Priority: 3, Align: "right"},
 	})
 
 	if len(m.images) > 0 {
 		rows := make([]TableRow, len(m.images))
 		for i, image := range m.images {
 			rows[i] = TableRow{
-				Cells:    []string{image.Name, image.Tag, TruncateDigest(image.Digest)},
+				Cells:    []string{m.selection.label(image.Reference(), models.NormalizeImageName(image.Name)), image.Tag, TruncateDigest(image.Digest), image.Platform, FormatSize(image.Size)},
 				Selected: i == m.cursor,
 				Data:     &image,
 			}
@@ -154,22 +246,120 @@ func (m ImageListScreen) View() string {
 	}
 	builder.WriteString(table.Render(tableWidth, m.cursor))
 	builder.WriteString(strings.Repeat("─", tableWidth) + "\n")
+	builder.WriteString(m.selection.View(m.imageIDs(), m.imageIDs()))
+	if m.exporting {
+		builder.WriteString(m.exportTo.View() + "\n" + RenderMuted("enter=preview export, esc=cancel") + "\n")
+	}
 	if m.errorMsg != "" {
 		builder.WriteString("\n" + RenderError("Error: "+m.errorMsg) + "\n")
 	}
 	if m.result != nil {
 		builder.WriteString("\n" + RenderResult(*m.result) + "\n")
 	}
+	if m.preview != nil {
+		builder.WriteString("\n" + m.preview.View() + "\n")
+	}
 	if m.confirm != nil {
 		builder.WriteString("\n" + m.confirm.View() + "\n")
 	}
-	builder.WriteString("\n" + RenderMuted("Keys: up/down=navigate, enter=submenu, p=pull, g=registries, b=build, n=image-prune, r=refresh, esc=back") + "\n")
+	if m.bulk != nil {
+		builder.WriteString("\n" + m.bulk.View())
+	}
+	if m.report != nil {
+		builder.WriteString("\n" + m.report.View())
+	}
+	keys := "Keys: up/down=navigate, enter=submenu, space=mark, a=mark all, /=mark matching, d=delete(!), e=export, p=pull, "
+	if supports(services.CapabilityRegistry) {
+		keys += "g=registries, "
+	}
+	if supports(services.CapabilityBuilder) {
+		keys += "b=build, "
+	}
+	builder.WriteString("\n" + RenderMuted(keys+"n=image-prune, r=refresh, esc=back") + "\n")
 	return builder.String()
 }
 
+// targets returns the marked images, or the one under the cursor when none is marked.
+func (m ImageListScreen) targets() []models.Image {
+	if m.selection.count() == 0 {
+		if len(m.images) == 0 || m.cursor < 0 || m.cursor >= len(m.images) {
+			return nil
+		}
+		return []models.Image{m.images[m.cursor]}
+	}
+	targets := []models.Image{}
+	for _, image := range m.images {
+		if m.selection.isMarked(image.Reference()) {
+			targets = append(targets, image)
+		}
+	}
+	return targets
+}
+
+// planBulkDelete prompts for deleting every marked image.
+func (m ImageListScreen) planBulkDelete() (ImageListScreen, tea.Cmd) {
+	targets := m.targets()
+	plan := bulkPlan{verb: "Delete", noun: "Image", action: services.ActionDeleteImage}
+	for _, image := range targets {
+		cmd, err := (services.ImageDeleteBuilder{ImageReference: image.Reference()}).Build()
+		plan.add(m.executor, image.Reference(), []models.Command{cmd}, err)
+	}
+	return m.promptBulk(plan)
+}
+
+// planBulkExport prompts for saving every marked image to an OCI archive in destination.
+func (m ImageListScreen) planBulkExport(destination string) (ImageListScreen, tea.Cmd) {
+	targets := m.targets()
+	workflow := services.NewExportWorkflowService(m.executor)
+	plan := bulkPlan{verb: "Export", noun: "Image"}
+	for _, image := range targets {
+		cmd, err := workflow.PlanImageSave(image, destination)
+		plan.add(m.executor, image.Reference(), []models.Command{cmd}, err)
+	}
+	return m.promptBulk(plan)
+}
+
+// promptBulk shows the one prompt for the plan, or runs it when the policy needs none.
+func (m ImageListScreen) promptBulk(plan bulkPlan) (ImageListScreen, tea.Cmd) {
+	m.report = nil
+	m.result = nil
+	if len(plan.items) == 0 {
+		m.errorMsg = "nothing to " + strings.ToLower(plan.verb)
+		if len(plan.skipped) > 0 {
+			m.report = &bulkReport{title: plan.title(), skipped: plan.skipped}
+		}
+		return m, nil
+	}
+	m.errorMsg = ""
+	m.bulk = &plan
+	m.preview, m.confirm = plan.prompt()
+	if m.preview == nil && m.confirm == nil {
+		m.loading = true
+		return m.runBulk()
+	}
+	return m, nil
+}
+
+func (m ImageListScreen) runBulk() (ImageListScreen, tea.Cmd) {
+	plan := *m.bulk
+	m.bulk = nil
+	return m, runBulkCmd(m.executor, ScreenImageList, plan)
+}
+
+// imageIDs returns the references that key the images, which are also what is matched
+// when marking by filter.
+func (m ImageListScreen) imageIDs() []string {
+	ids := make([]string, len(m.images))
+	for i, image := range m.images {
+		ids[i] = image.Reference()
+	}
+	return ids
+}
+
 func (m ImageListScreen) fetchImagesCmd() tea.Cmd {
+	builder := services.ImageListBuilder{Capabilities: capabilities}
 	return func() tea.Msg {
-		cmd, err := (services.ImageListBuilder{}).Build()
+		cmd, err := builder.Build()
 		if err != nil {
 			return imageListLoadedMsg{err: err}
 		}
@@ -177,7 +367,7 @@ func (m ImageListScreen) fetchImagesCmd() tea.Cmd {
 		if err != nil {
 			return imageListLoadedMsg{err: err}
 		}
-		images, parseErr := services.ParseImageList(result.Stdout)
+		images, parseErr := builder.Parse(result.Stdout)
 		if parseErr != nil {
 			return imageListLoadedMsg{err: parseErr}
 		}
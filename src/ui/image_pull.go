@@ -10,6 +10,9 @@ import (
 	"container-tui/src/services"
 )
 
+// pullProgressLines is how many of the latest pull output lines are shown while pulling.
+const pullProgressLines = 10
+
 type imagePullResultMsg struct {
 	result models.Result
 	err    error
@@ -25,6 +28,8 @@ type ImagePullScreen struct {
 	errorMsg     string
 	result       *models.Result
 	progress     ProgressModel
+	stream       *commandStream
+	lines        []string
 	width        int
 }
 
@@ -58,6 +63,12 @@ func (m ImagePullScreen) Update(msg tea.Msg) (ImagePullScreen, tea.Cmd) {
 	switch message := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = message.Width
+	case streamLinesMsg:
+		if !m.stream.owns(message) {
+			return m, nil
+		}
+		m.lines = appendStreamLines(m.lines, message.lines)
+		return m, m.stream.next()
 	case imagePullResultMsg:
 		m.loading = false
 		if message.err != nil {
@@ -80,6 +91,10 @@ func (m ImagePullScreen) Update(msg tea.Msg) (ImagePullScreen, tea.Cmd) {
 				previewed := m.preview.Command
 				m.preview = nil
 				m.loading = true
+				m.errorMsg = ""
+				m.result = nil
+				m.lines = nil
+				m.stream = newCommandStream()
 				m.progress.SetPercent(0)
 				return m, m.executeCommandCmd(previewed)
 			case "n", "esc":
@@ -89,8 +104,12 @@ func (m ImagePullScreen) Update(msg tea.Msg) (ImagePullScreen, tea.Cmd) {
 			return m, nil
 		}
 
+		if key := message.String(); m.loading && key != "esc" && key != "?" {
+			return m, nil
+		}
 		switch message.String() {
 		case "esc":
+			m.stream.stop()
 			return m, func() tea.Msg { return BackToListMsg{} }
 		case "?":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
@@ -116,6 +135,9 @@ func (m ImagePullScreen) View() string {
 	builder := strings.Builder{}
 	builder.WriteString(RenderTitle("Pull Image") + "\n\n")
 	builder.WriteString(m.input.View() + "\n")
+	if hint := referenceHint(m.input.Value()); hint != "" {
+		builder.WriteString(hint + "\n")
+	}
 	if m.loading {
 		builder.WriteString("\n" + RenderMuted("Pulling...") + "\n")
 	}
@@ -131,15 +153,21 @@ func (m ImagePullScreen) View() string {
 	}
 	if m.result != nil {
 		builder.WriteString("\n\n")
-		builder.WriteString(RenderResult(*m.result))
+		builder.WriteString(renderStreamResult(m.lines, *m.result))
+	} else if len(m.lines) > 0 {
+		start := max(0, len(m.lines)-pullProgressLines)
+		builder.WriteString("\n" + strings.Join(m.lines[start:], "\n") + "\n")
+	}
+	keys := "Keys: enter=preview, ?=help, esc=back"
+	if m.loading {
+		keys = "Keys: esc=cancel pull and back"
 	}
-	builder.WriteString("\n" + RenderMuted("Keys: enter=preview, ?=help, esc=back") + "\n")
+	builder.WriteString("\n" + RenderMuted(keys) + "\n")
 	return builder.String()
 }
 
 func (m ImagePullScreen) executeCommandCmd(command models.Command) tea.Cmd {
-	return func() tea.Msg {
-		result, err := m.executor.Execute(command)
+	return m.stream.start(m.executor, command, func(result models.Result, err error) tea.Msg {
 		return imagePullResultMsg{result: result, err: err}
-	}
+	})
 }
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"strings"
+
+	"container-tui/src/models"
+)
+
+// referenceHint validates an image reference as it is typed. It returns the parse error,
+// or the normalized reference when that differs from what was typed, or "" for empty input.
+func referenceHint(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	reference, err := models.ParseImageReference(value)
+	if err != nil {
+		return RenderWarning(err.Error())
+	}
+	if normalized := reference.String(); normalized != value {
+		return RenderMuted("→ " + normalized)
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -22,6 +23,7 @@ type ImageSubmenuScreen struct {
 	cursor   int
 	errorMsg string
 	confirm  *TypeToConfirmModal
+	preview  *CommandPreviewModal
 	width    int
 }
 
@@ -34,6 +36,7 @@ func (m ImageSubmenuScreen) SetImage(image models.Image) ImageSubmenuScreen {
 	m.cursor = 0
 	m.errorMsg = ""
 	m.confirm = nil
+	m.preview = nil
 	return m
 }
 
@@ -65,12 +68,23 @@ func (m ImageSubmenuScreen) Update(msg tea.Msg) (ImageSubmenuScreen, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if m.preview != nil {
+			switch strings.ToLower(message.String()) {
+			case "y", "enter":
+				previewed := m.preview.Command
+				m.preview = nil
+				return m, m.executeCommandCmd(previewed)
+			case "n", "esc":
+				m.preview = nil
+			}
+			return m, nil
+		}
 
 		switch message.String() {
 		case "up", "k":
 			m.cursor = max(0, m.cursor-1)
 		case "down", "j":
-			m.cursor = min(2, m.cursor+1)
+			m.cursor = min(3, m.cursor+1)
 		case "esc":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenImageList} }
 		case "enter":
@@ -79,14 +93,20 @@ func (m ImageSubmenuScreen) Update(msg tea.Msg) (ImageSubmenuScreen, tea.Cmd) {
 				selected := m.image
 				return m, func() tea.Msg { return screenChangeMsg{target: ScreenImageInspect, image: &selected, push: true} }
 			case 1:
-				cmd, err := (services.ImageDeleteBuilder{ImageReference: m.image.Reference()}).Build()
+				builder := services.ImageDeleteBuilder{ImageReference: m.image.Reference()}
+				cmd, err := builder.Build()
 				if err != nil {
 					m.errorMsg = err.Error()
 					return m, nil
 				}
-				confirm := NewTypeToConfirmModal("Delete Image", "delete", cmd)
-				m.confirm = &confirm
+				m.preview, m.confirm = guardedPrompt(builder, "Delete Image", "delete", cmd)
+				if m.preview == nil && m.confirm == nil {
+					return m, m.executeCommandCmd(cmd)
+				}
 				return m, nil
+			case 2:
+				selected := m.image
+				return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerRun, image: &selected, push: true} }
 			default:
 				return m, func() tea.Msg { return screenChangeMsg{target: ScreenImageList} }
 			}
@@ -96,7 +116,7 @@ func (m ImageSubmenuScreen) Update(msg tea.Msg) (ImageSubmenuScreen, tea.Cmd) {
 }
 
 func (m ImageSubmenuScreen) View() string {
-	options := []string{"Inspect image", "Delete image", "Back"}
+	options := []string{"Inspect image", "Delete image", "Run container", "Back"}
 	builder := strings.Builder{}
 	builder.WriteString(RenderTitle("Image Actions") + "\n\n")
 
@@ -106,6 +126,16 @@ func (m ImageSubmenuScreen) View() string {
 	builder.WriteString("repository: " + m.image.Name + "\n")
 	builder.WriteString("tag: " + m.image.Tag + "\n")
 	builder.WriteString("digest: " + m.image.Digest + "\n")
+	if m.image.Platform != "" {
+		builder.WriteString("platform: " + m.image.Platform + "\n")
+	}
+	if m.image.Size > 0 {
+		builder.WriteString("size: " + FormatSize(m.image.Size) + "\n")
+	}
+	if !m.image.Created.IsZero() {
+		builder.WriteString("created: " + m.image.Created.Local().Format(time.DateTime) + "\n")
+	}
+	writeLabels(&builder, m.image.Labels)
 	builder.WriteString("\n")
 
 	// Horizontal separator
@@ -136,6 +166,9 @@ func (m ImageSubmenuScreen) View() string {
 	if m.errorMsg != "" {
 		builder.WriteString("\n" + RenderError("Error: "+m.errorMsg) + "\n")
 	}
+	if m.preview != nil {
+		builder.WriteString("\n" + m.preview.View() + "\n")
+	}
 	if m.confirm != nil {
 		builder.WriteString("\n" + m.confirm.View() + "\n")
 	}
@@ -6,6 +6,8 @@ import "github.com/charmbracelet/bubbles/key"
 type KeyMap struct {
 	Quit   key.Binding
 	Images key.Binding
+	Cancel key.Binding
+	Fix    key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -19,5 +21,13 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("i"),
 			key.WithHelp("i", "images"),
 		),
+		Cancel: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "cancel running command"),
+		),
+		Fix: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "run the suggested fix"),
+		),
 	}
 }
@@ -23,6 +23,11 @@ type MachineListScreen struct {
 	loading   bool
 	errorMsg  string
 	hasLoaded bool
+	preview   *CommandPreviewModal
+	confirm   *TypeToConfirmModal
+	selection listSelection
+	bulk      *bulkPlan
+	report    *bulkReport
 }
 
 func NewMachineListScreen(executor services.CommandExecutor) MachineListScreen {
@@ -47,11 +52,54 @@ func (m MachineListScreen) Update(msg tea.Msg) (MachineListScreen, tea.Cmd) {
 		}
 		m.errorMsg = ""
 		m.machines = message.machines
+		m.selection = m.selection.retain(m.machineIDs())
 		if m.cursor >= len(m.machines) {
 			m.cursor = max(0, len(m.machines)-1)
 		}
 		return m, nil
+	case bulkFinishedMsg:
+		if message.screen != ScreenMachineList {
+			return m, nil
+		}
+		m.loading = false
+		m.report = &message.report
+		m.selection = m.selection.keepFailed(message.report.outcomes)
+		return m, m.fetchMachinesCmd()
 	case tea.KeyMsg:
+		if m.confirm != nil {
+			updatedConfirm, confirmed, canceled := m.confirm.Handle(message)
+			m.confirm = &updatedConfirm
+			if confirmed {
+				m.confirm = nil
+				m.loading = true
+				return m.runBulk()
+			}
+			if canceled {
+				m.confirm = nil
+				m.bulk = nil
+			}
+			return m, nil
+		}
+		if m.preview != nil {
+			switch strings.ToLower(message.String()) {
+			case "y", "enter":
+				m.preview = nil
+				m.loading = true
+				return m.runBulk()
+			case "n", "esc":
+				m.preview = nil
+				m.bulk = nil
+			}
+			return m, nil
+		}
+		if m.selection.filtering {
+			m.selection = m.selection.handleFilterKey(message, m.machineIDs(), m.machineIDs())
+			return m, nil
+		}
+
+		if busyKey(m.loading, message.String(), "s", "t", "d") {
+			return m, nil
+		}
 		switch message.String() {
 		case "up", "k":
 			m.cursor = max(0, m.cursor-1)
@@ -62,7 +110,26 @@ func (m MachineListScreen) Update(msg tea.Msg) (MachineListScreen, tea.Cmd) {
 			return m, m.fetchMachinesCmd()
 		case "c":
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenMachineCreate, push: true} }
+		case " ":
+			if selected, ok := m.selectedMachine(); ok {
+				m.selection = m.selection.toggle(selected.ID)
+				m.cursor = min(len(m.machines)-1, m.cursor+1)
+			}
+		case "a":
+			m.selection = m.selection.toggleAll(m.machineIDs())
+		case "/":
+			m.selection.filtering = true
+		case "s":
+			return m.planBulk("Start", "")
+		case "t":
+			return m.planBulk("Stop", services.ActionStopMachine)
+		case "d":
+			return m.planBulk("Delete", services.ActionDeleteMachine)
 		case "esc":
+			if m.selection.count() > 0 {
+				m.selection = m.selection.clear()
+				return m, nil
+			}
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenContainerList} }
 		case "enter":
 			selected, ok := m.selectedMachine()
@@ -103,7 +170,7 @@ func (m MachineListScreen) View() string {
 				defaultValue = "yes"
 			}
 			rows[i] = TableRow{
-				Cells:    []string{machine.ID, string(machine.State), machine.Image, defaultValue},
+				Cells:    []string{m.selection.label(machine.ID, machine.ID), string(machine.State), machine.Image, defaultValue},
 				Selected: i == m.cursor,
 				Data:     &machine,
 			}
@@ -121,10 +188,98 @@ func (m MachineListScreen) View() string {
 		builder.WriteString(table.Render(tableWidth, m.cursor))
 	}
 	builder.WriteString(strings.Repeat("─", tableWidth) + "\n")
-	builder.WriteString("\n" + RenderMuted("Keys: up/down, enter=submenu, c=create, r=refresh, esc=containers, q=quit") + "\n")
+	builder.WriteString(m.selection.View(m.machineIDs(), m.machineIDs()))
+	builder.WriteString("\n" + RenderMuted("Keys: up/down, enter=submenu, space=mark, a=mark all, /=mark matching, s=start, t=stop, d=delete(!), c=create, r=refresh, esc=containers, q=quit") + "\n")
+	if m.preview != nil {
+		builder.WriteString("\n" + m.preview.View() + "\n")
+	}
+	if m.confirm != nil {
+		builder.WriteString("\n" + m.confirm.View() + "\n")
+	}
+	if m.bulk != nil {
+		builder.WriteString("\n" + m.bulk.View())
+	}
+	if m.report != nil {
+		builder.WriteString("\n" + m.report.View())
+	}
 	return builder.String()
 }
 
+// targets returns the marked machines, or the one under the cursor when none is marked.
+func (m MachineListScreen) targets() []models.ContainerMachine {
+	if m.selection.count() == 0 {
+		if selected, ok := m.selectedMachine(); ok {
+			return []models.ContainerMachine{selected}
+		}
+		return nil
+	}
+	targets := []models.ContainerMachine{}
+	for _, machine := range m.machines {
+		if m.selection.isMarked(machine.ID) {
+			targets = append(targets, machine)
+		}
+	}
+	return targets
+}
+
+// planBulk prompts for starting, stopping or deleting every marked machine. Machines
+// already in the wanted state are skipped.
+func (m MachineListScreen) planBulk(verb string, action services.DestructiveAction) (MachineListScreen, tea.Cmd) {
+	targets := m.targets()
+	plan := bulkPlan{verb: verb, noun: "Machine", action: action}
+	for _, machine := range targets {
+		var cmd models.Command
+		var err error
+		switch verb {
+		case "Start":
+			if machine.NormalizedState() == models.MachineStateRunning {
+				plan.skip(machine.ID, "already running")
+				continue
+			}
+			cmd, err = (services.MachineStartBuilder{MachineID: machine.ID}).Build()
+		case "Stop":
+			if machine.NormalizedState() != models.MachineStateRunning {
+				plan.skip(machine.ID, "not running")
+				continue
+			}
+			cmd, err = (services.MachineStopBuilder{MachineID: machine.ID}).Build()
+		case "Delete":
+			cmd, err = (services.MachineDeleteBuilder{MachineID: machine.ID}).Build()
+		}
+		plan.add(m.executor, machine.ID, []models.Command{cmd}, err)
+	}
+	m.report = nil
+	if len(plan.items) == 0 {
+		m.errorMsg = "nothing to " + strings.ToLower(plan.verb)
+		if len(plan.skipped) > 0 {
+			m.report = &bulkReport{title: plan.title(), skipped: plan.skipped}
+		}
+		return m, nil
+	}
+	m.errorMsg = ""
+	m.bulk = &plan
+	m.preview, m.confirm = plan.prompt()
+	if m.preview == nil && m.confirm == nil {
+		m.loading = true
+		return m.runBulk()
+	}
+	return m, nil
+}
+
+func (m MachineListScreen) runBulk() (MachineListScreen, tea.Cmd) {
+	plan := *m.bulk
+	m.bulk = nil
+	return m, runBulkCmd(m.executor, ScreenMachineList, plan)
+}
+
+func (m MachineListScreen) machineIDs() []string {
+	ids := make([]string, len(m.machines))
+	for i, machine := range m.machines {
+		ids[i] = machine.ID
+	}
+	return ids
+}
+
 func (m MachineListScreen) selectedMachine() (models.ContainerMachine, bool) {
 	if len(m.machines) == 0 || m.cursor < 0 || m.cursor >= len(m.machines) {
 		return models.ContainerMachine{}, false
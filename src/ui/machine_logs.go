@@ -14,13 +14,14 @@ type machineLogsLoadedMsg struct {
 	err    error
 }
 
-// MachineLogsScreen shows container machine log output.
+// MachineLogsScreen shows container machine log output as it is written.
 type MachineLogsScreen struct {
 	executor services.CommandExecutor
 	machine  models.ContainerMachine
 	loading  bool
 	errorMsg string
 	lines    []string
+	stream   *commandStream
 }
 
 func NewMachineLogsScreen(executor services.CommandExecutor) MachineLogsScreen {
@@ -28,10 +29,12 @@ func NewMachineLogsScreen(executor services.CommandExecutor) MachineLogsScreen {
 }
 
 func (m MachineLogsScreen) SetMachine(machine models.ContainerMachine) MachineLogsScreen {
+	m.stream.stop()
 	m.machine = machine
 	m.errorMsg = ""
 	m.lines = []string{}
 	m.loading = true
+	m.stream = newCommandStream()
 	return m
 }
 
@@ -44,21 +47,26 @@ func (m MachineLogsScreen) Init() tea.Cmd {
 
 func (m MachineLogsScreen) Update(msg tea.Msg) (MachineLogsScreen, tea.Cmd) {
 	switch message := msg.(type) {
+	case streamLinesMsg:
+		if !m.stream.owns(message) {
+			return m, nil
+		}
+		m.loading = false
+		m.lines = appendStreamLines(m.lines, message.lines)
+		return m, m.stream.next()
 	case machineLogsLoadedMsg:
 		m.loading = false
 		if message.err != nil {
 			m.errorMsg = services.FormatError(message.err, message.result.Stderr)
 			return m, nil
 		}
-		content := strings.TrimSpace(message.result.Stdout)
-		if content == "" {
+		if len(m.lines) == 0 {
 			m.lines = []string{"No logs available."}
-		} else {
-			m.lines = strings.Split(content, "\n")
 		}
 	case tea.KeyMsg:
 		switch message.String() {
 		case "esc", "q":
+			m.stream.stop()
 			machineCopy := m.machine
 			return m, func() tea.Msg { return screenChangeMsg{target: ScreenMachineSubmenu, machine: &machineCopy} }
 		}
@@ -84,12 +92,11 @@ func (m MachineLogsScreen) View() string {
 }
 
 func (m MachineLogsScreen) loadLogsCmd() tea.Cmd {
-	return func() tea.Msg {
-		cmd, err := (services.MachineLogsBuilder{MachineID: m.machine.ID}).Build()
-		if err != nil {
-			return machineLogsLoadedMsg{err: err}
-		}
-		result, err := m.executor.Execute(cmd)
-		return machineLogsLoadedMsg{result: result, err: err}
+	cmd, err := (services.MachineLogsBuilder{MachineID: m.machine.ID}).Build()
+	if err != nil {
+		return func() tea.Msg { return machineLogsLoadedMsg{err: err} }
 	}
+	return m.stream.start(m.executor, cmd, func(result models.Result, err error) tea.Msg {
+		return machineLogsLoadedMsg{result: result, err: err}
+	})
 }
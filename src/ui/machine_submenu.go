@@ -18,8 +18,9 @@ type machineSubmenuActionMsg struct {
 }
 
 type machineSubmenuOption struct {
-	label  string
-	action string
+	label   string
+	action  string
+	blocked string
 }
 
 // MachineSubmenuScreen displays actions for the selected container machine.
@@ -33,7 +34,9 @@ type MachineSubmenuScreen struct {
 	result   *models.Result
 	preview  *CommandPreviewModal
 	confirm  *TypeToConfirmModal
-	width    int
+	// confirmAction is the action behind confirm.
+	confirmAction string
+	width         int
 }
 
 func NewMachineSubmenuScreen(executor services.CommandExecutor) MachineSubmenuScreen {
@@ -79,7 +82,7 @@ func (m MachineSubmenuScreen) Update(msg tea.Msg) (MachineSubmenuScreen, tea.Cmd
 				command := updatedConfirm.Command
 				m.confirm = nil
 				m.loading = true
-				return m, m.executeCommandCmd("delete", command)
+				return m, m.executeCommandCmd(m.confirmAction, command)
 			}
 			if canceled {
 				m.confirm = nil
@@ -143,14 +146,11 @@ func (m MachineSubmenuScreen) View() string {
 	builder.WriteString(strings.Repeat("─", width) + "\n\n")
 	builder.WriteString(headerStyle.Render("Available Actions") + "\n")
 
-	normalStyle := lipgloss.NewStyle()
-	selectedStyle := lipgloss.NewStyle().Reverse(true)
 	for i, option := range m.options {
-		style := normalStyle
-		if i == m.cursor {
-			style = selectedStyle
-		}
-		builder.WriteString(style.Render(option.label) + "\n")
+		builder.WriteString(renderActionOption(option.label, option.blocked, i == m.cursor) + "\n")
+	}
+	if m.cursor >= 0 && m.cursor < len(m.options) && m.options[m.cursor].blocked != "" {
+		builder.WriteString(RenderWarning(m.options[m.cursor].blocked) + "\n")
 	}
 	builder.WriteString("\n")
 	builder.WriteString(strings.Repeat("─", width) + "\n")
@@ -190,10 +190,39 @@ func (m MachineSubmenuScreen) buildOptions() []machineSubmenuOption {
 	}
 	options = append(options, machineSubmenuOption{label: "Delete machine", action: "delete"})
 	options = append(options, machineSubmenuOption{label: "Back", action: "back"})
+	for i := range options {
+		options[i].blocked = m.blockedReason(options[i].action)
+	}
 	return options
 }
 
+// blockedReason checks the command behind action against the execution policy.
+func (m MachineSubmenuScreen) blockedReason(action string) string {
+	var command models.Command
+	var err error
+	switch action {
+	case "start":
+		command, err = (services.MachineStartBuilder{MachineID: m.machine.ID}).Build()
+	case "stop":
+		command, err = (services.MachineStopBuilder{MachineID: m.machine.ID}).Build()
+	case "edit":
+		// Only the subcommand matters to the policy, so placeholder resources are enough.
+		command, err = (services.MachineSetBuilder{MachineID: m.machine.ID, CPUs: "1", Memory: "1G", HomeMount: "rw"}).Build()
+	case "set-default":
+		command, err = (services.MachineSetDefaultBuilder{MachineID: m.machine.ID}).Build()
+	case "delete":
+		command, err = (services.MachineDeleteBuilder{MachineID: m.machine.ID}).Build()
+	default:
+		return ""
+	}
+	return policyBlockReason(m.executor, command, err)
+}
+
 func (m MachineSubmenuScreen) selectOption(option machineSubmenuOption) (MachineSubmenuScreen, tea.Cmd) {
+	if option.blocked != "" {
+		m.errorMsg = option.blocked
+		return m, nil
+	}
 	switch option.action {
 	case "inspect":
 		machineCopy := m.machine
@@ -216,12 +245,13 @@ func (m MachineSubmenuScreen) selectOption(option machineSubmenuOption) (Machine
 		}
 		m.preview = &CommandPreviewModal{Title: "start", Command: cmd}
 	case "stop":
-		cmd, err := (services.MachineStopBuilder{MachineID: m.machine.ID}).Build()
+		builder := services.MachineStopBuilder{MachineID: m.machine.ID}
+		cmd, err := builder.Build()
 		if err != nil {
 			m.errorMsg = err.Error()
 			return m, nil
 		}
-		m.preview = &CommandPreviewModal{Title: "stop", Command: cmd}
+		return m.promptGuarded(builder, "stop", cmd)
 	case "set-default":
 		cmd, err := (services.MachineSetDefaultBuilder{MachineID: m.machine.ID}).Build()
 		if err != nil {
@@ -230,19 +260,38 @@ func (m MachineSubmenuScreen) selectOption(option machineSubmenuOption) (Machine
 		}
 		m.preview = &CommandPreviewModal{Title: "set-default", Command: cmd}
 	case "delete":
-		cmd, err := (services.MachineDeleteBuilder{MachineID: m.machine.ID}).Build()
+		builder := services.MachineDeleteBuilder{MachineID: m.machine.ID}
+		cmd, err := builder.Build()
 		if err != nil {
 			m.errorMsg = err.Error()
 			return m, nil
 		}
-		confirm := NewTypeToConfirmModal("Delete Machine", m.machine.ID, cmd)
-		m.confirm = &confirm
+		return m.promptGuarded(builder, "delete", cmd)
 	case "back":
 		return m, func() tea.Msg { return screenChangeMsg{target: ScreenMachineList} }
 	}
 	return m, nil
 }
 
+// promptGuarded shows the confirmation the policy requires, or runs the command right away.
+// action doubles as the preview title, which is how results are routed back.
+func (m MachineSubmenuScreen) promptGuarded(builder services.GuardedBuilder, action string, cmd models.Command) (MachineSubmenuScreen, tea.Cmd) {
+	title := "Stop Machine"
+	if action == "delete" {
+		title = "Delete Machine"
+	}
+	m.preview, m.confirm = guardedPrompt(builder, title, m.machine.ID, cmd)
+	m.confirmAction = action
+	if m.preview != nil {
+		m.preview.Title = action
+	}
+	if m.preview == nil && m.confirm == nil {
+		m.loading = true
+		return m, m.executeCommandCmd(action, cmd)
+	}
+	return m, nil
+}
+
 func (m MachineSubmenuScreen) executeCommandCmd(action string, command models.Command) tea.Cmd {
 	return func() tea.Msg {
 		result, err := m.executor.Execute(command)
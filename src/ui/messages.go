@@ -40,10 +40,16 @@ const (
 	ScreenFilePicker ActiveScreen = "file-picker"
 	// ScreenBuild shows the build workflow.
 	ScreenBuild ActiveScreen = "build"
+	// ScreenContainerRun runs a new container.
+	ScreenContainerRun ActiveScreen = "container-run"
 	// ScreenContainerExport shows the container export workflow.
 	ScreenContainerExport ActiveScreen = "container-export"
 	// ScreenDaemonControl shows daemon start/stop controls.
 	ScreenDaemonControl ActiveScreen = "daemon-control"
+	// ScreenHistory shows the command history browser.
+	ScreenHistory ActiveScreen = "history"
+	// ScreenProfiles shows the connection profiles.
+	ScreenProfiles ActiveScreen = "profiles"
 	// ScreenHelp shows the help screen.
 	ScreenHelp ActiveScreen = "help"
 )
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// policyBlockReason explains why the execution policy blocks command, or returns "".
+func policyBlockReason(executor services.CommandExecutor, command models.Command, buildErr error) string {
+	if buildErr != nil {
+		return ""
+	}
+	if err := services.CheckPolicy(executor, command); err != nil {
+		return services.FormatError(err, "")
+	}
+	return ""
+}
+
+// renderActionOption renders a submenu entry, greying out entries blocked by policy.
+func renderActionOption(label string, blocked string, selected bool) string {
+	if blocked != "" {
+		label = RenderMuted(label + " (blocked)")
+	}
+	if selected {
+		return lipgloss.NewStyle().Reverse(true).Render(label)
+	}
+	return label
+}
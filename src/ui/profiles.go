@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/services"
+)
+
+// profiles are the connection profiles commands run through, or nil when the backend does
+// not use them.
+var profiles *services.ProfileSet
+
+// ApplyProfiles sets the connection profiles shown in the status bar and on the profiles
+// screen.
+func ApplyProfiles(set *services.ProfileSet) {
+	profiles = set
+}
+
+// configManager saves settings changed in the app, or is nil when they are not saved.
+var configManager *services.ConfigManager
+
+// ApplyConfigManager sets the config manager that saves settings changed in the app, such
+// as the profile picked on the profiles screen.
+func ApplyConfigManager(manager *services.ConfigManager) {
+	configManager = manager
+}
+
+// profileSessionReason says why a profile switched to in the app is kept for the session
+// only, or is "" when the switch is saved.
+var profileSessionReason string
+
+// KeepProfileSwitchesForSession stops profile switches from being saved, for example
+// because --profile picked the profile for this run. reason is shown with the switch.
+func KeepProfileSwitchesForSession(reason string) {
+	profileSessionReason = reason
+}
+
+// activeProfileName returns the name of the active profile, or "" without profiles.
+func activeProfileName() string {
+	if profiles == nil {
+		return ""
+	}
+	return profiles.Active().Name
+}
+
+type profileSwitchedMsg struct {
+	profile services.Profile
+}
+
+// profileSavedMsg reports whether the switched-to profile was saved as the default.
+type profileSavedMsg struct {
+	profile string
+	err     error
+}
+
+// saveProfileCmd stores name as the profile used when --profile is not given, unless
+// switches are kept for the session.
+func saveProfileCmd(name string) tea.Cmd {
+	if configManager == nil || profileSessionReason != "" {
+		return nil
+	}
+	manager := configManager
+	return func() tea.Msg {
+		return profileSavedMsg{profile: name, err: manager.Set("profile", []string{name})}
+	}
+}
+
+// ProfilesScreen lists the connection profiles and switches between them.
+type ProfilesScreen struct {
+	profiles []services.Profile
+	cursor   int
+	width    int
+	errorMsg string
+}
+
+// NewProfilesScreen creates the profiles screen.
+func NewProfilesScreen() ProfilesScreen {
+	return ProfilesScreen{}
+}
+
+// Init has nothing to load; Reset reads the profiles.
+func (m ProfilesScreen) Init() tea.Cmd {
+	return nil
+}
+
+// Reset reloads the profiles and places the cursor on the active one.
+func (m ProfilesScreen) Reset() ProfilesScreen {
+	m.profiles = nil
+	m.cursor = 0
+	m.errorMsg = ""
+	if profiles == nil {
+		m.errorMsg = "Profiles are not available with this backend"
+		return m
+	}
+	m.profiles = profiles.Profiles()
+	active := profiles.Active().Name
+	for index, profile := range m.profiles {
+		if profile.Name == active {
+			m.cursor = index
+		}
+	}
+	return m
+}
+
+// Update handles screen messages.
+func (m ProfilesScreen) Update(msg tea.Msg) (ProfilesScreen, tea.Cmd) {
+	switch message := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = message.Width
+		return m, nil
+	case tea.KeyMsg:
+		switch message.String() {
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = min(len(m.profiles)-1, m.cursor+1)
+		case "enter":
+			if profiles == nil || m.cursor < 0 || m.cursor >= len(m.profiles) {
+				return m, nil
+			}
+			profile, err := profiles.Use(m.profiles[m.cursor].Name)
+			if err != nil {
+				m.errorMsg = err.Error()
+				return m, nil
+			}
+			return m, func() tea.Msg { return profileSwitchedMsg{profile: profile} }
+		case "?":
+			return m, func() tea.Msg { return screenChangeMsg{target: ScreenHelp} }
+		case "esc":
+			return m, func() tea.Msg { return BackToListMsg{} }
+		}
+	}
+	return m, nil
+}
+
+// View renders the screen content.
+func (m ProfilesScreen) View() string {
+	builder := strings.Builder{}
+	builder.WriteString(RenderTitle("Profiles") + "\n\n")
+
+	table := NewTable([]TableColumn{
+		{Header: "Profile", MinWidth: 12, Priority: 1, Align: "left"},
+		{Header: "Runs", MinWidth: 20, Priority: 2, Align: "left"},
+		{Header: "Active", MinWidth: 6, Priority: 3, Align: "left"},
+	})
+	if len(m.profiles) > 0 {
+		active := activeProfileName()
+		rows := make([]TableRow, len(m.profiles))
+		for index, profile := range m.profiles {
+			marker := ""
+			if profile.Name == active {
+				marker = "*"
+			}
+			rows[index] = TableRow{Cells: []string{profile.Name, profile.Describe(), marker}, Selected: index == m.cursor}
+		}
+		table.SetRows(rows)
+	}
+
+	tableWidth := m.width
+	if tableWidth == 0 {
+		tableWidth = 80
+	}
+	builder.WriteString(table.Render(tableWidth, m.cursor))
+	builder.WriteString(strings.Repeat("─", tableWidth) + "\n")
+	if m.errorMsg != "" {
+		builder.WriteString("\n" + RenderMuted(m.errorMsg) + "\n")
+	}
+	builder.WriteString("\n" + RenderMuted("Keys: up/down=navigate, enter=switch, ?=help, esc=back") + "\n")
+	return builder.String()
+}
+
+// switchProfile returns to the container list after a profile switch and saves the profile
+// as the default when it may. The previous profile's capabilities no longer apply, so the list loads
+// once the new profile's CLI is probed.
+func (m AppModel) switchProfile(message profileSwitchedMsg) (AppModel, tea.Cmd) {
+	m.noticeID++
+	m.notice = RenderSuccess("Switched to profile " + message.profile.Name)
+	if profileSessionReason != "" {
+		m.notice = RenderSuccess("Switched to profile " + message.profile.Name + " for this session (" + profileSessionReason + ")")
+	}
+	id := m.noticeID
+	m.active = ScreenContainerList
+	m.stack = []ActiveScreen{}
+	m.containerList.loading = true
+	capabilities = services.Capabilities{}
+	load := detectCapabilitiesCmd()
+	if load == nil {
+		load = m.containerList.fetchContainersCmd(true)
+	}
+	return m, tea.Batch(load, saveProfileCmd(message.profile.Name), tea.Tick(configNoticeDuration, func(time.Time) tea.Msg {
+		return configNoticeExpiredMsg{id: id}
+	}))
+}
+
+// profileSaved keeps the switch notice, or warns that the profile was not saved.
+func (m AppModel) profileSaved(message profileSavedMsg) AppModel {
+	if message.err != nil {
+		m.noticeID++
+		m.notice = RenderWarning("Switched to profile " + message.profile + " for this session; not saved: " + message.err.Error())
+	}
+	return m
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// effectiveConfig is the merged user and project config, used for build defaults and
+// shown on the help screen.
+var effectiveConfig = services.EffectiveConfig{Config: models.DefaultUserConfig()}
+
+// ApplyEffectiveConfig sets the config used for build defaults and presets.
+func ApplyEffectiveConfig(effective services.EffectiveConfig) {
+	effectiveConfig = effective
+}
+
+// projectDir is the directory holding the project config, or the working directory.
+func projectDir() string {
+	if dir := effectiveConfig.ProjectDir(); dir != "" {
+		return dir
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	return dir
+}
+
+// defaultBuildFile returns the configured build file when it exists. Relative paths are
+// relative to the working directory.
+func defaultBuildFile() (string, bool) {
+	path := effectiveConfig.Config.DefaultBuildFile
+	if path == "" {
+		return "", false
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return path, false
+	}
+	return path, true
+}
+
+// buildContextFor returns the configured build context, or the build file's directory.
+func buildContextFor(filePath string) string {
+	if context := effectiveConfig.Config.BuildContext; context != "" {
+		return context
+	}
+	return filepath.Dir(filePath)
+}
+
+func buildPresetNames() []string {
+	names := make([]string, 0, len(effectiveConfig.Config.BuildPresets))
+	for name := range effectiveConfig.Config.BuildPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runPresetNames() []string {
+	names := make([]string, 0, len(effectiveConfig.Config.RunPresets))
+	for name := range effectiveConfig.Config.RunPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// redactor masks secrets in every command shown on screen.
+var redactor = services.DefaultRedactor()
+
+// ApplyRedactor sets the redactor used for command previews and history.
+func ApplyRedactor(r *services.Redactor) {
+	if r == nil {
+		r = services.DefaultRedactor()
+	}
+	redactor = r
+}
+
+// displayCommand formats command for display with secrets masked.
+func displayCommand(command models.Command) string {
+	return redactor.RedactCommand(command).String()
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// remedyTracker remembers the last failure that has a remedy until a later command shows
+// the remedy is no longer needed. The executor that feeds it runs commands off the UI
+// goroutine, so access is locked.
+type remedyTracker struct {
+	mu      sync.Mutex
+	pending *services.RuntimeError
+}
+
+// observe records a failed command with a remedy, or clears the pending failure when cmd
+// succeeded in a way that resolves it.
+func (t *remedyTracker) observe(cmd models.Command, result models.Result, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		if t.pending != nil && t.pending.ResolvedBy(cmd) {
+			t.pending = nil
+		}
+		return
+	}
+	if failure := services.NewRuntimeError(cmd, result, err); failure.Remedy != services.RemedyNone {
+		t.pending = failure
+	}
+}
+
+// current returns the pending failure, or nil.
+func (t *remedyTracker) current() *services.RuntimeError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.pending
+}
+
+// resolve clears failure if it is still the pending one.
+func (t *remedyTracker) resolve(failure *services.RuntimeError) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending == failure {
+		t.pending = nil
+	}
+}
+
+// remedyExecutor reports the outcome of every command to a remedyTracker.
+type remedyExecutor struct {
+	delegate services.CommandExecutor
+	tracker  *remedyTracker
+}
+
+// Execute runs the command and records its outcome.
+func (r *remedyExecutor) Execute(cmd models.Command) (models.Result, error) {
+	return r.ExecuteContext(context.Background(), cmd)
+}
+
+// ExecuteContext runs the command under ctx and records its outcome.
+func (r *remedyExecutor) ExecuteContext(ctx context.Context, cmd models.Command) (models.Result, error) {
+	result, err := services.ExecuteContext(ctx, r.delegate, cmd)
+	r.tracker.observe(cmd, result, err)
+	return result, err
+}
+
+// Stream streams the command and records its outcome.
+func (r *remedyExecutor) Stream(ctx context.Context, cmd models.Command, onLine func(services.StreamLine)) (models.Result, error) {
+	result, err := services.StreamContext(ctx, r.delegate, cmd, onLine)
+	r.tracker.observe(cmd, result, err)
+	return result, err
+}
+
+// Unwrap returns the wrapped executor.
+func (r *remedyExecutor) Unwrap() services.CommandExecutor {
+	return r.delegate
+}
+
+type remedyFinishedMsg struct {
+	failure *services.RuntimeError
+	result  models.Result
+	err     error
+}
+
+// offeredRemedy returns the pending failure when the CLI can run its fix, or nil.
+func (m AppModel) offeredRemedy() *services.RuntimeError {
+	if m.remedies == nil {
+		return nil
+	}
+	failure := m.remedies.current()
+	if failure == nil {
+		return nil
+	}
+	switch failure.Remedy {
+	case services.RemedyRegistryLogin:
+		if !supports(services.CapabilityRegistry) {
+			return nil
+		}
+	case services.RemedyStartBuilder:
+		if !supports(services.CapabilityBuilder) {
+			return nil
+		}
+	}
+	return failure
+}
+
+// runRemedy runs the fix for failure. Registry logins prompt for credentials, so they take
+// over the terminal like a container shell.
+func (m AppModel) runRemedy(failure *services.RuntimeError) tea.Cmd {
+	command, interactive, err := failure.RemedyCommand()
+	if err != nil {
+		return nil
+	}
+	if interactive {
+		process := services.InteractiveCommand(m.runner, command)
+		return tea.Exec(process, func(err error) tea.Msg {
+			return remedyFinishedMsg{failure: failure, err: err}
+		})
+	}
+	executor := m.runner
+	return func() tea.Msg {
+		result, err := executor.Execute(command)
+		return remedyFinishedMsg{failure: failure, result: result, err: err}
+	}
+}
+
+// remedyView offers the fix for the pending failure on the line above the status bar.
+func (m AppModel) remedyView() string {
+	failure := m.offeredRemedy()
+	if failure == nil {
+		return ""
+	}
+	command, _, _ := failure.RemedyCommand()
+	button := lipgloss.NewStyle().Reverse(true).Render(" " + m.keys.Fix.Help().Key + ": " + failure.Remedy.Label() + " ")
+	return RenderWarning(failure.Error()) + "  " + button + " " + RenderMuted(displayCommand(command)) + "\n"
+}
+
+// remedyDoneNotice reports a fix that succeeded.
+func remedyDoneNotice(failure *services.RuntimeError) string {
+	switch failure.Remedy {
+	case services.RemedyStartDaemon:
+		return "Daemon started"
+	case services.RemedyStartBuilder:
+		return "Builder started"
+	case services.RemedyRegistryLogin:
+		return "Logged in to " + failure.Resource.Name
+	default:
+		return failure.Remedy.Label() + " done"
+	}
+}
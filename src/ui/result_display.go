@@ -16,6 +16,8 @@ func RenderResult(result models.Result) string {
 		statusLine = RenderSuccess(statusLine)
 	case models.ResultError:
 		statusLine = RenderError(statusLine)
+	case models.ResultCanceled:
+		statusLine = RenderWarning(statusLine)
 	default:
 		statusLine = RenderMuted(statusLine)
 	}
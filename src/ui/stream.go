@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// maxStreamLines bounds how many streamed lines a screen keeps in memory.
+const maxStreamLines = 1000
+
+// maxStreamBatch bounds how many queued lines are delivered in one message.
+const maxStreamBatch = 256
+
+var nextStreamID atomic.Int64
+
+// streamLinesMsg delivers lines produced by a running command stream.
+type streamLinesMsg struct {
+	id    int64
+	lines []services.StreamLine
+}
+
+// commandStream relays output from a background command into Bubble Tea messages.
+// The producer stops when the stream is stopped, so abandoned streams never block.
+type commandStream struct {
+	id      int64
+	msgs    chan tea.Msg
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started atomic.Bool
+	pending tea.Msg
+}
+
+func newCommandStream() *commandStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &commandStream{
+		id:     nextStreamID.Add(1),
+		msgs:   make(chan tea.Msg, maxStreamBatch),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// start runs command once and waits for its first message. done builds the screen's
+// completion message from the final result.
+func (s *commandStream) start(executor services.CommandExecutor, command models.Command, done func(models.Result, error) tea.Msg) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	if !s.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	return func() tea.Msg {
+		go func() {
+			result, err := services.StreamContext(s.ctx, executor, command, func(line services.StreamLine) {
+				s.send(streamLinesMsg{id: s.id, lines: []services.StreamLine{line}})
+			})
+			s.send(done(result, err))
+			close(s.msgs)
+		}()
+		return s.wait()
+	}
+}
+
+// next waits for the following message from the stream.
+func (s *commandStream) next() tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return s.wait
+}
+
+// stop cancels the command and releases the producer.
+func (s *commandStream) stop() {
+	if s != nil {
+		s.cancel()
+	}
+}
+
+// owns reports whether msg belongs to this stream.
+func (s *commandStream) owns(msg streamLinesMsg) bool {
+	return s != nil && s.id == msg.id
+}
+
+func (s *commandStream) send(msg tea.Msg) {
+	select {
+	case s.msgs <- msg:
+	case <-s.ctx.Done():
+	}
+}
+
+// wait blocks for the next message and coalesces queued lines into one batch.
+func (s *commandStream) wait() tea.Msg {
+	msg := s.pending
+	s.pending = nil
+	if msg == nil {
+		received, ok := <-s.msgs
+		if !ok {
+			return nil
+		}
+		msg = received
+	}
+	batch, ok := msg.(streamLinesMsg)
+	if !ok {
+		return msg
+	}
+	for len(batch.lines) < maxStreamBatch {
+		select {
+		case queued, open := <-s.msgs:
+			if !open {
+				return batch
+			}
+			if lines, isLines := queued.(streamLinesMsg); isLines {
+				batch.lines = append(batch.lines, lines.lines...)
+				continue
+			}
+			s.pending = queued
+			return batch
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// appendStreamLines appends streamed text to lines, keeping at most maxStreamLines.
+func appendStreamLines(lines []string, streamed []services.StreamLine) []string {
+	for _, line := range streamed {
+		lines = append(lines, line.Text)
+	}
+	if len(lines) > maxStreamLines {
+		lines = append([]string(nil), lines[len(lines)-maxStreamLines:]...)
+	}
+	return lines
+}
+
+// renderStreamResult shows streamed output followed by the final status, falling back to the
+// full result when nothing was streamed.
+func renderStreamResult(lines []string, result models.Result) string {
+	if len(lines) == 0 {
+		return RenderResult(result)
+	}
+	return strings.Join(lines, "\n") + "\n\n" + RenderResult(models.Result{Status: result.Status})
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -238,3 +239,22 @@ func TruncateDigest(digest string) string {
 
 	return digest
 }
+
+// FormatSize renders a byte count with a binary unit, or "" when the size is unknown.
+func FormatSize(bytes int64) string {
+	if bytes <= 0 {
+		return ""
+	}
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB"}
+	index := -1
+	for value >= unit && index < len(suffixes)-1 {
+		value /= unit
+		index++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[index])
+}
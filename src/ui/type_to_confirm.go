@@ -11,11 +11,13 @@ import (
 	"container-tui/src/services"
 )
 
-// TypeToConfirmModal requires the user to type an exact value.
+// TypeToConfirmModal requires the user to type an exact value. Commands, when set,
+// lists every command a bulk action confirms.
 type TypeToConfirmModal struct {
 	Title    string
 	Expected string
 	Command  models.Command
+	Commands []models.Command
 	input    textinput.Model
 	ErrorMsg string
 }
@@ -56,7 +58,14 @@ func (m TypeToConfirmModal) Handle(msg tea.Msg) (TypeToConfirmModal, bool, bool)
 func (m TypeToConfirmModal) View() string {
 	builder := strings.Builder{}
 	builder.WriteString(RenderTitle(m.Title) + "\n\n")
-	builder.WriteString("Command: " + m.Command.String() + "\n")
+	if len(m.Commands) > 0 {
+		builder.WriteString("Commands:\n")
+		for _, command := range m.Commands {
+			builder.WriteString("  " + displayCommand(command) + "\n")
+		}
+	} else {
+		builder.WriteString("Command: " + displayCommand(m.Command) + "\n")
+	}
 	builder.WriteString(RenderWarning(fmt.Sprintf("Confirm by typing: %s", m.Expected)) + "\n\n")
 	builder.WriteString(m.input.View() + "\n")
 	if m.ErrorMsg != "" {
@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"context"
 	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -198,6 +201,22 @@ func TestContainerExportScreenPromptsForCleanupAfterSuccess(t *testing.T) {
 	}
 }
 
+func TestContainerExportCleanupTypedConfirmationMatchesHeadless(t *testing.T) {
+	policy, err := services.NewConfirmationPolicy(true, map[string]string{"export-cleanup": "type"})
+	if err != nil {
+		t.Fatalf("policy: %v", err)
+	}
+	ApplyConfirmationPolicy(policy)
+	defer ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	screen := NewContainerExportScreen(flowExecutor{}).SetContainer(models.Container{ID: "abc", Name: "web", Status: models.ContainerStatusStopped})
+	screen.input.SetValue(t.TempDir())
+	updated, _ := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	updated, _ = updated.Update(containerExportResultMsg{result: services.ExportWorkflowResult{Result: models.Result{Status: models.ResultSuccess}, ArchivePath: "/tmp/out.tar"}})
+	if updated.typed == nil || updated.typed.Expected != updated.plan.GeneratedImageRef {
+		t.Fatalf("expected the cleanup to ask for the image reference, got %#v", updated.typed)
+	}
+}
+
 func TestImagePullCancelAndError(t *testing.T) {
 	screen := NewImagePullScreen(flowExecutor{})
 	screen.input.SetValue("nginx:latest")
@@ -215,6 +234,44 @@ func TestImagePullCancelAndError(t *testing.T) {
 	}
 }
 
+func TestImageReferenceInputsValidateInline(t *testing.T) {
+	pull := NewImagePullScreen(flowExecutor{})
+	pull.input.SetValue("nginx::latest")
+	if view := pull.View(); !strings.Contains(view, "invalid repository") {
+		t.Fatalf("expected the pull screen to flag the reference, got %q", view)
+	}
+	updated, _ := pull.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.preview != nil || updated.errorMsg == "" {
+		t.Fatalf("expected an invalid reference to block the preview")
+	}
+	pull.input.SetValue("nginx")
+	if view := pull.View(); !strings.Contains(view, "docker.io/library/nginx") {
+		t.Fatalf("expected the normalized reference, got %q", view)
+	}
+
+	build := NewBuildScreen(flowExecutor{}, "Containerfile")
+	build.input.SetValue("My-App:latest")
+	if view := build.View(); !strings.Contains(view, "must be lowercase") {
+		t.Fatalf("expected the build screen to flag the tag, got %q", view)
+	}
+
+	export := NewContainerExportScreen(flowExecutor{}).SetContainer(models.Container{ID: "abc", Name: "web", Status: models.ContainerStatusStopped})
+	export.input.SetValue(t.TempDir())
+	planned, _ := export.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if planned.plan == nil || !strings.HasPrefix(planned.plan.GeneratedImageRef, "actui-export/") {
+		t.Fatalf("expected the export to use a generated image, got %#v", planned.plan)
+	}
+}
+
+func TestImageListShowsNormalizedNames(t *testing.T) {
+	screen := NewImageListScreen(flowExecutor{})
+	screen.width = 120
+	screen, _ = screen.Update(imageListLoadedMsg{images: []models.Image{{Name: "alpine", Tag: "3.19"}}})
+	if view := screen.View(); !strings.Contains(view, "docker.io/library/alpine") {
+		t.Fatalf("expected the normalized name, got %q", view)
+	}
+}
+
 func TestFilePickerWindowSize(t *testing.T) {
 	screen := NewFilePickerScreen(flowExecutor{})
 	updated, _ := screen.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
@@ -259,3 +316,465 @@ func TestThemeResolveModes(t *testing.T) {
 	ApplyTheme("auto")
 	_ = RenderWarning("warn")
 }
+
+func TestAppModelCancelKeyWhileLoading(t *testing.T) {
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	app.active = ScreenImagePull
+	app.imagePull.loading = true
+	app.spinner.SetActive(true)
+	left, _ := app.statusBarInfo()
+	if !strings.Contains(left, "ctrl+x") {
+		t.Fatalf("expected cancel hint in status bar, got %q", left)
+	}
+	model, _ := app.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	updated := model.(AppModel)
+	if updated.active != ScreenImagePull {
+		t.Fatalf("expected cancel to keep the active screen")
+	}
+	canceled := models.Result{Status: models.ResultCanceled}
+	updated.imagePull, _ = updated.imagePull.Update(imagePullResultMsg{result: canceled, err: context.Canceled})
+	if updated.imagePull.loading || updated.imagePull.errorMsg != "command canceled" {
+		t.Fatalf("expected canceled result, got %q", updated.imagePull.errorMsg)
+	}
+}
+
+func TestProfileSwitchIsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	ApplyConfigManager(services.NewConfigManagerAt(path))
+	defer ApplyConfigManager(nil)
+
+	message := saveProfileCmd("mini")()
+	saved, ok := message.(profileSavedMsg)
+	if !ok || saved.err != nil {
+		t.Fatalf("expected the profile to be saved, got %#v", message)
+	}
+	config, _, err := services.NewConfigManagerAt(path).Load()
+	if err != nil || config.Profile != "mini" {
+		t.Fatalf("expected profile mini in the config, got %q (%v)", config.Profile, err)
+	}
+
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	model, _ := app.Update(profileSavedMsg{profile: "mini", err: errors.New("read-only file system")})
+	if notice := model.(AppModel).notice; !strings.Contains(notice, "not saved") {
+		t.Fatalf("expected a warning when the profile is not saved, got %q", notice)
+	}
+
+	KeepProfileSwitchesForSession("--profile is set")
+	defer KeepProfileSwitchesForSession("")
+	if saveProfileCmd("studio") != nil {
+		t.Fatal("expected a profile picked for the run not to be saved")
+	}
+	model, _ = app.Update(profileSwitchedMsg{profile: services.Profile{Name: "studio"}})
+	if notice := model.(AppModel).notice; !strings.Contains(notice, "for this session (--profile is set)") {
+		t.Fatalf("expected the switch to say it lasts for the session, got %q", notice)
+	}
+}
+
+func TestAppModelAppliesReloadedConfig(t *testing.T) {
+	defer ApplyTheme("auto")
+	defer ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+
+	config := models.DefaultUserConfig()
+	config.ThemeMode = "dark"
+	config.Confirmations = map[string]string{"stop-container": "none"}
+	model, cmd := app.Update(ConfigChangedMsg{Change: services.ConfigChange{Path: "config", Effective: services.EffectiveConfig{Config: config}}, Restart: []string{"read_only"}})
+	updated := model.(AppModel)
+	if cmd == nil || confirmationModeFor(services.ActionStopContainer) != services.ConfirmNone {
+		t.Fatalf("expected the confirmation policy to be applied")
+	}
+	if CurrentTheme().Title.GetForeground() != ResolveTheme("dark").Title.GetForeground() {
+		t.Fatalf("expected the dark theme to be applied")
+	}
+	_, right := updated.statusBarInfo()
+	if !strings.Contains(right, "Config reloaded; restart to apply read_only") {
+		t.Fatalf("expected a reload notice, got %q", right)
+	}
+
+	problem := services.ConfigProblem{Path: "config", Line: 1, Column: 1, Key: "theme_mode", Message: "unknown theme"}
+	model, _ = updated.Update(ConfigChangedMsg{Change: services.ConfigChange{Path: "config", Problems: []services.ConfigProblem{problem}}})
+	updated = model.(AppModel)
+	_, right = updated.statusBarInfo()
+	if !strings.Contains(right, "keeping previous settings: config:1:1: theme_mode") || confirmationModeFor(services.ActionStopContainer) != services.ConfirmNone {
+		t.Fatalf("expected the previous settings to be kept, got %q", right)
+	}
+
+	// The tick from the earlier reload must not clear the newer problem notice.
+	model, _ = updated.Update(configNoticeExpiredMsg{id: 1})
+	if _, right = model.(AppModel).statusBarInfo(); right == "" {
+		t.Fatalf("expected the problem notice to stay")
+	}
+}
+
+func TestAppModelSwitchesProfiles(t *testing.T) {
+	config := models.DefaultUserConfig()
+	config.Profiles = map[string]models.ConnectionProfile{"mini": {Host: "mini.local"}}
+	set, err := services.NewProfileSet(config, "")
+	if err != nil {
+		t.Fatalf("profiles: %v", err)
+	}
+	ApplyProfiles(set)
+	defer ApplyProfiles(nil)
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	if left, _ := app.statusBarInfo(); !strings.Contains(left, "[local] Containers") {
+		t.Fatalf("expected the active profile in the status bar, got %q", left)
+	}
+
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	model, _ = model.(AppModel).Update(cmd())
+	updated := model.(AppModel)
+	if updated.active != ScreenProfiles || !strings.Contains(updated.View(), "mini.local") {
+		t.Fatalf("expected the profiles screen, got %s", updated.active)
+	}
+	model, _ = updated.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, cmd = model.(AppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if set.Active().Name != "mini" {
+		t.Fatalf("expected mini to be active, got %s", set.Active().Name)
+	}
+	model, _ = model.(AppModel).Update(cmd())
+	updated = model.(AppModel)
+	left, right := updated.statusBarInfo()
+	if updated.active != ScreenContainerList || !strings.Contains(left, "[mini]") || !strings.Contains(right, "Switched to profile mini") {
+		t.Fatalf("expected a refreshed list on the new profile, got %s %q %q", updated.active, left, right)
+	}
+}
+
+func TestAppModelHidesUnsupportedActions(t *testing.T) {
+	ApplyCapabilities(services.Capabilities{
+		VersionLine: "container CLI version 0.4.1",
+		Version:     services.CLIVersion{Minor: 4, Patch: 1},
+		Features:    map[services.Capability]bool{services.CapabilityBuilder: true},
+	}, nil)
+	defer ApplyCapabilities(services.Capabilities{}, nil)
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	if strings.Contains(app.View(), "M=machines") {
+		t.Fatal("expected the machines key to be hidden")
+	}
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	if cmd != nil || model.(AppModel).active != ScreenContainerList || !strings.Contains(model.(AppModel).View(), "container CLI 0.4.1 has no `machine` command") {
+		t.Fatal("expected M to explain that machines are unsupported")
+	}
+
+	images := NewImageListScreen(flowExecutor{})
+	if view := images.View(); strings.Contains(view, "g=registries") || !strings.Contains(view, "b=build") {
+		t.Fatalf("expected only build in the image keys, got %q", view)
+	}
+	help := HelpScreen{}.content()
+	if strings.Contains(help, "View registries") || !strings.Contains(help, "0.4.1 is older than 0.10.0") {
+		t.Fatalf("expected the compatibility report in help, got %q", help)
+	}
+}
+
+func TestAppModelReloadsListAfterCapabilitiesAreDetected(t *testing.T) {
+	ApplyCapabilities(services.Capabilities{Features: map[services.Capability]bool{services.CapabilityBuilder: true}}, nil)
+	defer ApplyCapabilities(services.Capabilities{}, nil)
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+
+	model, cmd := app.Update(capabilitiesDetectedMsg{err: errors.New("probe failed")})
+	app = model.(AppModel)
+	if capabilities.Known() || cmd == nil || !app.containerList.loading {
+		t.Fatalf("expected unknown capabilities and a list reload after a failed probe")
+	}
+
+	app.active = ScreenImageList
+	app.containerList.hasLoaded = true
+	model, cmd = app.Update(capabilitiesDetectedMsg{capabilities: services.Capabilities{Features: map[services.Capability]bool{}}})
+	app = model.(AppModel)
+	if !capabilities.Known() || cmd != nil || app.containerList.hasLoaded {
+		t.Fatalf("expected a hidden list to reload when it is next shown")
+	}
+}
+
+func TestAppModelOffersFixForDaemonErrors(t *testing.T) {
+	backend := services.NewFakeBackend()
+	if _, err := backend.Execute(models.Command{Executable: "container", Args: []string{"system", "stop"}}); err != nil {
+		t.Fatalf("stop daemon: %v", err)
+	}
+	app := NewAppModel(backend, "1.0.0")
+	model, _ := app.Update(app.Init()())
+	app = model.(AppModel)
+	if !strings.Contains(app.View(), "ctrl+r: Start daemon") {
+		t.Fatalf("expected the daemon fix to be offered, got %q", app.View())
+	}
+
+	model, cmd := app.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if cmd == nil {
+		t.Fatal("expected ctrl+r to run the fix")
+	}
+	finished, ok := cmd().(remedyFinishedMsg)
+	if !ok || finished.err != nil {
+		t.Fatalf("expected the daemon to start, got %#v", finished)
+	}
+	model, _ = model.Update(finished)
+	app = model.(AppModel)
+	if view := app.View(); strings.Contains(view, "Start daemon") || !strings.Contains(view, "Daemon started") {
+		t.Fatalf("expected the fix to be cleared after it ran, got %q", view)
+	}
+}
+
+func TestAppModelOffersRegistryLoginForAuthErrors(t *testing.T) {
+	backend := services.NewFakeBackend()
+	app := NewAppModel(backend, "1.0.0")
+	if _, err := app.runner.Execute(models.Command{Executable: "container", Args: []string{"image", "pull", "registry.example.com/team/app:1"}}); err == nil {
+		t.Fatal("expected the pull to need a login")
+	}
+	failure := app.offeredRemedy()
+	if failure == nil || failure.Remedy != services.RemedyRegistryLogin || failure.Resource.Name != "registry.example.com" {
+		t.Fatalf("expected a login for registry.example.com, got %#v", failure)
+	}
+	if !strings.Contains(app.View(), "container registry login registry.example.com") {
+		t.Fatalf("expected the login command in the fix, got %q", app.View())
+	}
+	if _, err := app.runner.Execute(models.Command{Executable: "container", Args: []string{"list", "--all"}}); err != nil || app.offeredRemedy() == nil {
+		t.Fatal("expected an unrelated command to keep the fix")
+	}
+}
+
+type refusedExecutor struct{}
+
+func (refusedExecutor) Execute(models.Command) (models.Result, error) {
+	return models.Result{ExitCode: 1, Stderr: "Error: connection refused\n", Status: models.ResultError}, errors.New("exit status 1")
+}
+
+func TestStatusBarShowsRetryProgress(t *testing.T) {
+	retries := services.NewRetryingExecutor(refusedExecutor{}, services.RetryPolicy{Attempts: 3, Backoff: time.Minute, Kinds: []services.ErrorCategory{services.ErrorNetwork}})
+	app := NewAppModel(retries, "1.0.0")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := services.ExecuteContext(ctx, app.runner, models.Command{Executable: "container", Args: []string{"list"}})
+		done <- err
+	}()
+	deadline := time.Now().Add(time.Second)
+	for _, ok := app.retryStatus(); !ok && time.Now().Before(deadline); _, ok = app.retryStatus() {
+		time.Sleep(time.Millisecond)
+	}
+	app.spinner.SetActive(true)
+	if left, _ := app.statusBarInfo(); !strings.Contains(left, "retrying (2/3)") {
+		t.Fatalf("expected the retry in the status bar, got %q", left)
+	}
+	cancel()
+	if err := <-done; !services.IsCanceled(err) {
+		t.Fatalf("expected the backoff to end on cancel, got %v", err)
+	}
+}
+
+func TestContainerRunScreenOpensFromListAndImageSubmenu(t *testing.T) {
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	model, _ := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	app = model.(AppModel)
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd == nil {
+		t.Fatal("expected n to open the run form")
+	}
+	change, ok := cmd().(screenChangeMsg)
+	if !ok || change.target != ScreenContainerRun {
+		t.Fatalf("expected the run form, got %#v", change)
+	}
+
+	submenu := NewImageSubmenuScreen(flowExecutor{}).SetImage(models.Image{Name: "alpine", Tag: "3.19"})
+	submenu.cursor = 2
+	_, cmd = submenu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	change, ok = cmd().(screenChangeMsg)
+	if !ok || change.target != ScreenContainerRun || change.image == nil {
+		t.Fatalf("expected the run form with the image, got %#v", change)
+	}
+	model, _ = app.Update(change)
+	app = model.(AppModel)
+	if app.active != ScreenContainerRun || app.containerRun.fields[0].input.Value() != "alpine:3.19" {
+		t.Fatalf("expected the image prefilled, got %q", app.containerRun.fields[0].input.Value())
+	}
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if model.(AppModel).containerRun.fields[1].input.Value() != "q" {
+		t.Fatal("expected q to be typed into the form instead of quitting")
+	}
+}
+
+func TestContainerRunScreenValidatesFieldsAndPreviews(t *testing.T) {
+	screen := NewContainerRunScreen(flowExecutor{})
+	screen.fields[0].input.SetValue("nginx")
+	screen.fields[2].input.SetValue("8080:80, http")
+	screen.fields[8].input.SetValue("app")
+	updated, _ := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.preview != nil || updated.errorMsg == "" {
+		t.Fatal("expected invalid fields to block the preview")
+	}
+	view := updated.View()
+	if !strings.Contains(view, "invalid port mapping") || !strings.Contains(view, "working directory must be an absolute path") {
+		t.Fatalf("expected field errors in the view, got %q", view)
+	}
+
+	updated.fields[2].input.SetValue("8080:80")
+	updated.fields[8].input.SetValue("/app")
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.preview == nil {
+		t.Fatalf("expected a preview, got error %q", updated.errorMsg)
+	}
+	expected := "container run --detach --workdir /app --publish 8080:80 nginx"
+	if got := updated.preview.Command.String(); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestContainerListBulkDeleteConfirmsOnceAndSummarizes(t *testing.T) {
+	screen := NewContainerListScreen(flowExecutor{result: models.Result{Status: models.ResultSuccess}})
+	screen, _ = screen.Update(containerListLoadedMsg{containers: []models.Container{
+		{ID: "web", Name: "web", Status: models.ContainerStatusStopped},
+		{ID: "db", Name: "db", Status: models.ContainerStatusStopped},
+		{ID: "cache", Name: "cache", Status: models.ContainerStatusRunning},
+	}})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if screen.selection.count() != 3 || !strings.Contains(screen.View(), "3 selected") {
+		t.Fatalf("expected every container marked, got %d", screen.selection.count())
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if screen.confirm == nil || len(screen.confirm.Commands) != 2 || screen.confirm.Expected != "delete 2" {
+		t.Fatalf("expected one type-to-confirm for both stopped containers, got %#v", screen.confirm)
+	}
+	if view := screen.View(); !strings.Contains(view, "cache: container must be stopped to delete") {
+		t.Fatalf("expected the running container skipped, got %q", view)
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("delete 2")})
+	screen, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !screen.loading {
+		t.Fatal("expected the bulk delete to run")
+	}
+	finished, ok := cmd().(bulkFinishedMsg)
+	if !ok || len(finished.report.outcomes) != 2 {
+		t.Fatalf("expected two outcomes, got %#v", finished)
+	}
+	screen, _ = screen.Update(finished)
+	if view := screen.View(); !strings.Contains(view, "Delete 2 Containers: 2 succeeded, 0 failed, 1 skipped") {
+		t.Fatalf("expected the summary, got %q", view)
+	}
+	if screen.selection.count() != 0 {
+		t.Fatal("expected marks of succeeded containers cleared")
+	}
+}
+
+func TestContainerListBulkResultArrivesAfterNavigatingAway(t *testing.T) {
+	app := NewAppModel(flowExecutor{result: models.Result{Status: models.ResultSuccess}}, "1.0.0")
+	app.containerList, _ = app.containerList.Update(containerListLoadedMsg{containers: []models.Container{
+		{ID: "web", Name: "web", Status: models.ContainerStatusRunning},
+		{ID: "db", Name: "db", Status: models.ContainerStatusRunning},
+	}})
+	app.containerList.loading = true
+	if screen, cmd := app.containerList.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}}); cmd != nil || screen.preview != nil || screen.confirm != nil {
+		t.Fatal("expected action keys to wait while the list is busy")
+	}
+
+	app.active = ScreenImageList
+	report := bulkReport{title: "Stop 2 Containers", outcomes: []services.BulkOutcome{{Name: "web"}, {Name: "db", Err: errors.New("exit status 1")}}}
+	model, _ := app.Update(bulkFinishedMsg{screen: ScreenContainerList, report: report})
+	app = model.(AppModel)
+	list := app.containerList
+	if list.loading || list.report == nil || list.hasLoaded || !list.selection.isMarked("db") || list.selection.isMarked("web") {
+		t.Fatalf("expected the container list to take its report while hidden, got loading=%v report=%v", list.loading, list.report)
+	}
+}
+
+func TestContainerListBulkExportConfirmsCleanupLikeASingleExport(t *testing.T) {
+	policy, err := services.NewConfirmationPolicy(true, map[string]string{"export-cleanup": "type"})
+	if err != nil {
+		t.Fatalf("policy: %v", err)
+	}
+	ApplyConfirmationPolicy(policy)
+	defer ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	screen := NewContainerListScreen(flowExecutor{})
+	screen, _ = screen.Update(containerListLoadedMsg{containers: []models.Container{{ID: "web", Name: "web", Status: models.ContainerStatusStopped}}})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	screen.exportTo.SetValue(t.TempDir())
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen.confirm == nil || len(screen.confirm.Commands) != 3 || !strings.HasPrefix(screen.confirm.Expected, "actui-export/web:") {
+		t.Fatalf("expected the cleanup to be confirmed with the image reference, got %v (error %q)", screen.confirm != nil, screen.errorMsg)
+	}
+
+	ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen.confirm != nil || screen.preview == nil || len(screen.preview.Commands) != 3 {
+		t.Fatalf("expected a preview of the export and cleanup, got %#v", screen.preview)
+	}
+}
+
+func TestMachineListMarksByFilterAndStartsTogether(t *testing.T) {
+	app := NewAppModel(flowExecutor{result: models.Result{Status: models.ResultSuccess}}, "1.0.0")
+	model, _ := app.Update(screenChangeMsg{target: ScreenMachineList})
+	app = model.(AppModel)
+	app.machineList, _ = app.machineList.Update(machineListLoadedMsg{machines: []models.ContainerMachine{
+		{ID: "dev", State: models.MachineStateRunning},
+		{ID: "ci-1", State: models.MachineStateStopped},
+		{ID: "ci-2", State: models.MachineStateStopped},
+	}})
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyBackspace},
+		{Type: tea.KeyRunes, Runes: []rune("CI")},
+		{Type: tea.KeyEnter},
+	} {
+		model, cmd := app.Update(key)
+		if cmd != nil {
+			if _, quit := cmd().(tea.QuitMsg); quit {
+				t.Fatal("expected q to be typed into the filter")
+			}
+		}
+		app = model.(AppModel)
+	}
+	screen := app.machineList
+	if screen.selection.count() != 2 || !screen.selection.isMarked("ci-1") || !screen.selection.isMarked("ci-2") {
+		t.Fatalf("expected the ci machines marked, got %v", screen.selection.marked)
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if screen.preview == nil || len(screen.preview.Commands) != 2 || screen.preview.Title != "Start 2 Machines" {
+		t.Fatalf("expected one preview of both starts, got %#v", screen.preview)
+	}
+}
+
+func TestImageListBulkExportPlansArchives(t *testing.T) {
+	screen := NewImageListScreen(flowExecutor{})
+	screen, _ = screen.Update(imageListLoadedMsg{images: []models.Image{{Name: "alpine", Tag: "3.19"}, {Name: "nginx", Tag: "latest"}}})
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if !screen.selection.isMarked("alpine:3.19") || screen.cursor != 1 {
+		t.Fatal("expected space to mark the image and move down")
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !screen.exporting {
+		t.Fatal("expected the destination prompt")
+	}
+	destination := t.TempDir()
+	screen.exportTo.SetValue(destination)
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen.preview == nil || len(screen.preview.Commands) != 1 {
+		t.Fatalf("expected a preview of one save, got %#v (error %q)", screen.preview, screen.errorMsg)
+	}
+	if args := screen.preview.Commands[0].Args; args[1] != "save" || !strings.HasPrefix(args[3], destination) || args[4] != "alpine:3.19" {
+		t.Fatalf("unexpected save command %v", args)
+	}
+}
+
+func TestBuildStreamKeepsDrainingWhileHelpIsOpen(t *testing.T) {
+	app := NewAppModel(flowExecutor{}, "1.0.0")
+	app.buildScreen.filePath = "./Containerfile"
+	app.buildScreen.loading = true
+	app.buildScreen.stream = newCommandStream()
+	app.active = ScreenHelp
+
+	model, cmd := app.Update(streamLinesMsg{id: app.buildScreen.stream.id, lines: []services.StreamLine{{Text: "STEP 1/2"}}})
+	app = model.(AppModel)
+	if len(app.buildScreen.lines) != 1 || cmd == nil {
+		t.Fatalf("expected the build to take its lines and ask for more, got %v", app.buildScreen.lines)
+	}
+	model, _ = app.Update(buildResultMsg{result: models.Result{Status: models.ResultSuccess}})
+	app = model.(AppModel)
+	if app.buildScreen.loading || app.active != ScreenHelp {
+		t.Fatal("expected the build to finish without leaving Help")
+	}
+
+	app.buildScreen.loading = true
+	app.buildScreen, _ = app.buildScreen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.buildScreen.preview != nil {
+		t.Fatal("expected enter to be ignored while a build runs")
+	}
+}
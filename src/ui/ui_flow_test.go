@@ -1,12 +1,14 @@
 package ui
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"container-tui/src/models"
+	"container-tui/src/services"
 )
 
 type flowExecutor struct {
@@ -284,3 +286,261 @@ func TestHelpScreenUpdate(t *testing.T) {
 		t.Fatalf("unexpected message")
 	}
 }
+
+func TestContainerLogsStreamFlow(t *testing.T) {
+	exec := flowExecutor{result: models.Result{Stdout: "line one\nline two\n", Status: models.ResultSuccess}}
+	screen := NewContainerLogsScreen(exec).SetContainer(models.Container{ID: "abc", Name: "web"})
+	cmd := screen.Init()
+	if cmd == nil {
+		t.Fatalf("expected stream cmd")
+	}
+	msg := cmd()
+	lines, ok := msg.(streamLinesMsg)
+	if !ok || len(lines.lines) == 0 {
+		t.Fatalf("expected streamed lines, got %#v", msg)
+	}
+	updated, next := screen.Update(msg)
+	for next != nil {
+		updated, next = updated.Update(next())
+	}
+	if updated.loading || len(updated.lines) != 2 || updated.lines[1] != "line two" {
+		t.Fatalf("unexpected lines: %#v", updated.lines)
+	}
+	if !strings.Contains(updated.View(), "line one") {
+		t.Fatalf("expected streamed lines in view")
+	}
+}
+
+func TestSubmenusGreyOutPolicyBlockedActions(t *testing.T) {
+	readOnly := services.NewPolicyExecutor(flowExecutor{}, services.ExecutionPolicy{ReadOnly: true})
+
+	containerScreen := NewContainerSubmenuScreen(readOnly).SetContainer(models.Container{ID: "abc", Name: "web", Status: models.ContainerStatusRunning})
+	if !strings.Contains(containerScreen.View(), "Stop container (blocked)") {
+		t.Fatalf("expected stop to be greyed out, got %s", containerScreen.View())
+	}
+	updated, cmd := containerScreen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || updated.preview != nil || !strings.Contains(updated.errorMsg, "read-only mode") {
+		t.Fatalf("expected blocked stop to explain policy, got %q", updated.errorMsg)
+	}
+
+	denied := services.NewPolicyExecutor(flowExecutor{}, services.ExecutionPolicy{Deny: []string{"machine delete"}})
+	machineScreen := NewMachineSubmenuScreen(denied).SetMachine(models.ContainerMachine{ID: "dev", State: models.MachineStateRunning})
+	view := machineScreen.View()
+	if !strings.Contains(view, "Delete machine (blocked)") || strings.Contains(view, "Stop machine (blocked)") {
+		t.Fatalf("expected only delete to be blocked, got %s", view)
+	}
+
+	daemon := NewDaemonControlScreen(readOnly)
+	stopped, _ := daemon.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if stopped.confirm != nil || !strings.Contains(stopped.errorMsg, "system stop") {
+		t.Fatalf("expected daemon stop to be blocked, got %q", stopped.errorMsg)
+	}
+}
+
+func TestGuardedActionsFollowConfirmationPolicy(t *testing.T) {
+	policy, err := services.NewConfirmationPolicy(true, map[string]string{"stop-container": "none", "delete-image": "yes-no", "stop-daemon": "type"})
+	if err != nil {
+		t.Fatalf("policy: %v", err)
+	}
+	ApplyConfirmationPolicy(policy)
+	defer ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+
+	list := NewContainerListScreen(flowExecutor{})
+	list.containers = []models.Container{{ID: "abc", Name: "web", Status: models.ContainerStatusRunning}}
+	stopped, cmd := list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil || stopped.preview != nil || stopped.confirm != nil || !stopped.loading {
+		t.Fatalf("expected stop to run without confirmation")
+	}
+
+	submenu := NewImageSubmenuScreen(flowExecutor{}).SetImage(models.Image{Name: "alpine", Tag: "latest"})
+	submenu.cursor = 1
+	deleting, _ := submenu.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if deleting.preview == nil || deleting.confirm != nil {
+		t.Fatalf("expected yes/no confirmation for image delete")
+	}
+
+	daemon, _ := NewDaemonControlScreen(flowExecutor{}).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if daemon.typed == nil || daemon.confirm != nil {
+		t.Fatalf("expected type-to-confirm for daemon stop")
+	}
+
+	help := HelpScreen{}.View()
+	if !strings.Contains(help, "Guarded Actions") || !strings.Contains(help, "Stop Container") {
+		t.Fatalf("expected guarded actions on help screen")
+	}
+}
+
+func TestHistoryScreenFiltersAndReruns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "command.log")
+	writer := services.NewLogWriterAt(path, 0)
+	entries := []services.LogEntry{
+		services.BuildLogEntry(models.Command{Executable: "container", Args: []string{"build", "--tag", "app:1", "."}}, models.Result{Status: models.ResultSuccess, Stdout: "built app:1"}, false),
+		services.BuildLogEntry(models.Command{Executable: "container", Args: []string{"start", "web"}}, models.Result{Status: models.ResultError, Stderr: "not found"}, false),
+		services.BuildLogEntry(models.Command{Executable: "container", Args: []string{"image", "prune"}}, models.Result{Status: models.ResultSuccess}, true),
+	}
+	for _, entry := range entries {
+		if err := writer.Write(entry); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	executor := services.NewLoggingExecutor(flowExecutor{result: models.Result{Status: models.ResultSuccess}}, writer, false)
+
+	screen := NewHistoryScreen(executor)
+	screen, _ = screen.Update(screen.Init()())
+	if len(screen.visible) != 3 || !strings.Contains(screen.View(), "image prune") {
+		t.Fatalf("expected three entries, got %d:\n%s", len(screen.visible), screen.View())
+	}
+
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if len(screen.visible) != 2 {
+		t.Fatalf("expected success filter to keep two entries, got %d", len(screen.visible))
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if len(screen.visible) != 1 {
+		t.Fatalf("expected dry-run filter to keep one entry, got %d", len(screen.visible))
+	}
+	for _, r := range "fffDD" {
+		screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if len(screen.visible) != 3 {
+		t.Fatalf("expected filters to cycle back to all entries, got %d", len(screen.visible))
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range "build" {
+		screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if screen.searching || len(screen.visible) != 1 {
+		t.Fatalf("expected search to narrow to the build entry, got %d", len(screen.visible))
+	}
+
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !screen.detail || !strings.Contains(screen.View(), "built app:1") {
+		t.Fatalf("expected detail view with stdout:\n%s", screen.View())
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if screen.preview == nil || screen.preview.Command.String() != "container build --tag app:1 ." {
+		t.Fatalf("expected re-run preview, got %+v", screen.preview)
+	}
+	screen, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatalf("expected re-run command")
+	}
+	screen, cmd = screen.Update(cmd())
+	if screen.result == nil || cmd == nil {
+		t.Fatalf("expected re-run result and history reload")
+	}
+	screen, _ = screen.Update(cmd())
+	if len(screen.entries) != 4 {
+		t.Fatalf("expected re-run to be logged, got %d entries", len(screen.entries))
+	}
+}
+
+func TestHistoryRerunOfGuardedCommandFollowsConfirmationPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "command.log")
+	writer := services.NewLogWriterAt(path, 0)
+	if err := writer.Write(services.BuildLogEntry(models.Command{Executable: "container", Args: []string{"machine", "delete", "dev"}}, models.Result{Status: models.ResultSuccess}, false)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	executor := services.NewLoggingExecutor(flowExecutor{result: models.Result{Status: models.ResultSuccess}}, writer, false)
+	screen := NewHistoryScreen(executor)
+	screen, _ = screen.Update(screen.Init()())
+
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if screen.preview != nil || screen.confirm == nil || screen.confirm.Expected != "dev" {
+		t.Fatalf("expected type-to-confirm for machine delete, got preview %+v confirm %+v", screen.preview, screen.confirm)
+	}
+	screen, cmd := screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd != nil || screen.loading {
+		t.Fatalf("expected y not to confirm a typed prompt")
+	}
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if screen.confirm != nil {
+		t.Fatalf("expected esc to cancel the prompt")
+	}
+}
+
+func TestHistoryRerunConfirmsAllTargetsAndSkipsOptionValues(t *testing.T) {
+	policy, err := services.NewConfirmationPolicy(true, map[string]string{"stop-container": "type"})
+	if err != nil {
+		t.Fatalf("policy: %v", err)
+	}
+	ApplyConfirmationPolicy(policy)
+	defer ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	rerun := func(args ...string) HistoryScreen {
+		path := filepath.Join(t.TempDir(), "command.log")
+		writer := services.NewLogWriterAt(path, 0)
+		if err := writer.Write(services.BuildLogEntry(models.Command{Executable: "container", Args: args}, models.Result{Status: models.ResultSuccess}, false)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		screen := NewHistoryScreen(services.NewLoggingExecutor(flowExecutor{}, writer, false))
+		screen, _ = screen.Update(screen.Init()())
+		screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+		return screen
+	}
+
+	if screen := rerun("stop", "-t", "10", "web"); screen.confirm == nil || screen.confirm.Expected != "web" {
+		t.Fatalf("expected to type only the container name, got %+v", screen.confirm)
+	}
+	if screen := rerun("delete", "--all"); screen.preview != nil || screen.confirm == nil || screen.confirm.Expected != "delete all" {
+		t.Fatalf("expected delete --all to be typed to confirm, got preview %+v confirm %+v", screen.preview, screen.confirm)
+	}
+
+	ApplyConfirmationPolicy(services.DefaultConfirmationPolicy())
+	if screen := rerun("stop", "--all"); screen.preview != nil || screen.confirm == nil || screen.confirm.Expected != "stop all" {
+		t.Fatalf("expected stop --all to need more than a yes/no, got preview %+v confirm %+v", screen.preview, screen.confirm)
+	}
+}
+
+func TestBuildScreenUsesProjectDefaultsAndPresets(t *testing.T) {
+	root := t.TempDir()
+	pull := false
+	config := models.DefaultUserConfig()
+	config.DefaultTagPattern = "{project}:dev"
+	config.BuildContext = root
+	config.BuildPresets = map[string]models.BuildPreset{
+		"api": {File: filepath.Join(root, "api", "Containerfile"), Tag: "{dir}:{date}", PullLatest: &pull},
+	}
+	ApplyEffectiveConfig(services.EffectiveConfig{
+		Config:      config,
+		ProjectPath: filepath.Join(root, "Shop", services.ProjectConfigName),
+		Settings:    []services.ConfigSetting{{Key: "default_tag_pattern", Value: `"{project}:dev"`, Source: services.SourceProject}},
+	})
+	defer ApplyEffectiveConfig(services.EffectiveConfig{Config: models.DefaultUserConfig()})
+
+	screen := NewBuildScreen(flowExecutor{}, filepath.Join(root, "Containerfile"))
+	if screen.input.Value() != "shop:dev" || screen.context != root || !screen.pullLatest {
+		t.Fatalf("unexpected defaults: tag %q, context %q", screen.input.Value(), screen.context)
+	}
+
+	screen, _ = screen.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if screen.filePath != filepath.Join(root, "api", "Containerfile") || screen.context != root || screen.pullLatest {
+		t.Fatalf("unexpected preset values: file %q, context %q", screen.filePath, screen.context)
+	}
+	if !strings.HasPrefix(screen.input.Value(), filepath.Base(root)+":") || !strings.Contains(screen.View(), "Preset: api") {
+		t.Fatalf("expected the preset tag and name, got %q:\n%s", screen.input.Value(), screen.View())
+	}
+
+	help := HelpScreen{}.View()
+	if !strings.Contains(help, "Effective Config") || !strings.Contains(help, "default_tag_pattern") || !strings.Contains(help, services.SourceProject) {
+		t.Fatalf("expected the effective config on the help screen:\n%s", help)
+	}
+}
+
+func TestHelpScreenScrolls(t *testing.T) {
+	help, _ := HelpScreen{}.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	top := help.View()
+	if lines := strings.Count(top, "\n"); lines != 9 {
+		t.Fatalf("expected 9 lines above the status bar, got %d", lines)
+	}
+	help, _ = help.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if help.offset != 1 || help.View() == top {
+		t.Fatalf("expected down to scroll")
+	}
+	for range 200 {
+		help, _ = help.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	}
+	if !strings.Contains(help.View(), "esc or ? to return") {
+		t.Fatalf("expected the end of the help at the last page:\n%s", help.View())
+	}
+}
@@ -36,7 +36,7 @@ func (m YesNoConfirmModal) View() string {
 	if m.Body != "" {
 		builder.WriteString(m.Body + "\n\n")
 	}
-	builder.WriteString("Command: " + m.Command.String() + "\n")
+	builder.WriteString("Command: " + displayCommand(m.Command) + "\n")
 	builder.WriteString(RenderMuted("Confirm (y/n)") + "\n")
 	if m.Warning {
 		builder.WriteString("\n" + RenderWarning("Warning: destructive action!") + "\n")
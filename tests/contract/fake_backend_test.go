@@ -0,0 +1,84 @@
+package contract
+
+import (
+	"testing"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+func mustBuild(t *testing.T, builder interface {
+	Build() (models.Command, error)
+}) models.Command {
+	t.Helper()
+	cmd, err := builder.Build()
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+	return cmd
+}
+
+func TestFakeBackendOutputParses(t *testing.T) {
+	backend := services.NewFakeBackend()
+
+	result, err := backend.Execute(mustBuild(t, services.ListContainersBuilder{}))
+	if err != nil {
+		t.Fatalf("list containers: %v", err)
+	}
+	containers, err := services.ParseContainerList(result.Stdout)
+	if err != nil || len(containers) == 0 {
+		t.Fatalf("expected parsable containers, got %v (%v)", containers, err)
+	}
+	for _, container := range containers {
+		if container.Status == models.ContainerStatusUnknown {
+			t.Fatalf("expected known status for %s", container.ID)
+		}
+	}
+
+	result, err = backend.Execute(mustBuild(t, services.ImageListBuilder{}))
+	if err != nil {
+		t.Fatalf("list images: %v", err)
+	}
+	images, err := services.ParseImageList(result.Stdout)
+	if err != nil || len(images) == 0 {
+		t.Fatalf("expected parsable images, got %v (%v)", images, err)
+	}
+
+	result, err = backend.Execute(mustBuild(t, services.MachineListBuilder{}))
+	if err != nil {
+		t.Fatalf("list machines: %v", err)
+	}
+	if machines, err := services.ParseMachineList(result.Stdout); err != nil || len(machines) == 0 {
+		t.Fatalf("expected parsable machines, got %v (%v)", machines, err)
+	}
+
+	result, err = backend.Execute(mustBuild(t, services.RegistryListBuilder{}))
+	if err != nil {
+		t.Fatalf("list registries: %v", err)
+	}
+	if registries, err := services.ParseRegistryList(result.Stdout); err != nil || len(registries) == 0 {
+		t.Fatalf("expected parsable registries, got %v (%v)", registries, err)
+	}
+
+	result, err = backend.Execute(mustBuild(t, services.CheckDaemonStatusBuilder{}))
+	if err != nil {
+		t.Fatalf("daemon status: %v", err)
+	}
+	if status := services.ParseDaemonStatus(result.Stdout); !status.Running {
+		t.Fatalf("expected running daemon, got %+v", status)
+	}
+}
+
+func TestFakeBackendShellDetection(t *testing.T) {
+	detector := services.NewShellDetector(services.NewFakeBackend())
+	shell, err := detector.DetectShell("web")
+	if err != nil {
+		t.Fatalf("expected shell, got %v", err)
+	}
+	if shell != "bash" {
+		t.Fatalf("expected bash for nginx container, got %q", shell)
+	}
+	if _, err := detector.DetectShell("scratchpad"); err == nil {
+		t.Fatal("expected stopped container to have no shell")
+	}
+}
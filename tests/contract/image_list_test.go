@@ -20,3 +20,20 @@ func TestImageListBuilderBuildsCommand(t *testing.T) {
 		t.Fatalf("unexpected args: %v", cmd.Args)
 	}
 }
+
+func TestImageListBuilderUsesJSONWhenSupported(t *testing.T) {
+	capabilities := services.Capabilities{Features: map[services.Capability]bool{services.CapabilityImageListJSON: true}}
+	builder := services.ImageListBuilder{Capabilities: capabilities}
+	backend := services.NewFakeBackend()
+	result, err := backend.Execute(mustBuild(t, builder))
+	if err != nil {
+		t.Fatalf("list images: %v", err)
+	}
+	images, err := builder.Parse(result.Stdout)
+	if err != nil || len(images) != 4 {
+		t.Fatalf("expected four images, got %v (%v)", images, err)
+	}
+	if images[0].Name != "docker.io/library/nginx" || images[0].Tag != "latest" || images[0].Platform != "linux/arm64" || images[0].Size == 0 || images[0].Created.IsZero() {
+		t.Fatalf("expected the JSON details of nginx, got %+v", images[0])
+	}
+}
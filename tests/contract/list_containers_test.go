@@ -26,3 +26,29 @@ func TestListContainersBuilderBuildsListAll(t *testing.T) {
 		t.Fatalf("expected args [list --all], got %v", cmd.Args)
 	}
 }
+
+func TestListContainersBuilderUsesJSONWhenSupported(t *testing.T) {
+	capabilities := services.Capabilities{Features: map[services.Capability]bool{services.CapabilityContainerListJSON: true}}
+	builder := services.ListContainersBuilder{Capabilities: capabilities}
+	cmd, err := builder.Build()
+	if err != nil {
+		t.Fatalf("expected no build error, got %v", err)
+	}
+	if !reflect.DeepEqual(cmd.Args, []string{"list", "--all", "--format", "json"}) {
+		t.Fatalf("expected json output args, got %v", cmd.Args)
+	}
+
+	backend := services.NewFakeBackend()
+	result, err := backend.Execute(cmd)
+	if err != nil {
+		t.Fatalf("list containers: %v", err)
+	}
+	containers, err := builder.Parse(result.Stdout)
+	if err != nil || len(containers) != 3 {
+		t.Fatalf("expected three containers, got %v (%v)", containers, err)
+	}
+	web := containers[0]
+	if web.Name != "web" || web.Platform != "linux/arm64" || len(web.Networks) != 1 || web.Networks[0].Address == "" || web.CreatedAt.IsZero() {
+		t.Fatalf("expected the JSON details of web, got %+v", web)
+	}
+}
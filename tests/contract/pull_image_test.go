@@ -34,3 +34,11 @@ func TestPullImageBuilderBuildsCommand(t *testing.T) {
 		t.Fatalf("expected args [image pull nginx:latest], got %v", cmd.Args)
 	}
 }
+
+func TestPullImageBuilderRejectsInvalidReference(t *testing.T) {
+	for _, reference := range []string{"nginx::latest", "Registry.io/UPPER", "nginx@sha256:abc"} {
+		if _, err := (services.PullImageBuilder{Reference: reference}).Build(); err == nil {
+			t.Fatalf("expected %q to be rejected", reference)
+		}
+	}
+}
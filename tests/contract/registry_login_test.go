@@ -0,0 +1,27 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"container-tui/src/services"
+)
+
+func TestRegistryLoginBuilderBuildsCommand(t *testing.T) {
+	cmd, err := services.RegistryLoginBuilder{Server: " ghcr.io "}.Build()
+	if err != nil {
+		t.Fatalf("expected no build error, got %v", err)
+	}
+	if !reflect.DeepEqual(cmd.Args, []string{"registry", "login", "ghcr.io"}) {
+		t.Fatalf("expected registry login ghcr.io, got %v", cmd.Args)
+	}
+}
+
+func TestRegistryLoginBuilderRequiresServer(t *testing.T) {
+	if err := (services.RegistryLoginBuilder{}).Validate(); err == nil {
+		t.Fatal("expected an error without a server")
+	}
+	if _, err := (services.RegistryLoginBuilder{Server: "ghcr.io extra"}).Build(); err == nil {
+		t.Fatal("expected an error for a server with whitespace")
+	}
+}
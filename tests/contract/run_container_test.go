@@ -0,0 +1,86 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+func TestRunContainerBuilderRequiresImage(t *testing.T) {
+	if err := (services.RunContainerBuilder{}).Validate(); err == nil {
+		t.Fatalf("expected validation error for empty image")
+	}
+}
+
+func TestRunContainerBuilderBuildsCommand(t *testing.T) {
+	builder := services.RunContainerBuilder{
+		Image:      "nginx",
+		Name:       "web",
+		Detach:     true,
+		Ports:      []models.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}, {HostPort: 5353, ContainerPort: 53, Protocol: "udp"}},
+		Env:        []string{"DEBUG=1"},
+		EnvFiles:   []string{".env"},
+		Volumes:    []models.Mount{{Source: "/srv/data", Destination: "/data"}, {Source: "cache", Destination: "/cache", ReadOnly: true}},
+		CPUs:       2,
+		Memory:     "1G",
+		Workdir:    "/app",
+		Entrypoint: "/bin/sh",
+		Command:    []string{"-c", "echo hello"},
+		Labels:     []string{"team=web"},
+	}
+
+	cmd, err := builder.Build()
+	if err != nil {
+		t.Fatalf("expected no build error, got %v", err)
+	}
+	expected := []string{
+		"run", "--name", "web", "--detach", "--cpus", "2", "--memory", "1G", "--workdir", "/app", "--entrypoint", "/bin/sh",
+		"--env", "DEBUG=1", "--env-file", ".env", "--publish", "8080:80", "--publish", "5353:53/udp",
+		"--volume", "/srv/data:/data", "--volume", "cache:/cache:ro", "--label", "team=web",
+		"nginx", "-c", "echo hello",
+	}
+	if cmd.Executable != "container" || !reflect.DeepEqual(cmd.Args, expected) {
+		t.Fatalf("expected args %v, got %v", expected, cmd.Args)
+	}
+}
+
+func TestRunContainerBuilderReportsFieldErrors(t *testing.T) {
+	builder := services.RunContainerBuilder{
+		Image:   "nginx::latest",
+		Name:    "-web",
+		Ports:   []models.PortMapping{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}, {HostPort: 8080, ContainerPort: 81, Protocol: "tcp"}},
+		Env:     []string{"1BAD=x"},
+		Volumes: []models.Mount{{Source: "data", Destination: "relative"}},
+		Memory:  "lots",
+		Workdir: "app",
+		Labels:  []string{"novalue"},
+	}
+
+	problems := builder.FieldErrors()
+	for _, field := range []services.RunField{
+		services.RunFieldImage, services.RunFieldName, services.RunFieldPorts, services.RunFieldEnv,
+		services.RunFieldVolumes, services.RunFieldMemory, services.RunFieldWorkdir, services.RunFieldLabels,
+	} {
+		if problems[field] == nil {
+			t.Errorf("expected a problem for %s", field)
+		}
+	}
+	if problems[services.RunFieldCPUs] != nil || problems[services.RunFieldCommand] != nil {
+		t.Fatalf("expected no problems for untouched fields, got %v", problems)
+	}
+	if _, err := builder.Build(); err == nil {
+		t.Fatalf("expected build to fail")
+	}
+}
+
+func TestParseRunCommandHandlesQuotes(t *testing.T) {
+	words, err := services.ParseRunCommand(`-c "echo hello"`)
+	if err != nil || !reflect.DeepEqual(words, []string{"-c", "echo hello"}) {
+		t.Fatalf("expected [-c echo hello], got %v (%v)", words, err)
+	}
+	if _, err := services.ParseRunCommand(`-c "echo`); err == nil {
+		t.Fatalf("expected an unterminated quote to fail")
+	}
+}
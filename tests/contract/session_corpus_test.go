@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+// TestSessionCorpusParses runs every recorded listing through its parser. Drop sessions
+// captured with `actui --record` into testdata/sessions to grow the corpus.
+func TestSessionCorpusParses(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "sessions", "*.jsonl"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("expected at least one recorded session")
+	}
+	for _, path := range paths {
+		entries, err := services.ReadFixture(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		for _, entry := range entries {
+			if entry.ExitCode != 0 {
+				continue
+			}
+			command := strings.Join(entry.Args, " ")
+			switch {
+			case command == "list --all":
+				if _, err := services.ParseContainerList(entry.Stdout); err != nil {
+					t.Errorf("%s: container list: %v", path, err)
+				}
+			case command == "image list":
+				if _, err := services.ParseImageList(entry.Stdout); err != nil {
+					t.Errorf("%s: image list: %v", path, err)
+				}
+			case command == "machine list --format json":
+				if _, err := services.ParseMachineList(entry.Stdout); err != nil {
+					t.Errorf("%s: machine list: %v", path, err)
+				}
+			case command == "registry list --format json":
+				if _, err := services.ParseRegistryList(entry.Stdout); err != nil {
+					t.Errorf("%s: registry list: %v", path, err)
+				}
+			case command == "system status --format json":
+				if status := services.ParseDaemonStatus(entry.Stdout); status.State == models.DaemonStateUnknown {
+					t.Errorf("%s: daemon status not recognized: %q", path, entry.Stdout)
+				}
+			}
+		}
+	}
+}
+
+func TestReplayServesRecordedSession(t *testing.T) {
+	entries, err := services.ReadFixture(filepath.Join("testdata", "sessions", "fake-backend.jsonl"))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	replay := services.NewReplayExecutor(entries, nil)
+
+	first, _ := replay.Execute(mustBuild(t, services.ListContainersBuilder{}))
+	second, _ := replay.Execute(mustBuild(t, services.ListContainersBuilder{}))
+	before, _ := services.ParseContainerList(first.Stdout)
+	after, _ := services.ParseContainerList(second.Stdout)
+	if before[0].Status == after[0].Status {
+		t.Fatalf("expected repeated list to replay the later recording, got %s twice", before[0].Status)
+	}
+	if len(replay.Drift()) != 0 {
+		t.Fatalf("expected no drift, got %v", replay.Drift())
+	}
+}
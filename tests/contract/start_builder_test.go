@@ -0,0 +1,18 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"container-tui/src/services"
+)
+
+func TestStartBuilderBuilderBuildsCommand(t *testing.T) {
+	cmd, err := services.StartBuilderBuilder{}.Build()
+	if err != nil {
+		t.Fatalf("expected no build error, got %v", err)
+	}
+	if cmd.Executable != "container" || !reflect.DeepEqual(cmd.Args, []string{"builder", "start"}) {
+		t.Fatalf("expected container builder start, got %s", cmd.String())
+	}
+}
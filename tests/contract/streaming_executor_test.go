@@ -0,0 +1,28 @@
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+func TestRealExecutorStreamsOutput(t *testing.T) {
+	var executor services.StreamingExecutor = services.RealExecutor{}
+	cmd := models.Command{Executable: "/bin/sh", Args: []string{"-c", "echo first; echo second"}}
+
+	var lines []string
+	result, err := executor.Stream(context.Background(), cmd, func(line services.StreamLine) {
+		lines = append(lines, line.Text)
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != models.ResultSuccess {
+		t.Fatalf("expected success status, got %s", result.Status)
+	}
+	if len(lines) != 2 || lines[0] != "first" || lines[1] != "second" {
+		t.Fatalf("expected streamed lines in order, got %#v", lines)
+	}
+}
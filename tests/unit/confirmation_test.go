@@ -28,3 +28,33 @@ func TestTypeToConfirmValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmationPolicyModes(t *testing.T) {
+	policy, err := services.NewConfirmationPolicy(true, map[string]string{"stop-container": "none", "export-cleanup": "type"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := map[services.DestructiveAction]services.ConfirmationMode{
+		services.ActionStopContainer:   services.ConfirmNone,
+		services.ActionExportCleanup:   services.ConfirmTypeToConfirm,
+		services.ActionDeleteContainer: services.ConfirmTypeToConfirm,
+		services.ActionStopDaemon:      services.ConfirmYesNo,
+	}
+	for action, want := range cases {
+		if got := policy.ModeFor(action); got != want {
+			t.Fatalf("%s: expected %s, got %s", action, want, got)
+		}
+	}
+
+	disabled, _ := services.NewConfirmationPolicy(false, map[string]string{"delete-machine": "type"})
+	if disabled.ModeFor(services.ActionPruneImages) != services.ConfirmNone {
+		t.Fatalf("expected disabled confirmations to skip prompts")
+	}
+	if disabled.ModeFor(services.ActionDeleteMachine) != services.ConfirmTypeToConfirm {
+		t.Fatalf("expected explicit override to survive the global switch")
+	}
+
+	if _, err := services.NewConfirmationPolicy(true, map[string]string{"delete-everything": "none", "stop-machine": "maybe"}); err == nil {
+		t.Fatalf("expected invalid confirmations to be reported")
+	}
+}
@@ -3,6 +3,7 @@ package unit
 import (
 	"testing"
 
+	"container-tui/src/models"
 	"container-tui/src/services"
 )
 
@@ -18,3 +19,83 @@ func TestDestructiveActionMetadata(t *testing.T) {
 		t.Fatalf("expected stop daemon metadata")
 	}
 }
+
+func TestGuardedBuildersDeclareKnownActions(t *testing.T) {
+	metadata := services.DestructiveActionMetadata()
+	builders := []services.GuardedBuilder{
+		services.DeleteContainerBuilder{},
+		services.StopContainerBuilder{},
+		services.StopDaemonBuilder{},
+		services.ImageDeleteBuilder{},
+		services.ImagePruneBuilder{},
+		services.MachineDeleteBuilder{},
+		services.MachineStopBuilder{},
+	}
+	for _, builder := range builders {
+		if _, ok := metadata[builder.GuardedAction()]; !ok {
+			t.Fatalf("missing metadata for %s", builder.GuardedAction())
+		}
+	}
+	if len(services.DestructiveActions()) != len(metadata) {
+		t.Fatalf("expected every action to be listed")
+	}
+}
+
+func TestCommandActionMatchesGuardedBuilders(t *testing.T) {
+	type guardedCommandBuilder interface {
+		services.GuardedBuilder
+		services.CommandBuilder
+	}
+	builders := []guardedCommandBuilder{
+		services.DeleteContainerBuilder{ContainerID: "web"},
+		services.StopContainerBuilder{ContainerID: "web"},
+		services.StopDaemonBuilder{},
+		services.ImageDeleteBuilder{ImageReference: "alpine:3"},
+		services.ImagePruneBuilder{},
+		services.MachineDeleteBuilder{MachineID: "dev"},
+		services.MachineStopBuilder{MachineID: "dev"},
+	}
+	for _, builder := range builders {
+		command, err := builder.Build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		guard, ok := services.CommandAction(command)
+		if !ok || guard.Action != builder.GuardedAction() || guard.Expected == "" || guard.AllTargets {
+			t.Fatalf("expected %s for %s, got %+v %v", builder.GuardedAction(), command, guard, ok)
+		}
+	}
+	for _, args := range [][]string{{"list", "--all"}, {"start", "web"}, {"image", "pull", "alpine"}, {"system", "start"}, {"stop"}} {
+		if guard, ok := services.CommandAction(models.Command{Executable: "container", Args: args}); ok {
+			t.Fatalf("expected %v to be unguarded, got %s", args, guard.Action)
+		}
+	}
+}
+
+func TestCommandActionReadsTargetsAndAll(t *testing.T) {
+	cases := []struct {
+		args     []string
+		action   services.DestructiveAction
+		expected string
+		all      bool
+	}{
+		{[]string{"stop", "-t", "10", "web"}, services.ActionStopContainer, "web", false},
+		{[]string{"stop", "--signal=SIGKILL", "web", "db"}, services.ActionStopContainer, "web db", false},
+		{[]string{"delete", "--force", "web"}, services.ActionDeleteContainer, "web", false},
+		{[]string{"delete", "--all"}, services.ActionDeleteContainer, "delete all", true},
+		{[]string{"stop", "-a"}, services.ActionStopContainer, "stop all", true},
+		{[]string{"image", "prune", "--all"}, services.ActionPruneImages, "prune all", true},
+		{[]string{"image", "rm", "-a"}, services.ActionDeleteImage, "rm all", true},
+	}
+	for _, tc := range cases {
+		guard, ok := services.CommandAction(models.Command{Executable: "container", Args: tc.args})
+		if !ok || guard.Action != tc.action || guard.Expected != tc.expected || guard.AllTargets != tc.all {
+			t.Fatalf("%v: got %+v %v", tc.args, guard, ok)
+		}
+	}
+
+	guard := services.CommandGuard{Action: services.ActionStopContainer, AllTargets: true}
+	if guard.Mode(services.ConfirmYesNo) != services.ConfirmTypeToConfirm || guard.Mode(services.ConfirmNone) != services.ConfirmNone {
+		t.Fatalf("expected all targets to need a typed confirmation unless turned off")
+	}
+}
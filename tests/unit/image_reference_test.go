@@ -1,6 +1,7 @@
 package unit
 
 import (
+	"strings"
 	"testing"
 
 	"container-tui/src/models"
@@ -47,3 +48,57 @@ func TestImageReferenceValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseImageReferenceNormalizes(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	cases := []struct {
+		reference string
+		want      models.ImageReference
+	}{
+		{"nginx", models.ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx:1.25", models.ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"acme/api:v2", models.ImageReference{Registry: "docker.io", Repository: "acme/api", Tag: "v2"}},
+		{"index.docker.io/library/alpine", models.ImageReference{Registry: "docker.io", Repository: "library/alpine"}},
+		{"localhost:5000/team/tools/cli:dev", models.ImageReference{Registry: "localhost:5000", Repository: "team/tools/cli", Tag: "dev"}},
+		{"ghcr.io/acme/api@" + digest, models.ImageReference{Registry: "ghcr.io", Repository: "acme/api", Digest: digest}},
+		{"ghcr.io/acme/api:v1@" + digest, models.ImageReference{Registry: "ghcr.io", Repository: "acme/api", Tag: "v1", Digest: digest}},
+		{"[::1]:5000/app", models.ImageReference{Registry: "[::1]:5000", Repository: "app"}},
+		{"my_org/my__app-v2.1", models.ImageReference{Registry: "docker.io", Repository: "my_org/my__app-v2.1"}},
+	}
+	for _, testCase := range cases {
+		got, err := models.ParseImageReference(testCase.reference)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", testCase.reference, err)
+		}
+		if got != testCase.want {
+			t.Fatalf("%s: expected %+v, got %+v", testCase.reference, testCase.want, got)
+		}
+		if _, err := models.ParseImageReference(got.String()); err != nil {
+			t.Fatalf("%s: normalized form %q does not parse: %v", testCase.reference, got.String(), err)
+		}
+	}
+}
+
+func TestParseImageReferenceRejectsInvalidReferences(t *testing.T) {
+	cases := map[string]string{
+		"":                       "required",
+		"nginx latest":           "whitespace",
+		"nginx::latest":          "invalid repository",
+		"nginx:":                 "invalid tag",
+		"nginx:-dev":             "invalid tag",
+		"Registry.io/UPPER":      "must be lowercase",
+		"acme/API":               "must be lowercase",
+		"bad_host.io/app":        "invalid registry host",
+		"acme//api":              "invalid repository",
+		"acme/api-":              "invalid repository",
+		"nginx@sha256:abc":       "invalid digest",
+		"nginx@md5":              "invalid digest",
+		strings.Repeat("a", 256): "longer than 255",
+	}
+	for reference, want := range cases {
+		_, err := models.ParseImageReference(reference)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%q: expected error containing %q, got %v", reference, want, err)
+		}
+	}
+}
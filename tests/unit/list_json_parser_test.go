@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"container-tui/src/models"
+	"container-tui/src/services"
+)
+
+func TestParseContainerListJSON(t *testing.T) {
+	output := `[{"status":"running","configuration":{"id":"web","image":{"reference":"docker.io/library/nginx:latest"},
+		"labels":{"app":"web"},"platform":{"os":"linux","architecture":"arm64"},
+		"mounts":[{"source":"/Users/demo/site","destination":"/usr/share/nginx/html","options":["ro"]}],
+		"publishedPorts":[{"hostAddress":"0.0.0.0","hostPort":8080,"containerPort":80,"proto":"tcp"}]},
+		"networks":[{"network":"default","address":"192.168.64.2/24","hostname":"web"}],
+		"creationDate":790000000}]`
+	containers, err := services.ParseContainerListJSON(output)
+	if err != nil || len(containers) != 1 {
+		t.Fatalf("expected one container, got %v (%v)", containers, err)
+	}
+	web := containers[0]
+	if web.ID != "web" || web.Status != models.ContainerStatusRunning || web.Labels["app"] != "web" || web.Platform != "linux/arm64" {
+		t.Fatalf("unexpected container %+v", web)
+	}
+	if len(web.Mounts) != 1 || !web.Mounts[0].ReadOnly || len(web.Ports) != 1 || web.Ports[0].HostPort != 8080 {
+		t.Fatalf("unexpected mounts or ports %+v %+v", web.Mounts, web.Ports)
+	}
+	// Foundation encodes dates as seconds since 2001-01-01.
+	if want := time.Date(2026, 1, 13, 12, 26, 40, 0, time.UTC); !web.CreatedAt.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, web.CreatedAt)
+	}
+}
+
+func TestParseImageListJSON(t *testing.T) {
+	output := `[{"reference":"ghcr.io/acme/api@sha256:abc","descriptor":{"digest":"sha256:abc","size":512},
+		"variants":[{"platform":{"os":"linux","architecture":"arm64"},"size":1000,"config":{"created":"2026-01-10T12:00:00Z","config":{"Labels":{"team":"api"}}}},
+		{"platform":{"os":"linux","architecture":"amd64"},"size":2000}]}]`
+	images, err := services.ParseImageListJSON(output)
+	if err != nil || len(images) != 1 {
+		t.Fatalf("expected one image, got %v (%v)", images, err)
+	}
+	image := images[0]
+	if image.Name != "ghcr.io/acme/api" || image.Tag != "<none>" || image.Platform != "linux/arm64, linux/amd64" || image.Size != 3000 || image.Labels["team"] != "api" {
+		t.Fatalf("unexpected image %+v", image)
+	}
+}
+
+func TestListBuilderParseErrorsNameFormatAndVersion(t *testing.T) {
+	capabilities := services.Capabilities{
+		Version:  services.CLIVersion{Major: 1, Minor: 2},
+		Features: map[services.Capability]bool{services.CapabilityImageListJSON: true},
+	}
+	_, err := services.ImageListBuilder{Capabilities: capabilities}.Parse(`[{"reference": 7}]`)
+	var parseErr *services.OutputParseError
+	if !errors.As(err, &parseErr) || !strings.Contains(err.Error(), "`container image list` json output from container CLI 1.2.0") {
+		t.Fatalf("expected a json parse error naming the version, got %v", err)
+	}
+
+	// A CLI that ignores --format prints its table, which the text parser still reads.
+	images, err := services.ImageListBuilder{Capabilities: capabilities}.Parse("NAME TAG DIGEST\nubuntu latest sha256:abc123\n")
+	if err != nil || len(images) != 1 {
+		t.Fatalf("expected the table fallback, got %v (%v)", images, err)
+	}
+	_, err = services.ListContainersBuilder{}.Parse("BROKEN\nvalue")
+	if err == nil || !strings.Contains(err.Error(), "table output from container CLI of unknown version") {
+		t.Fatalf("expected a table parse error, got %v", err)
+	}
+}